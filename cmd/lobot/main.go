@@ -10,12 +10,16 @@ import (
 	"syscall"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles-w-3/lobot/internal/config"
 	"github.com/miles-w-3/lobot/internal/k8s"
 	"github.com/miles-w-3/lobot/internal/ui"
 	"k8s.io/klog/v2"
 )
 
 func main() {
+	readOnly := flag.Bool("read-only", false, "refuse all changes to clusters: edits, deletes, scaling, exec and other actions")
+	flag.Parse()
+
 	// Initialize slog to write to out.log (overwrites on each run)
 	logFile, err := os.Create("out.log")
 	if err != nil {
@@ -31,7 +35,7 @@ func main() {
 
 	slog.Info("Lobot starting")
 
-	if err := run(); err != nil {
+	if err := run(*readOnly); err != nil {
 		slog.Error("Application error", "error", err)
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -40,7 +44,7 @@ func main() {
 	slog.Info("Lobot exiting")
 }
 
-func run() error {
+func run(readOnly bool) error {
 	logger := slog.Default()
 
 	// Create context for graceful shutdown
@@ -80,8 +84,23 @@ func run() error {
 	fs.Set("stderrthreshold", "FATAL") // Only FATAL logs go to stderr
 	fs.Set("alsologtostderr", "false")
 
+	// Load user configuration (custom columns, views and protected contexts)
+	configPath, err := config.DefaultPath()
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+
+	protection, err := k8s.NewWriteProtection(readOnly, cfg.ProtectedContexts)
+	if err != nil {
+		return err
+	}
+
 	// Initialize Kubernetes client
-	client, err := k8s.NewClient(logger)
+	client, err := k8s.NewClient(logger, protection)
 	if err != nil {
 		return fmt.Errorf("failed to create Kubernetes client: %w", err)
 	}
@@ -92,9 +111,10 @@ func run() error {
 		return fmt.Errorf("failed to create resource service: %w", err)
 	}
 	defer resourceService.Close()
+	resourceService.SetHelmSQLConnections(cfg.HelmSQLConnections())
 
 	// Create UI model
-	model := ui.NewModel(resourceService, logger, errorTracker)
+	model := ui.NewModel(resourceService, cfg, logger, errorTracker)
 
 	// Create Bubbletea program
 	p := tea.NewProgram(
@@ -115,6 +135,8 @@ func run() error {
 			logger.Error("Resource service error", "error", update.Error)
 			// Send error to UI instead of just logging
 			p.Send(ui.ErrorMsg{Error: update.Error})
+		case k8s.ServiceUpdatePortForwards:
+			p.Send(ui.PortForwardUpdateMsg{})
 		}
 	}
 
@@ -0,0 +1,196 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+)
+
+// configEnvVar names the environment variable that overrides the config file location
+const configEnvVar = "LOBOT_CONFIG"
+
+// CustomColumn is a user-defined table column
+// Expression is a JSONPath expression such as {.metadata.labels.app} or a Go template such as {{ .spec.replicas }}
+type CustomColumn struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+	Width      int    `json:"width,omitempty"`
+}
+
+// ViewSort is the sort order of a saved view
+type ViewSort struct {
+	Column     string `json:"column"` // Column title, so the sort survives columns being added or removed
+	Descending bool   `json:"descending,omitempty"`
+}
+
+// View is a named combination of resource type, filters, sort order and columns
+type View struct {
+	Name string `json:"name"`
+	// Context scopes the view to one kube context; views without one are offered in every context
+	Context       string         `json:"context,omitempty"`
+	Resource      string         `json:"resource"` // resource.group, e.g. "deployments.apps"
+	Namespace     string         `json:"namespace,omitempty"`
+	NameFilter    string         `json:"nameFilter,omitempty"`
+	LabelSelector string         `json:"labelSelector,omitempty"`
+	FieldSelector string         `json:"fieldSelector,omitempty"`
+	Query         string         `json:"query,omitempty"`
+	Sort          *ViewSort      `json:"sort,omitempty"`
+	Columns       []CustomColumn `json:"columns,omitempty"`
+}
+
+// HelmSQLStorage is the Postgres database a cluster's Helm releases are stored in with Helm's SQL storage driver
+type HelmSQLStorage struct {
+	Context string `json:"context"` // Kube context the releases belong to
+	// Connection is the Postgres connection string, as given to Helm in HELM_DRIVER_SQL_CONNECTION_STRING
+	Connection string `json:"connection"`
+}
+
+// Config is the user configuration persisted between runs
+type Config struct {
+	// Columns holds the custom columns of each resource type, keyed by resource.group (e.g. "deployments.apps")
+	Columns map[string][]CustomColumn `json:"columns,omitempty"`
+	// Views holds the saved views
+	Views []View `json:"views,omitempty"`
+	// ProtectedContexts are regular expressions matching whole kube context names, e.g. ".*prod.*";
+	// changes in matching contexts must be confirmed by typing the context name
+	ProtectedContexts []string `json:"protectedContexts,omitempty"`
+	// HelmSQLStorage lists the databases of clusters whose Helm releases are kept with Helm's SQL storage driver
+	HelmSQLStorage []HelmSQLStorage `json:"helmSQLStorage,omitempty"`
+
+	path string
+}
+
+// DefaultPath returns the config file location: $LOBOT_CONFIG, or lobot/config.yaml in the user config directory
+func DefaultPath() (string, error) {
+	if path := os.Getenv(configEnvVar); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	return filepath.Join(dir, "lobot", "config.yaml"), nil
+}
+
+// Load reads the config file at path; a missing file yields an empty config that is created on first save
+func Load(path string) (*Config, error) {
+	cfg := &Config{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Path returns where the config is saved
+func (c *Config) Path() string {
+	return c.path
+}
+
+// Save writes the config back to its file
+// The file is replaced atomically so an interrupted save can't leave a truncated config behind
+func (c *Config) Save() error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".config-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
+
+// CustomColumns returns the custom columns configured for a resource type
+func (c *Config) CustomColumns(resourceKey string) []CustomColumn {
+	return c.Columns[resourceKey]
+}
+
+// SetCustomColumns replaces the custom columns of a resource type
+func (c *Config) SetCustomColumns(resourceKey string, columns []CustomColumn) {
+	if len(columns) == 0 {
+		delete(c.Columns, resourceKey)
+		return
+	}
+	if c.Columns == nil {
+		c.Columns = make(map[string][]CustomColumn)
+	}
+	c.Columns[resourceKey] = columns
+}
+
+// ViewsFor returns the views offered in a kube context: those scoped to it and those without a context
+func (c *Config) ViewsFor(context string) []View {
+	var views []View
+	for _, view := range c.Views {
+		if view.Context == "" || view.Context == context {
+			views = append(views, view)
+		}
+	}
+	return views
+}
+
+// SetView saves a view, replacing any view with the same name and context
+func (c *Config) SetView(view View) {
+	for i, existing := range c.Views {
+		if existing.Name == view.Name && existing.Context == view.Context {
+			c.Views[i] = view
+			return
+		}
+	}
+	c.Views = append(c.Views, view)
+}
+
+// DeleteView removes the view with the given name and context, reporting whether it existed
+func (c *Config) DeleteView(name, context string) bool {
+	for i, existing := range c.Views {
+		if existing.Name == name && existing.Context == context {
+			c.Views = append(c.Views[:i], c.Views[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// HistoryDir returns where backups of changed resources are kept, next to the config file
+func (c *Config) HistoryDir() string {
+	return filepath.Join(filepath.Dir(c.path), "history")
+}
+
+// HelmSQLConnections returns the Helm SQL storage connection strings, keyed by kube context
+func (c *Config) HelmSQLConnections() map[string]string {
+	connections := make(map[string]string, len(c.HelmSQLStorage))
+	for _, storage := range c.HelmSQLStorage {
+		connections[storage.Context] = storage.Connection
+	}
+	return connections
+}
@@ -0,0 +1,171 @@
+package filters
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/miles-w-3/lobot/internal/k8s"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// fieldExtractor returns the values of a field for a resource; a requirement matches if any value does
+type fieldExtractor func(resource k8s.TrackedObject) []string
+
+// fieldExtractors are the fields FieldFilter understands, with kubectl field selector names as aliases
+var fieldExtractors = map[string]fieldExtractor{
+	"status":             statusField,
+	"status.phase":       phaseField,
+	"node":               nodeField,
+	"spec.nodeName":      nodeField,
+	"owner":              ownerKindField,
+	"name":               nameField,
+	"metadata.name":      nameField,
+	"namespace":          namespaceField,
+	"metadata.namespace": namespaceField,
+}
+
+// FieldFilter filters resources with a field selector over common fields,
+// such as "status=CrashLoopBackOff,node=worker-1,owner!=DaemonSet"
+type FieldFilter struct {
+	expression   string
+	requirements fields.Requirements
+}
+
+// NewFieldFilter creates a new field filter that matches everything
+func NewFieldFilter() *FieldFilter {
+	return &FieldFilter{}
+}
+
+// SupportedFields returns the field names FieldFilter accepts
+func SupportedFields() []string {
+	names := make([]string, 0, len(fieldExtractors))
+	for name := range fieldExtractors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetSelector parses and applies a field selector; the filter is unchanged if the selector is invalid
+func (ff *FieldFilter) SetSelector(expression string) error {
+	expression = strings.TrimSpace(expression)
+	selector, err := fields.ParseSelector(expression)
+	if err != nil {
+		return err
+	}
+
+	requirements := selector.Requirements()
+	for _, req := range requirements {
+		if _, ok := fieldExtractors[req.Field]; !ok {
+			return fmt.Errorf("unsupported field %q (supported: %s)", req.Field, strings.Join(SupportedFields(), ", "))
+		}
+	}
+
+	ff.expression = expression
+	ff.requirements = requirements
+	return nil
+}
+
+// GetSelector returns the current selector expression
+func (ff *FieldFilter) GetSelector() string {
+	return ff.expression
+}
+
+// FilterResources filters a list of resources by field values
+func (ff *FieldFilter) FilterResources(resources []k8s.TrackedObject) []k8s.TrackedObject {
+	if len(ff.requirements) == 0 {
+		return resources
+	}
+
+	filtered := make([]k8s.TrackedObject, 0, len(resources))
+	for _, resource := range resources {
+		if ff.matches(resource) {
+			filtered = append(filtered, resource)
+		}
+	}
+	return filtered
+}
+
+// matches checks a resource against every requirement
+func (ff *FieldFilter) matches(resource k8s.TrackedObject) bool {
+	for _, req := range ff.requirements {
+		values := fieldExtractors[req.Field](resource)
+		found := slices.ContainsFunc(values, func(value string) bool {
+			return fieldValueMatches(req.Field, value, req.Value)
+		})
+
+		if req.Operator == selection.NotEquals {
+			found = !found
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldValueMatches compares a value case-insensitively
+// Statuses such as "Ready 3/3" or "Ready,Cordoned" also match on their leading word, so status=Ready works for them
+func fieldValueMatches(field, value, want string) bool {
+	if strings.EqualFold(value, want) {
+		return true
+	}
+	if field != "status" {
+		return false
+	}
+	head, _, _ := strings.Cut(value, " ")
+	head, _, _ = strings.Cut(head, ",")
+	return strings.EqualFold(head, want)
+}
+
+// statusField is the status shown in the table
+func statusField(resource k8s.TrackedObject) []string {
+	return []string{resource.GetStatus()}
+}
+
+// phaseField is status.phase, for kinds that have one
+func phaseField(resource k8s.TrackedObject) []string {
+	raw := resource.GetRaw()
+	if raw == nil {
+		return nil
+	}
+	phase, _, _ := unstructured.NestedString(raw.Object, "status", "phase")
+	return []string{phase}
+}
+
+// nodeField is the node a pod is scheduled to
+func nodeField(resource k8s.TrackedObject) []string {
+	raw := resource.GetRaw()
+	if raw == nil {
+		return nil
+	}
+	node, _, _ := unstructured.NestedString(raw.Object, "spec", "nodeName")
+	return []string{node}
+}
+
+// ownerKindField lists the kinds of a resource's owners
+func ownerKindField(resource k8s.TrackedObject) []string {
+	raw := resource.GetRaw()
+	if raw == nil {
+		return nil
+	}
+	var kinds []string
+	for _, owner := range raw.GetOwnerReferences() {
+		kinds = append(kinds, owner.Kind)
+	}
+	return kinds
+}
+
+// nameField is the resource name
+func nameField(resource k8s.TrackedObject) []string {
+	return []string{resource.GetName()}
+}
+
+// namespaceField is the resource namespace
+func namespaceField(resource k8s.TrackedObject) []string {
+	return []string{resource.GetNamespace()}
+}
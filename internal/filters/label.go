@@ -0,0 +1,61 @@
+package filters
+
+import (
+	"strings"
+
+	"github.com/miles-w-3/lobot/internal/k8s"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// LabelSelectorFilter filters resources with a Kubernetes label selector
+// such as "app=web,tier!=cache,env in (prod,stg),!canary"
+type LabelSelectorFilter struct {
+	expression string
+	selector   labels.Selector
+}
+
+// NewLabelSelectorFilter creates a new label selector filter that matches everything
+func NewLabelSelectorFilter() *LabelSelectorFilter {
+	return &LabelSelectorFilter{
+		expression: "",
+		selector:   labels.Everything(),
+	}
+}
+
+// SetSelector parses and applies a label selector; the filter is unchanged if the selector is invalid
+func (lf *LabelSelectorFilter) SetSelector(expression string) error {
+	expression = strings.TrimSpace(expression)
+	selector, err := labels.Parse(expression)
+	if err != nil {
+		return err
+	}
+
+	lf.expression = expression
+	lf.selector = selector
+	return nil
+}
+
+// GetSelector returns the current selector expression
+func (lf *LabelSelectorFilter) GetSelector() string {
+	return lf.expression
+}
+
+// FilterResources filters a list of resources by their labels
+// Objects without a Kubernetes manifest (Helm releases) have no labels, so only an empty selector matches them
+func (lf *LabelSelectorFilter) FilterResources(resources []k8s.TrackedObject) []k8s.TrackedObject {
+	if lf.selector.Empty() {
+		return resources
+	}
+
+	filtered := make([]k8s.TrackedObject, 0, len(resources))
+	for _, resource := range resources {
+		var resourceLabels map[string]string
+		if raw := resource.GetRaw(); raw != nil {
+			resourceLabels = raw.GetLabels()
+		}
+		if lf.selector.Matches(labels.Set(resourceLabels)) {
+			filtered = append(filtered, resource)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,486 @@
+package filters
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/miles-w-3/lobot/internal/k8s"
+)
+
+// A query is a boolean expression over resource fields, e.g.
+//
+//	status!=Running and age>2h and label.team=payments and .spec.replicas>3
+//
+// Terms are FIELD OP VALUE, FIELD in (VALUE, ...) matching any of the values,
+// or a bare FIELD that matches when the field is set.
+// Terms combine with and, or, not and parentheses; adjacent terms are joined with and.
+// Operators are = (or ==), !=, >, >=, <, <=, =~ (regex) and !~ (negated regex).
+// Values may be quoted with "..." or '...' to include spaces or operator characters.
+
+// QueryError is a syntax error in a query, with the position it was found at
+type QueryError struct {
+	Pos     int // Byte offset into the query
+	Message string
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("%s at column %d", e.Message, e.Pos+1)
+}
+
+// tokenKind identifies a query token
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+// token is a lexed query token
+// Only tokenWord tokens can be keywords, so a quoted "and" is a plain value
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// queryOperators are the comparison operators, longest first so the lexer matches greedily
+var queryOperators = []string{"==", "!=", ">=", "<=", "=~", "!~", "=", ">", "<"}
+
+// isOperatorChar reports whether a character starts a comparison operator and so ends a bare word
+func isOperatorChar(r rune) bool {
+	return strings.ContainsRune("=!<>~", r)
+}
+
+// lexQuery splits a query into tokens
+func lexQuery(query string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(query) {
+		r, size := utf8.DecodeRuneInString(query[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: i})
+			i++
+
+		case r == '"' || r == '\'':
+			text, end, err := lexQuoted(query, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: i})
+			i = end
+
+		case isOperatorChar(r):
+			matched := ""
+			for _, op := range queryOperators {
+				if strings.HasPrefix(query[i:], op) {
+					matched = op
+					break
+				}
+			}
+			if matched == "" {
+				return nil, &QueryError{Pos: i, Message: fmt.Sprintf("unexpected %q", r)}
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: matched, pos: i})
+			i += len(matched)
+
+		default:
+			start := i
+			for i < len(query) {
+				c, size := utf8.DecodeRuneInString(query[i:])
+				if unicode.IsSpace(c) || c == '(' || c == ')' || c == ',' || isOperatorChar(c) {
+					break
+				}
+				i += size
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: query[start:i], pos: start})
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(query)}), nil
+}
+
+// lexQuoted reads a quoted string starting at start, returning its value and the offset after the closing quote
+// Double-quoted strings support Go escapes; single-quoted strings are taken literally
+func lexQuoted(query string, start int) (string, int, error) {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			if quote == '\'' {
+				return query[start+1 : i], i + 1, nil
+			}
+			value, err := strconv.Unquote(query[start : i+1])
+			if err != nil {
+				return "", 0, &QueryError{Pos: start, Message: "invalid quoted string"}
+			}
+			return value, i + 1, nil
+		}
+	}
+	return "", 0, &QueryError{Pos: start, Message: "unterminated string"}
+}
+
+// queryNode is a node of a parsed query
+type queryNode interface {
+	matches(resource k8s.TrackedObject) bool
+}
+
+type andNode struct{ left, right queryNode }
+type orNode struct{ left, right queryNode }
+type notNode struct{ operand queryNode }
+
+func (n andNode) matches(resource k8s.TrackedObject) bool {
+	return n.left.matches(resource) && n.right.matches(resource)
+}
+
+func (n orNode) matches(resource k8s.TrackedObject) bool {
+	return n.left.matches(resource) || n.right.matches(resource)
+}
+
+func (n notNode) matches(resource k8s.TrackedObject) bool {
+	return !n.operand.matches(resource)
+}
+
+// comparisonNode is a FIELD OP VALUE term
+type comparisonNode struct {
+	field *queryField
+	op    string
+	value string
+	regex *regexp.Regexp // Compiled value of =~ and !~
+}
+
+func (n comparisonNode) matches(resource k8s.TrackedObject) bool {
+	values := n.field.values(resource)
+
+	// Negated operators match when no value matches, so a missing field is != anything
+	switch n.op {
+	case "!=":
+		for _, value := range values {
+			if n.equals(value) {
+				return false
+			}
+		}
+		return true
+	case "!~":
+		for _, value := range values {
+			if n.regex.MatchString(value) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, value := range values {
+		switch n.op {
+		case "=", "==":
+			if n.equals(value) {
+				return true
+			}
+		case "=~":
+			if n.regex.MatchString(value) {
+				return true
+			}
+		case ">":
+			if compareQueryValues(value, n.value) > 0 {
+				return true
+			}
+		case ">=":
+			if compareQueryValues(value, n.value) >= 0 {
+				return true
+			}
+		case "<":
+			if compareQueryValues(value, n.value) < 0 {
+				return true
+			}
+		case "<=":
+			if compareQueryValues(value, n.value) <= 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// equals compares a field value with the term's value
+// Statuses also match on their leading word, as in FieldFilter, so status=Ready matches "Ready 3/3"
+func (n comparisonNode) equals(value string) bool {
+	if compareQueryValues(value, n.value) == 0 {
+		return true
+	}
+	return strings.EqualFold(n.field.name, "status") && fieldValueMatches("status", value, n.value)
+}
+
+// presenceNode is a bare FIELD term, true when the field has a value other than "" or false
+type presenceNode struct {
+	field *queryField
+}
+
+func (n presenceNode) matches(resource k8s.TrackedObject) bool {
+	for _, value := range n.field.values(resource) {
+		if value != "" && !strings.EqualFold(value, "false") {
+			return true
+		}
+	}
+	return false
+}
+
+// queryParser is a recursive descent parser over lexed tokens
+type queryParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *queryParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// isKeyword reports whether a token is the given unquoted keyword
+func isKeyword(t token, keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+// parseOr parses: and ("or" and)*
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), "or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseAnd parses: unary (["and"] unary)*
+func (p *queryParser) parseAnd() (queryNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if isKeyword(t, "and") {
+			p.next()
+		} else if t.kind != tokenWord && t.kind != tokenLParen || isKeyword(t, "or") {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right}
+	}
+}
+
+// parseUnary parses: "not" unary | primary
+func (p *queryParser) parseUnary() (queryNode, error) {
+	if isKeyword(p.peek(), "not") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses: "(" or-expression ")" | FIELD "in" "(" VALUE ("," VALUE)* ")" | FIELD [OP VALUE]
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenLParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, &QueryError{Pos: closing.pos, Message: "expected )"}
+		}
+		return node, nil
+
+	case tokenWord:
+		if isKeyword(t, "and") || isKeyword(t, "or") {
+			return nil, &QueryError{Pos: t.pos, Message: fmt.Sprintf("expected a field before %q", t.text)}
+		}
+		field, err := parseQueryField(t.text)
+		if err != nil {
+			return nil, &QueryError{Pos: t.pos, Message: err.Error()}
+		}
+
+		if isKeyword(p.peek(), "in") {
+			p.next()
+			return p.parseInList(field)
+		}
+		if p.peek().kind != tokenOperator {
+			return presenceNode{field: field}, nil
+		}
+		op := p.next()
+		value := p.next()
+		if value.kind != tokenWord && value.kind != tokenString {
+			return nil, &QueryError{Pos: value.pos, Message: fmt.Sprintf("expected a value after %s", op.text)}
+		}
+
+		node := comparisonNode{field: field, op: op.text, value: value.text}
+		if op.text == "=~" || op.text == "!~" {
+			regex, err := regexp.Compile(value.text)
+			if err != nil {
+				return nil, &QueryError{Pos: value.pos, Message: "invalid regex"}
+			}
+			node.regex = regex
+		}
+		return node, nil
+
+	case tokenEOF:
+		return nil, &QueryError{Pos: t.pos, Message: "unexpected end of query"}
+
+	default:
+		return nil, &QueryError{Pos: t.pos, Message: fmt.Sprintf("unexpected %q", t.text)}
+	}
+}
+
+// parseInList parses the values of an in term, which matches when the field equals any of them
+func (p *queryParser) parseInList(field *queryField) (queryNode, error) {
+	if open := p.next(); open.kind != tokenLParen {
+		return nil, &QueryError{Pos: open.pos, Message: "expected ( after in"}
+	}
+
+	var node queryNode
+	for {
+		value := p.next()
+		if value.kind != tokenWord && value.kind != tokenString {
+			return nil, &QueryError{Pos: value.pos, Message: "expected a value"}
+		}
+		term := comparisonNode{field: field, op: "=", value: value.text}
+		if node == nil {
+			node = term
+		} else {
+			node = orNode{left: node, right: term}
+		}
+
+		switch separator := p.next(); separator.kind {
+		case tokenComma:
+		case tokenRParen:
+			return node, nil
+		default:
+			return nil, &QueryError{Pos: separator.pos, Message: "expected , or )"}
+		}
+	}
+}
+
+// Query is a parsed query
+type Query struct {
+	text string
+	root queryNode // nil for an empty query, which matches everything
+}
+
+// ParseQuery parses a query, returning a *QueryError for syntax errors
+// The text is lexed as given, so error positions are columns of the input
+func ParseQuery(text string) (*Query, error) {
+	tokens, err := lexQuery(text)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &Query{text: strings.TrimSpace(text)}
+	if len(tokens) == 1 {
+		return query, nil
+	}
+
+	parser := &queryParser{tokens: tokens}
+	root, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := parser.peek(); t.kind != tokenEOF {
+		return nil, &QueryError{Pos: t.pos, Message: fmt.Sprintf("unexpected %q", t.text)}
+	}
+	query.root = root
+	return query, nil
+}
+
+// String returns the query text
+func (q *Query) String() string {
+	return q.text
+}
+
+// Matches evaluates the query against a resource
+func (q *Query) Matches(resource k8s.TrackedObject) bool {
+	return q.root == nil || q.root.matches(resource)
+}
+
+// QueryFilter filters resources with a query
+type QueryFilter struct {
+	query *Query
+}
+
+// NewQueryFilter creates a new query filter that matches everything
+func NewQueryFilter() *QueryFilter {
+	return &QueryFilter{query: &Query{}}
+}
+
+// SetQuery parses and applies a query; the filter is unchanged if the query is invalid
+func (qf *QueryFilter) SetQuery(text string) error {
+	query, err := ParseQuery(text)
+	if err != nil {
+		return err
+	}
+	qf.query = query
+	return nil
+}
+
+// GetQuery returns the current query text
+func (qf *QueryFilter) GetQuery() string {
+	return qf.query.String()
+}
+
+// FilterResources filters a list of resources by the query
+func (qf *QueryFilter) FilterResources(resources []k8s.TrackedObject) []k8s.TrackedObject {
+	if qf.query.root == nil {
+		return resources
+	}
+
+	filtered := make([]k8s.TrackedObject, 0, len(resources))
+	for _, resource := range resources {
+		if qf.query.Matches(resource) {
+			filtered = append(filtered, resource)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,267 @@
+package filters
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miles-w-3/lobot/internal/k8s"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	// completionSampleSize is how many resources are walked to collect field paths for completion
+	completionSampleSize = 20
+	// completionMaxDepth limits how deep object field paths are collected
+	completionMaxDepth = 8
+)
+
+// queryFieldExtractors are the named query fields, alongside label.KEY, annotation.KEY and .json.paths
+var queryFieldExtractors = map[string]fieldExtractor{
+	"name":      nameField,
+	"namespace": namespaceField,
+	"kind":      kindField,
+	"status":    statusField,
+	"severity":  severityField,
+	"age":       ageField,
+	"node":      nodeField,
+	"owner":     ownerKindField,
+}
+
+// queryField resolves a field of a query term
+type queryField struct {
+	name   string
+	values fieldExtractor
+}
+
+// parseQueryField resolves a field name
+func parseQueryField(name string) (*queryField, error) {
+	if strings.HasPrefix(name, ".") {
+		return parseObjectField(name)
+	}
+
+	for _, prefix := range []string{"label.", "labels."} {
+		if key, ok := strings.CutPrefix(name, prefix); ok && key != "" {
+			return &queryField{name: name, values: metadataMapField(key, true)}, nil
+		}
+	}
+	for _, prefix := range []string{"annotation.", "annotations."} {
+		if key, ok := strings.CutPrefix(name, prefix); ok && key != "" {
+			return &queryField{name: name, values: metadataMapField(key, false)}, nil
+		}
+	}
+
+	if extractor, ok := queryFieldExtractors[strings.ToLower(name)]; ok {
+		return &queryField{name: name, values: extractor}, nil
+	}
+	return nil, fmt.Errorf("unknown field %q", name)
+}
+
+// parseObjectField resolves a path into the raw object, such as .spec.replicas or .spec.containers[*].image
+func parseObjectField(path string) (*queryField, error) {
+	parser := jsonpath.New(path).AllowMissingKeys(true)
+	if err := parser.Parse("{" + path + "}"); err != nil {
+		return nil, fmt.Errorf("invalid field path %q", path)
+	}
+
+	// JSONPath keeps evaluation state, so evaluations are serialized
+	var mu sync.Mutex
+	values := func(obj k8s.TrackedObject) []string {
+		raw := obj.GetRaw()
+		if raw == nil {
+			return nil
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		results, err := parser.FindResults(raw.Object)
+		if err != nil {
+			return nil
+		}
+		var values []string
+		for _, result := range results {
+			for _, value := range result {
+				if !value.IsValid() || value.Interface() == nil {
+					continue
+				}
+				values = append(values, fmt.Sprintf("%v", value.Interface()))
+			}
+		}
+		return values
+	}
+	return &queryField{name: path, values: values}, nil
+}
+
+// metadataMapField returns an extractor for a label or annotation
+func metadataMapField(key string, label bool) fieldExtractor {
+	return func(obj k8s.TrackedObject) []string {
+		raw := obj.GetRaw()
+		if raw == nil {
+			return nil
+		}
+		values := raw.GetAnnotations()
+		if label {
+			values = raw.GetLabels()
+		}
+		if value, ok := values[key]; ok {
+			return []string{value}
+		}
+		return nil
+	}
+}
+
+// kindField is the resource kind
+func kindField(obj k8s.TrackedObject) []string {
+	return []string{obj.GetKind()}
+}
+
+// severityField is the evaluated status severity (ok, progressing, warning, error or unknown)
+func severityField(obj k8s.TrackedObject) []string {
+	return []string{obj.GetSeverity().String()}
+}
+
+// ageField is the resource age as a duration, so it compares against values such as 2h or 3d
+func ageField(obj k8s.TrackedObject) []string {
+	return []string{obj.GetAge().Round(time.Second).String()}
+}
+
+// compareQueryValues compares a field value with a query value
+// Numbers, durations (with a d suffix for days) and quantities compare numerically when both sides parse the
+// same way; anything else compares as case-insensitive text
+func compareQueryValues(actual, want string) int {
+	if x, err := strconv.ParseFloat(actual, 64); err == nil {
+		if y, err := strconv.ParseFloat(want, 64); err == nil {
+			return cmp.Compare(x, y)
+		}
+	}
+	if x, ok := parseQueryDuration(actual); ok {
+		if y, ok := parseQueryDuration(want); ok {
+			return cmp.Compare(x, y)
+		}
+	}
+	if x, err := resource.ParseQuantity(actual); err == nil {
+		if y, err := resource.ParseQuantity(want); err == nil {
+			return x.Cmp(y)
+		}
+	}
+	return strings.Compare(strings.ToLower(actual), strings.ToLower(want))
+}
+
+// parseQueryDuration parses a Go duration, optionally led by a number of days ("3d", "1d12h")
+func parseQueryDuration(value string) (time.Duration, bool) {
+	var days time.Duration
+	if before, after, found := strings.Cut(value, "d"); found {
+		n, err := strconv.Atoi(before)
+		if err != nil {
+			return 0, false
+		}
+		days = time.Duration(n) * 24 * time.Hour
+		if after == "" {
+			return days, true
+		}
+		value = after
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, false
+	}
+	return days + d, true
+}
+
+// QueryFieldCandidates lists the fields a query over these resources can use, for completion
+// Labels, annotations and object paths are collected from a sample of the resources
+func QueryFieldCandidates(resources []k8s.TrackedObject) []string {
+	seen := make(map[string]bool)
+	for name := range queryFieldExtractors {
+		seen[name] = true
+	}
+
+	for _, obj := range resources[:min(len(resources), completionSampleSize)] {
+		raw := obj.GetRaw()
+		if raw == nil {
+			continue
+		}
+		for key := range raw.GetLabels() {
+			seen["label."+key] = true
+		}
+		for key := range raw.GetAnnotations() {
+			seen["annotation."+key] = true
+		}
+		collectObjectPaths(raw.Object, "", 0, seen)
+	}
+
+	candidates := make([]string, 0, len(seen))
+	for candidate := range seen {
+		candidates = append(candidates, candidate)
+	}
+	sort.Strings(candidates)
+	return candidates
+}
+
+// collectObjectPaths adds the paths of an object's leaf fields, using [*] for lists
+// Labels, annotations and managed fields are left out; labels and annotations have their own fields
+func collectObjectPaths(value interface{}, path string, depth int, seen map[string]bool) {
+	if depth > completionMaxDepth {
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			childPath := path + "." + key
+			if strings.ContainsAny(key, ".~/ ") {
+				childPath = path + "['" + key + "']"
+			}
+			switch childPath {
+			case ".metadata.labels", ".metadata.annotations", ".metadata.managedFields":
+				continue
+			}
+			collectObjectPaths(child, childPath, depth+1, seen)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectObjectPaths(child, path+"[*]", depth+1, seen)
+		}
+	default:
+		if path != "" {
+			seen[path] = true
+		}
+	}
+}
+
+// CompleteQuery returns completions of the field being typed at the end of a query
+// Each completion is the whole query with the field completed, ready for a text input's suggestions
+func CompleteQuery(query string, candidates []string) []string {
+	start := strings.LastIndexFunc(query, func(r rune) bool {
+		return r == ' ' || r == '(' || r == ',' || isOperatorChar(r)
+	}) + 1
+	partial := query[start:]
+	if partial == "" {
+		return nil
+	}
+
+	// Only complete fields, not values: the text before the partial word must not end in an operator or an in list
+	before := strings.TrimRight(query[:start], " ")
+	if before != "" && (isOperatorChar(rune(before[len(before)-1])) || before[len(before)-1] == ',') {
+		return nil
+	}
+	if opening, ok := strings.CutSuffix(before, "("); ok {
+		if fields := strings.Fields(opening); len(fields) > 0 && strings.EqualFold(fields[len(fields)-1], "in") {
+			return nil
+		}
+	}
+
+	var completions []string
+	for _, candidate := range candidates {
+		if len(candidate) > len(partial) && strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(partial)) {
+			completions = append(completions, query[:start]+candidate)
+		}
+	}
+	return completions
+}
@@ -0,0 +1,131 @@
+package filters
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/miles-w-3/lobot/internal/k8s"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// queryTestResource builds a resource with a name, status, age and labels for evaluating queries
+func queryTestResource(name, status string, age time.Duration, labels map[string]string) k8s.TrackedObject {
+	raw := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+	}}
+	raw.SetLabels(labels)
+	return &k8s.K8sResource{
+		CoreFields: k8s.CoreFields{Name: name, Namespace: "default", Status: status, Age: age, Raw: raw},
+		Kind:       "Pod",
+	}
+}
+
+var queryTestResources = []k8s.TrackedObject{
+	queryTestResource("api", "Running", time.Hour, map[string]string{"team": "payments", "tier": "backend"}),
+	queryTestResource("web", "Pending", 3*time.Hour, map[string]string{"team": "payments", "tier": "frontend"}),
+	queryTestResource("db", "Running", 5*time.Hour, map[string]string{"team": "storage"}),
+	queryTestResource("job and more", "Failed", 10*time.Minute, nil),
+}
+
+// matchingNames returns the names of the test resources a query matches
+func matchingNames(t *testing.T, text string) []string {
+	t.Helper()
+	query, err := ParseQuery(text)
+	if err != nil {
+		t.Fatalf("ParseQuery(%q): %v", text, err)
+	}
+	var names []string
+	for _, resource := range queryTestResources {
+		if query.Matches(resource) {
+			names = append(names, resource.GetName())
+		}
+	}
+	return names
+}
+
+func TestParseQueryMatches(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "empty query matches everything", query: "  ", want: []string{"api", "web", "db", "job and more"}},
+		{name: "comparison", query: "status!=Running", want: []string{"web", "job and more"}},
+		{name: "adjacent terms are joined with and", query: "label.team=payments status=Running", want: []string{"api"}},
+		{name: "and binds tighter than or", query: "name=db or label.team=payments and status=Pending", want: []string{"web", "db"}},
+		{name: "parentheses override precedence", query: "(name=db or label.team=payments) and status=Running", want: []string{"api", "db"}},
+		{name: "not binds tighter than and", query: "not status=Running and age<2h", want: []string{"job and more"}},
+		{name: "not of a group", query: "not (status=Running or status=Pending)", want: []string{"job and more"}},
+		{name: "keywords are case insensitive", query: "name=api OR name=db", want: []string{"api", "db"}},
+		{name: "double-quoted value with spaces", query: `name="job and more"`, want: []string{"job and more"}},
+		{name: "single-quoted value with spaces", query: `name='job and more'`, want: []string{"job and more"}},
+		{name: "quoted keyword is a value", query: `name="or" or name=web`, want: []string{"web"}},
+		{name: "quoted operator characters", query: `name!="a=b"`, want: []string{"api", "web", "db", "job and more"}},
+		{name: "in list", query: "name in (api, db)", want: []string{"api", "db"}},
+		{name: "in list with quoted values", query: `name in ("job and more", 'web')`, want: []string{"web", "job and more"}},
+		{name: "in list combined with and", query: "name in (api,web,db) and label.tier=frontend", want: []string{"web"}},
+		{name: "negated in list", query: "not label.team in (payments)", want: []string{"db", "job and more"}},
+		{name: "presence term", query: "label.tier", want: []string{"api", "web"}},
+		{name: "regex", query: `name=~"^(api|web)$"`, want: []string{"api", "web"}},
+		{name: "durations compare as durations", query: "age>=3h", want: []string{"web", "db"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchingNames(t, tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseQuery(%q) matches %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		query   string
+		pos     int
+		message string
+	}{
+		{query: "status=", pos: 7, message: "expected a value after ="},
+		{query: "   status=", pos: 10, message: "expected a value after ="},
+		{query: "  bogus=1", pos: 2, message: `unknown field "bogus"`},
+		{query: "(name=api", pos: 9, message: "expected )"},
+		{query: " name=api)", pos: 9, message: `unexpected ")"`},
+		{query: `name="api`, pos: 5, message: "unterminated string"},
+		{query: "name ! api", pos: 5, message: `unexpected '!'`},
+		{query: "and name=api", pos: 0, message: `expected a field before "and"`},
+		{query: "name=api or", pos: 11, message: "unexpected end of query"},
+		{query: `name=~"("`, pos: 6, message: "invalid regex"},
+		{query: "name in api", pos: 8, message: "expected ( after in"},
+		{query: "name in (api db)", pos: 13, message: "expected , or )"},
+		{query: "name in (api,)", pos: 13, message: "expected a value"},
+		{query: "name in ()", pos: 9, message: "expected a value"},
+		{query: "name=a,b", pos: 6, message: `unexpected ","`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseQuery(tt.query)
+			var queryErr *QueryError
+			if !errors.As(err, &queryErr) {
+				t.Fatalf("ParseQuery(%q) error = %v, want a *QueryError", tt.query, err)
+			}
+			if queryErr.Pos != tt.pos || queryErr.Message != tt.message {
+				t.Errorf("ParseQuery(%q) error = %q at %d, want %q at %d", tt.query, queryErr.Message, queryErr.Pos, tt.message, tt.pos)
+			}
+		})
+	}
+}
+
+func TestQueryString(t *testing.T) {
+	query, err := ParseQuery("  status=Running  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := query.String(); got != "status=Running" {
+		t.Errorf("String() = %q, want %q", got, "status=Running")
+	}
+}
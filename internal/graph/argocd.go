@@ -110,6 +110,7 @@ func (b *Builder) BuildArgoGraph(app k8s.TrackedObject) *ResourceGraph {
 					Name:      resourceStatus.Name,
 					Namespace: resourceStatus.Namespace,
 					Status:    "Missing",
+					Severity:  k8s.SeverityWarning,
 					Age:       0,
 					Raw:       nil,
 				},
@@ -124,6 +124,28 @@ func (g *ResourceGraph) GetChildren(node *Node) []*Node {
 	return children
 }
 
+// GetDescendants returns all nodes reachable from the given node, excluding the node itself
+func (g *ResourceGraph) GetDescendants(node *Node) []*Node {
+	var descendants []*Node
+	visited := map[*Node]bool{node: true}
+	queue := []*Node{node}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, child := range g.GetChildren(current) {
+			if visited[child] {
+				continue
+			}
+			visited[child] = true
+			descendants = append(descendants, child)
+			queue = append(queue, child)
+		}
+	}
+
+	return descendants
+}
+
 // GetParents returns all parent nodes of a given node
 func (g *ResourceGraph) GetParents(node *Node) []*Node {
 	var parents []*Node
@@ -15,14 +15,29 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
-// HelmRelease represents a Helm v3 release decoded from a Secret
+// Helm storage drivers releases are read from
+const (
+	StorageSecret    = "Secret"
+	StorageConfigMap = "ConfigMap"
+	StorageSQL       = "SQL"
+)
+
+// gzipMagic starts gzip-compressed data
+var gzipMagic = []byte{0x1f, 0x8b, 0x08}
+
+// HelmRelease represents a Helm v3 release decoded from a Secret, ConfigMap or SQL row
 type HelmRelease struct {
-	Name      string      `json:"name"`
-	Namespace string      `json:"namespace"`
-	Info      ReleaseInfo `json:"info"`
-	Chart     Chart       `json:"chart"`
-	Manifest  string      `json:"manifest"`
-	Version   int         `json:"version"`
+	Name      string                 `json:"name"`
+	Namespace string                 `json:"namespace"`
+	Info      ReleaseInfo            `json:"info"`
+	Chart     Chart                  `json:"chart"`
+	Config    map[string]interface{} `json:"config"` // Values supplied by the user
+	Manifest  string                 `json:"manifest"`
+	Hooks     []Hook                 `json:"hooks"`
+	Version   int                    `json:"version"`
+
+	// Storage is the storage driver the release was read from
+	Storage string `json:"-"`
 }
 
 // ReleaseInfo contains information about the release
@@ -31,17 +46,54 @@ type ReleaseInfo struct {
 	FirstDeployed time.Time `json:"first_deployed"`
 	LastDeployed  time.Time `json:"last_deployed"`
 	Description   string    `json:"description"`
+	Notes         string    `json:"notes"`
 }
 
 // Chart represents the Helm chart metadata
 type Chart struct {
-	Metadata ChartMetadata `json:"metadata"`
+	Metadata ChartMetadata          `json:"metadata"`
+	Values   map[string]interface{} `json:"values"` // Default values of the chart
 }
 
 // ChartMetadata contains chart metadata
 type ChartMetadata struct {
-	Name    string `json:"name"`
-	Version string `json:"version"`
+	Name         string            `json:"name"`
+	Version      string            `json:"version"`
+	AppVersion   string            `json:"appVersion"`
+	Description  string            `json:"description"`
+	APIVersion   string            `json:"apiVersion"`
+	Type         string            `json:"type"`
+	Home         string            `json:"home"`
+	Dependencies []ChartDependency `json:"dependencies"`
+}
+
+// ChartDependency is a chart the chart depends on
+type ChartDependency struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	Repository string `json:"repository"`
+	Condition  string `json:"condition"`
+	Alias      string `json:"alias"`
+	Enabled    bool   `json:"enabled"`
+}
+
+// Hook is a resource Helm creates at a point in the release lifecycle, such as a pre-install Job
+type Hook struct {
+	Name           string        `json:"name"`
+	Kind           string        `json:"kind"`
+	Path           string        `json:"path"`
+	Manifest       string        `json:"manifest"`
+	Events         []string      `json:"events"`
+	LastRun        HookExecution `json:"last_run"`
+	Weight         int           `json:"weight"`
+	DeletePolicies []string      `json:"delete_policies"`
+}
+
+// HookExecution records the last run of a hook
+type HookExecution struct {
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+	Phase       string    `json:"phase"`
 }
 
 // DecodeHelmSecretTyped decodes Helm release from a typed Kubernetes Secret
@@ -57,34 +109,76 @@ func DecodeHelmSecret(secret *corev1.Secret, logger *slog.Logger) (*HelmRelease,
 		return nil, fmt.Errorf("release field not found in secret data")
 	}
 
-	releaseString := string(releaseData)
-	decodedData, err := base64.StdEncoding.DecodeString(releaseString)
+	release, err := decodeRelease(string(releaseData))
 	if err != nil {
-		return nil, fmt.Errorf("Failed to decode helm secret data for %s", secret.Name)
+		return nil, fmt.Errorf("failed to decode helm secret data for %s: %w", secret.Name, err)
 	}
 
-	gzipReader, err := gzip.NewReader(bytes.NewReader(decodedData))
-	if err != nil {
-		return nil, fmt.Errorf("gzip decompress failed: %w", err)
+	// Ensure namespace is set (fall back to secret's namespace if not in data)
+	if release.Namespace == "" {
+		release.Namespace = secret.Namespace
 	}
-	defer gzipReader.Close()
+	release.Storage = StorageSecret
 
-	jsonData, err := io.ReadAll(gzipReader)
+	return release, nil
+}
+
+// decodeRelease decodes a release the way Helm's storage drivers encode it: base64 of gzipped JSON
+func decodeRelease(data string) (*HelmRelease, error) {
+	decodedData, err := DecodeReleaseJSON(data)
 	if err != nil {
-		return nil, fmt.Errorf("read decompressed data failed: %w", err)
+		return nil, err
 	}
 
 	var release HelmRelease
-	if err := json.Unmarshal(jsonData, &release); err != nil {
+	if err := json.Unmarshal(decodedData, &release); err != nil {
 		return nil, fmt.Errorf("json unmarshal failed: %w", err)
 	}
+	return &release, nil
+}
 
-	// Ensure namespace is set (fall back to secret's namespace if not in data)
-	if release.Namespace == "" {
-		release.Namespace = secret.Namespace
+// DecodeReleaseJSON undoes Helm's encoding of a stored release, returning the release as JSON
+func DecodeReleaseJSON(data string) ([]byte, error) {
+	decodedData, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("base64 decode failed: %w", err)
 	}
 
-	return &release, nil
+	// Helm only compresses releases that start with the gzip magic header
+	if bytes.HasPrefix(decodedData, gzipMagic) {
+		gzipReader, err := gzip.NewReader(bytes.NewReader(decodedData))
+		if err != nil {
+			return nil, fmt.Errorf("gzip decompress failed: %w", err)
+		}
+		defer gzipReader.Close()
+
+		decodedData, err = io.ReadAll(gzipReader)
+		if err != nil {
+			return nil, fmt.Errorf("read decompressed data failed: %w", err)
+		}
+	}
+	return decodedData, nil
+}
+
+// EncodeReleaseJSON encodes a release given as JSON the way Helm's storage drivers do: gzipped, then base64 encoded
+func EncodeReleaseJSON(data []byte) (string, error) {
+	var buf bytes.Buffer
+	gzipWriter, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return "", fmt.Errorf("gzip compress failed: %w", err)
+	}
+	if _, err := gzipWriter.Write(data); err != nil {
+		return "", fmt.Errorf("gzip compress failed: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return "", fmt.Errorf("gzip compress failed: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// ReleaseObjectName returns the name Helm's Secret and ConfigMap drivers give the object storing a revision
+func ReleaseObjectName(name string, version int) string {
+	return fmt.Sprintf("sh.helm.release.v1.%s.v%d", name, version)
 }
 
 // IsHelmReleaseSecret checks if a Secret is a Helm release secret
@@ -106,3 +200,31 @@ func DecodeHelmSecretFromUnstructured(secret *unstructured.Unstructured, logger
 	// Use the original decoding logic
 	return DecodeHelmSecret(&typedSecret, logger)
 }
+
+// IsHelmReleaseConfigMap checks if a ConfigMap holds a release stored with Helm's ConfigMap driver
+func IsHelmReleaseConfigMap(configMap *unstructured.Unstructured) bool {
+	if configMap.GetLabels()["owner"] != "helm" {
+		return false
+	}
+	_, found, _ := unstructured.NestedString(configMap.Object, "data", "release")
+	return found
+}
+
+// DecodeHelmConfigMapFromUnstructured decodes a Helm release from a cached ConfigMap of Helm's ConfigMap driver
+// Unlike a Secret's data, a ConfigMap's isn't base64 encoded by Kubernetes, so only Helm's encoding is undone
+func DecodeHelmConfigMapFromUnstructured(configMap *unstructured.Unstructured, logger *slog.Logger) (*HelmRelease, error) {
+	data, found, err := unstructured.NestedString(configMap.Object, "data", "release")
+	if err != nil || !found {
+		return nil, fmt.Errorf("release field not found in configmap data")
+	}
+
+	release, err := decodeRelease(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode helm configmap data for %s: %w", configMap.GetName(), err)
+	}
+	if release.Namespace == "" {
+		release.Namespace = configMap.GetNamespace()
+	}
+	release.Storage = StorageConfigMap
+	return release, nil
+}
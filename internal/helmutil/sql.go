@@ -0,0 +1,62 @@
+package helmutil
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	// Postgres driver, the only database Helm's SQL storage driver supports
+	_ "github.com/lib/pq"
+)
+
+// sqlReleasesQuery reads every release from the table Helm's SQL storage driver keeps them in
+const sqlReleasesQuery = `SELECT body, namespace FROM releases_v1 WHERE owner = $1`
+
+// SQLQuerier runs queries against Helm's SQL storage; *sql.DB satisfies it
+type SQLQuerier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// OpenSQLStorage opens the Postgres database of Helm's SQL storage driver
+// The connection string is the one given to Helm in HELM_DRIVER_SQL_CONNECTION_STRING
+func OpenSQLStorage(connection string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", connection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open helm sql storage: %w", err)
+	}
+	return db, nil
+}
+
+// ListSQLReleases decodes every release stored with Helm's SQL storage driver
+// Rows that fail to decode are logged and skipped
+func ListSQLReleases(ctx context.Context, db SQLQuerier, logger *slog.Logger) ([]*HelmRelease, error) {
+	rows, err := db.QueryContext(ctx, sqlReleasesQuery, "helm")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query helm sql storage: %w", err)
+	}
+	defer rows.Close()
+
+	var releases []*HelmRelease
+	for rows.Next() {
+		var body, namespace string
+		if err := rows.Scan(&body, &namespace); err != nil {
+			return nil, fmt.Errorf("failed to read helm sql storage: %w", err)
+		}
+
+		release, err := decodeRelease(body)
+		if err != nil {
+			logger.Warn("Failed to decode Helm release from sql storage", "namespace", namespace, "error", err)
+			continue
+		}
+		if release.Namespace == "" {
+			release.Namespace = namespace
+		}
+		release.Storage = StorageSQL
+		releases = append(releases, release)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read helm sql storage: %w", err)
+	}
+	return releases, nil
+}
@@ -0,0 +1,134 @@
+package helmutil
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+// fakeSQLData holds the rows each connection of the fake driver returns, keyed by data source name
+var fakeSQLData = map[string][][]driver.Value{}
+
+// fakeSQLQueries records the queries and arguments each fake connection was sent
+var fakeSQLQueries = map[string][]string{}
+
+// fakeSQLDriver is a database/sql driver standing in for Postgres; it only answers queries
+type fakeSQLDriver struct{}
+
+func (fakeSQLDriver) Open(name string) (driver.Conn, error) {
+	return &fakeSQLConn{name: name}, nil
+}
+
+type fakeSQLConn struct {
+	name string
+}
+
+func (c *fakeSQLConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeSQLStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeSQLConn) Close() error { return nil }
+
+func (c *fakeSQLConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("transactions aren't supported")
+}
+
+type fakeSQLStmt struct {
+	conn  *fakeSQLConn
+	query string
+}
+
+func (s *fakeSQLStmt) Close() error  { return nil }
+func (s *fakeSQLStmt) NumInput() int { return -1 }
+
+func (s *fakeSQLStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, fmt.Errorf("exec isn't supported")
+}
+
+func (s *fakeSQLStmt) Query(args []driver.Value) (driver.Rows, error) {
+	fakeSQLQueries[s.conn.name] = append(fakeSQLQueries[s.conn.name], fmt.Sprint(s.query, args))
+	return &fakeSQLRows{rows: fakeSQLData[s.conn.name]}, nil
+}
+
+type fakeSQLRows struct {
+	rows [][]driver.Value
+}
+
+func (r *fakeSQLRows) Columns() []string { return []string{"body", "namespace"} }
+func (r *fakeSQLRows) Close() error      { return nil }
+
+func (r *fakeSQLRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func init() {
+	sql.Register("helmutil-fake", fakeSQLDriver{})
+}
+
+// encodeTestRelease encodes a release the way Helm's SQL driver stores its body
+func encodeTestRelease(t *testing.T, release map[string]interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(release)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := EncodeReleaseJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func TestListSQLReleases(t *testing.T) {
+	fakeSQLData[t.Name()] = [][]driver.Value{
+		{encodeTestRelease(t, map[string]interface{}{
+			"name":    "web",
+			"version": 3,
+			"info":    map[string]interface{}{"status": "deployed", "description": "Upgrade complete"},
+			"chart":   map[string]interface{}{"metadata": map[string]interface{}{"name": "nginx", "version": "1.2.3"}},
+		}), "apps"},
+		// A release body without a namespace takes the one of its row
+		{encodeTestRelease(t, map[string]interface{}{"name": "db", "namespace": "", "version": 1}), "data"},
+		{"not a release", "broken"},
+	}
+
+	db, err := sql.Open("helmutil-fake", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	releases, err := ListSQLReleases(context.Background(), db, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("ListSQLReleases: %v", err)
+	}
+
+	queries := fakeSQLQueries[t.Name()]
+	if want := fmt.Sprint(sqlReleasesQuery, []driver.Value{"helm"}); len(queries) != 1 || queries[0] != want {
+		t.Errorf("queries = %q, want [%q]", queries, want)
+	}
+
+	if len(releases) != 2 {
+		t.Fatalf("got %d releases, want 2 (the undecodable row skipped)", len(releases))
+	}
+	web, stored := releases[0], releases[1]
+	if web.Name != "web" || web.Namespace != "apps" || web.Version != 3 || web.Storage != StorageSQL {
+		t.Errorf("web = %s/%s v%d from %s, want apps/web v3 from %s", web.Namespace, web.Name, web.Version, web.Storage, StorageSQL)
+	}
+	if web.Info.Status != "deployed" || web.Info.Description != "Upgrade complete" || web.Chart.Metadata.Name != "nginx" {
+		t.Errorf("web info = %+v, chart = %+v", web.Info, web.Chart.Metadata)
+	}
+	if stored.Name != "db" || stored.Namespace != "data" || stored.Storage != StorageSQL {
+		t.Errorf("db = %s/%s from %s, want data/db from %s", stored.Namespace, stored.Name, stored.Storage, StorageSQL)
+	}
+}
@@ -0,0 +1,279 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// FieldManager is the field manager lobot applies changes as
+const FieldManager = "lobot"
+
+// EditPreview is an edit checked against the cluster with a server-side dry run, awaiting confirmation
+type EditPreview struct {
+	Resource TrackedObject
+	Original *unstructured.Unstructured // The object as it was opened in the editor
+	Edited   *unstructured.Unstructured // The object as it was saved from the editor
+	Live     *unstructured.Unstructured // The object on the cluster when the dry run ran
+	DryRun   *unstructured.Unstructured // What the server would store; nil when the dry run conflicted
+	// Conflict is set when other field managers own fields the edit changes, or, for an update,
+	// when the object changed since the edit started
+	Conflict error
+	// Merged is set once the edit has been rebased onto the live object
+	Merged bool
+	// Skipped lists the conflicting fields a merge left to the field managers that own them
+	Skipped []string
+	// Removed lists the fields the edit removes that other field managers own, which server-side apply would keep
+	// When set, the edit is sent as an update of the whole object, checked against the resourceVersion it was made from
+	Removed []string
+}
+
+// applyStrippedFields are server-populated fields left out of an applied configuration
+var applyStrippedFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "uid"},
+	{"metadata", "generation"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "selfLink"},
+	{"status"},
+}
+
+// PreviewEdit runs a server-side dry-run apply of an edited object and fetches the live object to compare it to
+// Only the fields the edit changes and those lobot already manages are applied; an edit removing fields other
+// field managers own is dry-run as an update instead
+// A conflict isn't an error; it is recorded on the preview so it can be merged or forced
+func (c *Client) PreviewEdit(ctx context.Context, resource TrackedObject, original, edited *unstructured.Unstructured) (*EditPreview, error) {
+	resourceInterface, err := c.resourceInterfaceFor(resource)
+	if err != nil {
+		return nil, fmt.Errorf("resource cannot be edited: %w", err)
+	}
+
+	live, err := resourceInterface.Get(ctx, resource.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return nil, describeAPIError("read", err)
+	}
+
+	preview := &EditPreview{Resource: resource, Original: original, Edited: edited, Live: live}
+	preview.Removed = unownedRemovals(original, edited)
+	preview.DryRun, err = c.writeEdit(ctx, preview, true, false)
+	if err != nil {
+		if !errors.IsConflict(err) {
+			return nil, describeEditError(err, preview.Update())
+		}
+		preview.Conflict = err
+	}
+	return preview, nil
+}
+
+// Update reports whether the edit is sent as an update rather than a server-side apply
+func (p *EditPreview) Update() bool {
+	return len(p.Removed) > 0
+}
+
+// ApplyEdit applies a previewed edit with server-side apply, or as an update when it removes fields others own
+// force takes ownership of fields other field managers own, as kubectl apply --force-conflicts does;
+// for an update it overwrites the object even if it changed since the edit started
+func (c *Client) ApplyEdit(ctx context.Context, preview *EditPreview, force bool) error {
+	c.Logger.Info("Applying edited resource",
+		"kind", preview.Resource.GetKind(),
+		"name", preview.Resource.GetName(),
+		"namespace", preview.Resource.GetNamespace(),
+		"force", force,
+		"update", preview.Update())
+
+	if _, err := c.writeEdit(ctx, preview, false, force); err != nil {
+		return describeEditError(err, preview.Update())
+	}
+	return nil
+}
+
+// MergeEdit rebases an edit onto the live object: the changes made in the editor are replayed on top of
+// whatever changed on the cluster since, and the result is dry-run again
+// Fields a server-side apply conflicted on are set back to their live values, leaving them to the field managers
+// that own them; fields changed differently on both sides can't be merged and are returned in the error
+func (c *Client) MergeEdit(ctx context.Context, preview *EditPreview) (*EditPreview, error) {
+	original, edited, live := applyConfiguration(preview.Original), applyConfiguration(preview.Edited), applyConfiguration(preview.Live)
+
+	var conflicts []string
+	merged := mergeThreeWay(original.Object, edited.Object, live.Object, "", &conflicts)
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return nil, fmt.Errorf("merge conflict: these fields were changed both in the editor and on the cluster: %s",
+			strings.Join(conflicts, ", "))
+	}
+
+	mergedObj, _ := merged.(map[string]interface{})
+	var skipped []string
+	if !preview.Update() {
+		for _, field := range conflictingFields(preview.Conflict) {
+			if !revertField(mergedObj, live.Object, field) {
+				return nil, fmt.Errorf("merge conflict: %s is owned by another field manager and couldn't be left to it", field)
+			}
+			skipped = append(skipped, field)
+		}
+	}
+
+	merge, err := c.PreviewEdit(ctx, preview.Resource, preview.Live, &unstructured.Unstructured{Object: mergedObj})
+	if err != nil {
+		return nil, err
+	}
+	merge.Merged = true
+	merge.Skipped = skipped
+	return merge, nil
+}
+
+// mergeThreeWay combines the changes from base to ours with those from base to theirs
+// A value changed on only one side takes that side's value; maps changed on both sides are merged key by key,
+// and anything else changed differently on both sides is recorded as a conflict at its path
+func mergeThreeWay(base, ours, theirs interface{}, path string, conflicts *[]string) interface{} {
+	switch {
+	case mergeEqual(ours, base):
+		return theirs
+	case mergeEqual(theirs, base), mergeEqual(ours, theirs):
+		return ours
+	}
+
+	oursMap, oursOK := ours.(map[string]interface{})
+	theirsMap, theirsOK := theirs.(map[string]interface{})
+	if !oursOK || !theirsOK {
+		*conflicts = append(*conflicts, path)
+		return ours
+	}
+	baseMap, _ := base.(map[string]interface{})
+
+	merged := make(map[string]interface{})
+	keys := make(map[string]bool)
+	for _, m := range []map[string]interface{}{baseMap, oursMap, theirsMap} {
+		for k := range m {
+			keys[k] = true
+		}
+	}
+	for k := range keys {
+		// A missing key is distinct from a null value, so absence is tracked alongside the value
+		value := mergeThreeWay(lookup(baseMap, k), lookup(oursMap, k), lookup(theirsMap, k), path+"."+k, conflicts)
+		if value != (absent{}) {
+			merged[k] = value
+		}
+	}
+	return merged
+}
+
+// absent stands for a key missing from a map during a three-way merge
+type absent struct{}
+
+// mergeEqual compares two values of a three-way merge
+// Numbers from the editor decode as float64 and those from the cluster as int64, so values are compared as JSON
+func mergeEqual(a, b interface{}) bool {
+	_, aAbsent := a.(absent)
+	_, bAbsent := b.(absent)
+	if aAbsent || bAbsent {
+		return aAbsent && bAbsent
+	}
+	return jsonEqual(a, b)
+}
+
+// lookup returns the value of a key, or absent when the map doesn't have it
+func lookup(m map[string]interface{}, key string) interface{} {
+	if value, ok := m[key]; ok {
+		return value
+	}
+	return absent{}
+}
+
+// applyConfiguration returns a copy of an object without the fields the server populates
+func applyConfiguration(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	config := obj.DeepCopy()
+	for _, path := range applyStrippedFields {
+		unstructured.RemoveNestedField(config.Object, path...)
+	}
+	return config
+}
+
+// writeEdit sends an edit to the cluster, returning what the server stored
+func (c *Client) writeEdit(ctx context.Context, preview *EditPreview, dryRun, force bool) (*unstructured.Unstructured, error) {
+	if preview.Update() {
+		return c.update(ctx, preview.Resource, preview.Original, preview.Edited, dryRun, force)
+	}
+	return c.apply(ctx, preview.Resource, editPatch(preview.Original, preview.Edited), dryRun, force)
+}
+
+// apply sends an object to the cluster with server-side apply, returning what the server stored
+func (c *Client) apply(ctx context.Context, resource TrackedObject, obj *unstructured.Unstructured, dryRun, force bool) (*unstructured.Unstructured, error) {
+	resourceInterface, err := c.resourceInterfaceFor(resource)
+	if err != nil {
+		return nil, fmt.Errorf("resource cannot be edited: %w", err)
+	}
+
+	data, err := json.Marshal(applyConfiguration(obj).Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode resource: %w", err)
+	}
+
+	opts := metav1.PatchOptions{FieldManager: FieldManager, Force: &force}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	return resourceInterface.Patch(ctx, resource.GetName(), types.ApplyPatchType, data, opts)
+}
+
+// update replaces an object with its edited version, returning what the server stored
+// The update fails with a conflict if the object changed since original was read, unless force is set
+func (c *Client) update(ctx context.Context, resource TrackedObject, original, edited *unstructured.Unstructured, dryRun, force bool) (*unstructured.Unstructured, error) {
+	resourceInterface, err := c.resourceInterfaceFor(resource)
+	if err != nil {
+		return nil, fmt.Errorf("resource cannot be edited: %w", err)
+	}
+
+	obj := applyConfiguration(edited)
+	if !force {
+		obj.SetResourceVersion(original.GetResourceVersion())
+	}
+
+	opts := metav1.UpdateOptions{FieldManager: FieldManager}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	return resourceInterface.Update(ctx, obj, opts)
+}
+
+// describeEditError maps API errors from applying an edit to friendlier messages
+func describeEditError(err error, update bool) error {
+	switch {
+	case errors.IsConflict(err) && update:
+		return fmt.Errorf("conflict: the resource changed on the cluster since you started editing: %w", err)
+	case errors.IsConflict(err):
+		return fmt.Errorf("conflict: other field managers own fields you changed: %w", err)
+	case errors.IsInvalid(err):
+		return fmt.Errorf("validation failed: the edited manifest failed Kubernetes validation. "+
+			"Check that all required fields are present and valid: %w", err)
+	case errors.IsNotFound(err):
+		return fmt.Errorf("not found: resource no longer exists on the cluster. "+
+			"It may have been deleted while you were editing: %w", err)
+	default:
+		return describeAPIError("update", err)
+	}
+}
+
+// ManifestYAML renders an object as YAML for comparison, without the fields that change on every write
+func ManifestYAML(obj *unstructured.Unstructured) string {
+	if obj == nil {
+		return ""
+	}
+	clean := obj.DeepCopy()
+	unstructured.RemoveNestedField(clean.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(clean.Object, "metadata", "resourceVersion")
+	data, err := yaml.Marshal(clean.Object)
+	if err != nil {
+		return fmt.Sprintf("# failed to encode resource: %v\n", err)
+	}
+	return string(data)
+}
@@ -0,0 +1,480 @@
+package k8s
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// editPatch builds the configuration lobot applies for an edit: the fields changed from the original,
+// plus the fields lobot already manages, so that applying the edit doesn't take ownership of anything else
+// Lists are compared whole, so a changed list is applied with all of its items
+func editPatch(original, edited *unstructured.Unstructured) *unstructured.Unstructured {
+	base, ours := applyConfiguration(original).Object, applyConfiguration(edited).Object
+
+	owned, _ := pruneToFields(ours, managedFields(original)).(map[string]interface{})
+
+	// The object is identified by these, whether or not they changed
+	config := &unstructured.Unstructured{Object: mergePatch(owned, changedFields(base, ours))}
+	config.SetAPIVersion(edited.GetAPIVersion())
+	config.SetKind(edited.GetKind())
+	config.SetName(edited.GetName())
+	if namespace := edited.GetNamespace(); namespace != "" {
+		config.SetNamespace(namespace)
+	}
+	return config
+}
+
+// unownedRemovals returns the fields an edit removes that lobot doesn't manage
+// Server-side apply only removes fields the applier owns, so these would be left on the object
+func unownedRemovals(original, edited *unstructured.Unstructured) []string {
+	base := applyConfiguration(original).Object
+	owned := pruneToFields(base, managedFields(original))
+
+	var removals []string
+	for _, path := range removedFields(base, applyConfiguration(edited).Object, nil) {
+		if !ownsRemoval(base, owned, path) {
+			removals = append(removals, strings.Join(path, "."))
+		}
+	}
+	return removals
+}
+
+// managedFields returns the fields lobot has applied to an object, in the FieldsV1 form the server records them in
+func managedFields(obj *unstructured.Unstructured) map[string]interface{} {
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager != FieldManager || entry.Operation != metav1.ManagedFieldsOperationApply ||
+			entry.Subresource != "" || entry.FieldsV1 == nil {
+			continue
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err == nil {
+			return fields
+		}
+	}
+	return nil
+}
+
+// changedFields returns the parts of ours that differ from base
+// Maps are compared key by key; any other changed value, lists included, is taken whole
+func changedFields(base, ours map[string]interface{}) map[string]interface{} {
+	changed := make(map[string]interface{})
+	for key, value := range ours {
+		baseValue, found := base[key]
+		if !found {
+			changed[key] = value
+			continue
+		}
+		valueMap, valueIsMap := value.(map[string]interface{})
+		baseMap, baseIsMap := baseValue.(map[string]interface{})
+		if valueIsMap && baseIsMap {
+			if sub := changedFields(baseMap, valueMap); len(sub) > 0 {
+				changed[key] = sub
+			}
+			continue
+		}
+		if !jsonEqual(value, baseValue) {
+			changed[key] = value
+		}
+	}
+	return changed
+}
+
+// removedFields returns the paths of the fields in base that ours no longer has
+// A list that lost items is reported at the list's own path
+func removedFields(base, ours map[string]interface{}, path []string) [][]string {
+	var removed [][]string
+	for key, baseValue := range base {
+		fieldPath := append(append([]string(nil), path...), key)
+		value, found := ours[key]
+		if !found {
+			removed = append(removed, fieldPath)
+			continue
+		}
+		switch baseValue := baseValue.(type) {
+		case map[string]interface{}:
+			if valueMap, ok := value.(map[string]interface{}); ok {
+				removed = append(removed, removedFields(baseValue, valueMap, fieldPath)...)
+			}
+		case []interface{}:
+			if valueList, ok := value.([]interface{}); ok && len(valueList) < len(baseValue) {
+				removed = append(removed, fieldPath)
+			}
+		}
+	}
+	return removed
+}
+
+// ownsRemoval reports whether the field at path is entirely managed by lobot, given the part of base lobot owns
+func ownsRemoval(base map[string]interface{}, owned interface{}, path []string) bool {
+	ownedMap, _ := owned.(map[string]interface{})
+	ownedValue, found, _ := unstructured.NestedFieldNoCopy(ownedMap, path...)
+	if !found {
+		return false
+	}
+	baseValue, _, _ := unstructured.NestedFieldNoCopy(base, path...)
+	if baseList, ok := baseValue.([]interface{}); ok {
+		ownedList, _ := ownedValue.([]interface{})
+		return len(ownedList) == len(baseList)
+	}
+	return true
+}
+
+// pruneToFields returns the part of a value covered by a FieldsV1 field set
+// Map fields are matched by "f:" keys and list items by "k:", "v:" and "i:" keys; a field with no nested set is taken whole
+func pruneToFields(value interface{}, fields map[string]interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		pruned := make(map[string]interface{})
+		for key, sub := range fields {
+			name, ok := strings.CutPrefix(key, "f:")
+			if !ok {
+				continue
+			}
+			child, found := value[name]
+			if !found {
+				continue
+			}
+			subFields, _ := sub.(map[string]interface{})
+			if isLeafFields(subFields) {
+				pruned[name] = child
+			} else {
+				pruned[name] = pruneToFields(child, subFields)
+			}
+		}
+		return pruned
+
+	case []interface{}:
+		var pruned []interface{}
+		for i, item := range value {
+			for key, sub := range fields {
+				keyFields, ok := matchListItem(item, i, key)
+				if !ok {
+					continue
+				}
+				subFields, _ := sub.(map[string]interface{})
+				if isLeafFields(subFields) {
+					pruned = append(pruned, item)
+					break
+				}
+				prunedItem := pruneToFields(item, subFields)
+				// The keys identifying an item are kept so the server can still match it
+				if itemMap, ok := prunedItem.(map[string]interface{}); ok {
+					for name := range keyFields {
+						itemMap[name] = item.(map[string]interface{})[name]
+					}
+				}
+				pruned = append(pruned, prunedItem)
+				break
+			}
+		}
+		return pruned
+
+	default:
+		return value
+	}
+}
+
+// isLeafFields reports whether a field set covers a field as a whole, with no nested fields listed
+func isLeafFields(fields map[string]interface{}) bool {
+	for key := range fields {
+		if key != "." {
+			return false
+		}
+	}
+	return true
+}
+
+// matchListItem reports whether a list item at index is the one a FieldsV1 list key refers to
+// For "k:" keys the key fields are returned too
+func matchListItem(item interface{}, index int, key string) (map[string]interface{}, bool) {
+	switch {
+	case strings.HasPrefix(key, "k:"):
+		var keyFields map[string]interface{}
+		if err := json.Unmarshal([]byte(key[2:]), &keyFields); err != nil {
+			return nil, false
+		}
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		for name, want := range keyFields {
+			if !jsonEqual(itemMap[name], want) {
+				return nil, false
+			}
+		}
+		return keyFields, true
+	case strings.HasPrefix(key, "v:"):
+		var want interface{}
+		if err := json.Unmarshal([]byte(key[2:]), &want); err != nil {
+			return nil, false
+		}
+		return nil, jsonEqual(item, want)
+	case strings.HasPrefix(key, "i:"):
+		return nil, key[2:] == strconv.Itoa(index)
+	default:
+		return nil, false
+	}
+}
+
+// jsonEqual compares two values by their JSON encoding, as numbers decode to different types from YAML and JSON
+func jsonEqual(a, b interface{}) bool {
+	aData, aErr := json.Marshal(a)
+	bData, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && string(aData) == string(bData)
+}
+
+// mergePatch combines two configurations, with changes taking precedence over base where they overlap
+func mergePatch(base, changes map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(changes))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range changes {
+		valueMap, valueIsMap := value.(map[string]interface{})
+		baseMap, baseIsMap := base[key].(map[string]interface{})
+		if valueIsMap && baseIsMap {
+			merged[key] = mergePatch(baseMap, valueMap)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// conflictingFields returns the paths of the fields a server-side apply conflict reports other field managers own
+func conflictingFields(err error) []string {
+	var status apierrors.APIStatus
+	if err == nil || !errors.As(err, &status) || status.Status().Details == nil {
+		return nil
+	}
+	var fields []string
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict && cause.Field != "" {
+			fields = append(fields, cause.Field)
+		}
+	}
+	return fields
+}
+
+// fieldPathElement is one step of a field path as the server reports it, such as .spec, [name="app"], [="x"] or [0]
+type fieldPathElement struct {
+	field    string                 // Map field
+	keys     map[string]interface{} // Key fields of an associative list item
+	value    interface{}            // Set item
+	hasValue bool
+	index    int // List item by position, or -1
+}
+
+// revertField sets the field at a reported path back to its value in live, or removes it when live doesn't have it
+// It reports whether the field could be reached
+func revertField(obj, live map[string]interface{}, path string) bool {
+	elements, found := resolveFieldPath(live, path)
+	if !found {
+		if elements, found = resolveFieldPath(obj, path); !found {
+			return false
+		}
+	}
+	if len(elements) == 0 {
+		return false
+	}
+
+	liveValue, liveFound := findField(live, elements)
+	parent, found := findField(obj, elements[:len(elements)-1])
+	if !found {
+		return false
+	}
+	last := elements[len(elements)-1]
+
+	switch parent := parent.(type) {
+	case map[string]interface{}:
+		if last.index != -1 || last.keys != nil || last.hasValue {
+			return false
+		}
+		if liveFound {
+			parent[last.field] = runtime.DeepCopyJSONValue(liveValue)
+		} else {
+			delete(parent, last.field)
+		}
+		return true
+	case []interface{}:
+		// A list item can be replaced in place, but adding or removing one would need the list's parent
+		i := findListItem(parent, last)
+		switch {
+		case i >= 0 && liveFound:
+			parent[i] = runtime.DeepCopyJSONValue(liveValue)
+			return true
+		case i < 0 && !liveFound:
+			return true
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+}
+
+// resolveFieldPath parses a reported field path against an object
+// Field names may contain dots, as annotation keys do, so the object decides where each name ends
+func resolveFieldPath(obj interface{}, path string) ([]fieldPathElement, bool) {
+	if path == "" {
+		return nil, true
+	}
+
+	var element fieldPathElement
+	var child interface{}
+	var rest string
+	switch path[0] {
+	case '.':
+		m, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		for name := range m {
+			after, ok := strings.CutPrefix(path[1:], name)
+			if ok && (after == "" || after[0] == '.' || after[0] == '[') && len(name) >= len(element.field) {
+				element.field, rest = name, after
+			}
+		}
+		if element.field == "" {
+			return nil, false
+		}
+		element.index = -1
+		child = m[element.field]
+
+	case '[':
+		end := closingBracket(path)
+		list, ok := obj.([]interface{})
+		if end < 0 || !ok {
+			return nil, false
+		}
+		var err error
+		if element, err = parseFieldSelector(path[1:end]); err != nil {
+			return nil, false
+		}
+		i := findListItem(list, element)
+		if i < 0 {
+			return nil, false
+		}
+		child, rest = list[i], path[end+1:]
+
+	default:
+		return nil, false
+	}
+
+	elements, found := resolveFieldPath(child, rest)
+	return append([]fieldPathElement{element}, elements...), found
+}
+
+// closingBracket returns the index of the bracket closing the one a path starts with, skipping quoted values
+func closingBracket(path string) int {
+	quoted := false
+	for i := 1; i < len(path); i++ {
+		switch {
+		case quoted && path[i] == '\\':
+			i++
+		case path[i] == '"':
+			quoted = !quoted
+		case !quoted && path[i] == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// parseFieldSelector parses what selects a list item: key=value pairs, =value or a position
+// Values are JSON, as the server prints them
+func parseFieldSelector(selector string) (fieldPathElement, error) {
+	element := fieldPathElement{index: -1}
+	if value, ok := strings.CutPrefix(selector, "="); ok {
+		element.hasValue = true
+		return element, json.Unmarshal([]byte(value), &element.value)
+	}
+	if index, err := strconv.Atoi(selector); err == nil {
+		element.index = index
+		return element, nil
+	}
+
+	element.keys = make(map[string]interface{})
+	for len(selector) > 0 {
+		name, value, ok := strings.Cut(selector, "=")
+		if !ok {
+			return element, fmt.Errorf("invalid list selector %q", selector)
+		}
+		// The value runs to the next comma outside quotes
+		end, quoted := len(value), false
+		for i := 0; i < len(value); i++ {
+			if quoted && value[i] == '\\' {
+				i++
+			} else if value[i] == '"' {
+				quoted = !quoted
+			} else if !quoted && value[i] == ',' {
+				end = i
+				break
+			}
+		}
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(value[:end]), &decoded); err != nil {
+			return element, fmt.Errorf("invalid list selector %q: %w", selector, err)
+		}
+		element.keys[name] = decoded
+		selector = strings.TrimPrefix(value[end:], ",")
+	}
+	return element, nil
+}
+
+// findField returns the value at a parsed field path
+func findField(obj interface{}, elements []fieldPathElement) (interface{}, bool) {
+	for _, element := range elements {
+		switch value := obj.(type) {
+		case map[string]interface{}:
+			child, found := value[element.field]
+			if !found || element.index != -1 || element.keys != nil || element.hasValue {
+				return nil, false
+			}
+			obj = child
+		case []interface{}:
+			i := findListItem(value, element)
+			if i < 0 {
+				return nil, false
+			}
+			obj = value[i]
+		default:
+			return nil, false
+		}
+	}
+	return obj, true
+}
+
+// findListItem returns the position of the list item a path element selects, or -1
+func findListItem(list []interface{}, element fieldPathElement) int {
+	for i, item := range list {
+		switch {
+		case element.index != -1:
+			if i == element.index {
+				return i
+			}
+		case element.hasValue:
+			if jsonEqual(item, element.value) {
+				return i
+			}
+		case element.keys != nil:
+			itemMap, ok := item.(map[string]interface{})
+			matched := ok
+			for name, want := range element.keys {
+				matched = matched && jsonEqual(itemMap[name], want)
+			}
+			if matched {
+				return i
+			}
+		}
+	}
+	return -1
+}
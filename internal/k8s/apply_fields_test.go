@@ -0,0 +1,385 @@
+package k8s
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/yaml"
+)
+
+// clusterObject decodes JSON the way objects read from the cluster are, with whole numbers as int64
+func clusterObject(t *testing.T, data string) map[string]interface{} {
+	t.Helper()
+	var obj map[string]interface{}
+	if err := utiljson.Unmarshal([]byte(data), &obj); err != nil {
+		t.Fatal(err)
+	}
+	return obj
+}
+
+// editorObject decodes YAML the way a saved editor buffer is, with numbers as float64
+func editorObject(t *testing.T, data string) map[string]interface{} {
+	t.Helper()
+	var obj map[string]interface{}
+	if err := yaml.Unmarshal([]byte(data), &obj); err != nil {
+		t.Fatal(err)
+	}
+	return obj
+}
+
+// withLobotFields records fields as applied by lobot on an object
+func withLobotFields(obj map[string]interface{}, fields string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: obj}
+	u.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{Manager: "kube-controller-manager", Operation: metav1.ManagedFieldsOperationUpdate, FieldsType: "FieldsV1",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)}},
+		{Manager: FieldManager, Operation: metav1.ManagedFieldsOperationApply, FieldsType: "FieldsV1",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(fields)}},
+	})
+	return u
+}
+
+const deploymentJSON = `{
+	"apiVersion": "apps/v1",
+	"kind": "Deployment",
+	"metadata": {
+		"name": "web",
+		"namespace": "apps",
+		"resourceVersion": "42",
+		"labels": {"app": "web", "team": "a", "tier": "frontend"},
+		"annotations": {"example.com/owner": "ops"}
+	},
+	"spec": {
+		"replicas": 3,
+		"template": {"spec": {"containers": [
+			{"name": "app", "image": "web:1", "ports": [{"containerPort": 8080}]},
+			{"name": "sidecar", "image": "proxy:1"}
+		]}}
+	},
+	"status": {"readyReplicas": 3}
+}`
+
+// lobotDeploymentFields are the fields lobot applied to the deployment: one label and the app container's image
+const lobotDeploymentFields = `{
+	"f:metadata": {"f:labels": {"f:tier": {}}},
+	"f:spec": {"f:template": {"f:spec": {"f:containers": {"k:{\"name\":\"app\"}": {".": {}, "f:image": {}, "f:name": {}}}}}}
+}`
+
+func TestEditPatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields string
+		edit   func(obj map[string]interface{})
+		want   string
+	}{
+		{
+			name:   "unchanged object applies only the fields lobot owns",
+			fields: lobotDeploymentFields,
+			edit:   func(map[string]interface{}) {},
+			want: `{"apiVersion": "apps/v1", "kind": "Deployment",
+				"metadata": {"name": "web", "namespace": "apps", "labels": {"tier": "frontend"}},
+				"spec": {"template": {"spec": {"containers": [{"name": "app", "image": "web:1"}]}}}}`,
+		},
+		{
+			name: "changed label is applied alone",
+			edit: func(obj map[string]interface{}) {
+				_ = unstructured.SetNestedField(obj, "b", "metadata", "labels", "team")
+			},
+			want: `{"apiVersion": "apps/v1", "kind": "Deployment",
+				"metadata": {"name": "web", "namespace": "apps", "labels": {"team": "b"}}}`,
+		},
+		{
+			name:   "label lobot owns is dropped when removed",
+			fields: lobotDeploymentFields,
+			edit: func(obj map[string]interface{}) {
+				unstructured.RemoveNestedField(obj, "metadata", "labels", "tier")
+			},
+			want: `{"apiVersion": "apps/v1", "kind": "Deployment",
+				"metadata": {"name": "web", "namespace": "apps", "labels": {}},
+				"spec": {"template": {"spec": {"containers": [{"name": "app", "image": "web:1"}]}}}}`,
+		},
+		{
+			name: "changed list is applied whole",
+			edit: func(obj map[string]interface{}) {
+				containers, _, _ := unstructured.NestedSlice(obj, "spec", "template", "spec", "containers")
+				containers[1].(map[string]interface{})["image"] = "proxy:2"
+				_ = unstructured.SetNestedSlice(obj, containers, "spec", "template", "spec", "containers")
+			},
+			want: `{"apiVersion": "apps/v1", "kind": "Deployment",
+				"metadata": {"name": "web", "namespace": "apps"},
+				"spec": {"template": {"spec": {"containers": [
+					{"name": "app", "image": "web:1", "ports": [{"containerPort": 8080}]},
+					{"name": "sidecar", "image": "proxy:2"}
+				]}}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := withLobotFields(clusterObject(t, deploymentJSON), tt.fields)
+			if tt.fields == "" {
+				original.SetManagedFields(nil)
+			}
+			// The saved buffer round-trips through YAML, so its numbers are float64 where the original's are int64
+			data, _ := yaml.Marshal(original.Object)
+			edited := &unstructured.Unstructured{Object: editorObject(t, string(data))}
+			tt.edit(edited.Object)
+
+			got := editPatch(original, edited).Object
+			if want := clusterObject(t, tt.want); !jsonEqual(got, want) {
+				t.Errorf("editPatch() = %v\nwant %v", got, want)
+			}
+		})
+	}
+}
+
+func TestUnownedRemovals(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(obj map[string]interface{})
+		want []string
+	}{
+		{
+			name: "no removals",
+			edit: func(obj map[string]interface{}) {
+				_ = unstructured.SetNestedField(obj, "b", "metadata", "labels", "team")
+			},
+		},
+		{
+			name: "removal of a field lobot owns",
+			edit: func(obj map[string]interface{}) {
+				unstructured.RemoveNestedField(obj, "metadata", "labels", "tier")
+			},
+		},
+		{
+			name: "removal of fields other managers own",
+			edit: func(obj map[string]interface{}) {
+				unstructured.RemoveNestedField(obj, "metadata", "labels", "team")
+				unstructured.RemoveNestedField(obj, "metadata", "annotations")
+			},
+			want: []string{"metadata.annotations", "metadata.labels.team"},
+		},
+		{
+			name: "removal of a list item another manager owns",
+			edit: func(obj map[string]interface{}) {
+				containers, _, _ := unstructured.NestedSlice(obj, "spec", "template", "spec", "containers")
+				_ = unstructured.SetNestedSlice(obj, containers[:1], "spec", "template", "spec", "containers")
+			},
+			want: []string{"spec.template.spec.containers"},
+		},
+		{
+			name: "status is never applied",
+			edit: func(obj map[string]interface{}) {
+				unstructured.RemoveNestedField(obj, "status")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := withLobotFields(clusterObject(t, deploymentJSON), lobotDeploymentFields)
+			edited := original.DeepCopy()
+			tt.edit(edited.Object)
+
+			got := unownedRemovals(original, edited)
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unownedRemovals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangedFieldsNumbers(t *testing.T) {
+	base := clusterObject(t, `{"spec": {"replicas": 3, "ratio": 0.5, "ports": [80, 443]}}`)
+	ours := editorObject(t, "spec:\n  replicas: 3\n  ratio: 0.5\n  ports: [80, 443]\n")
+	if changed := changedFields(base, ours); len(changed) != 0 {
+		t.Errorf("changedFields() = %v, want no changes between int64 and float64 numbers", changed)
+	}
+
+	ours = editorObject(t, "spec:\n  replicas: 4\n  ratio: 0.5\n  ports: [80, 443]\n")
+	if changed := changedFields(base, ours); !jsonEqual(changed, map[string]interface{}{"spec": map[string]interface{}{"replicas": 4}}) {
+		t.Errorf("changedFields() = %v, want only spec.replicas", changed)
+	}
+}
+
+func TestPruneToFields(t *testing.T) {
+	value := clusterObject(t, `{"containers": [
+		{"name": "app", "image": "web:1", "env": [{"name": "A", "value": "1"}]},
+		{"name": "sidecar", "image": "proxy:1"}
+	], "finalizers": ["a", "b"], "args": ["x", "y"]}`)
+
+	tests := []struct {
+		name   string
+		fields string
+		want   string
+	}{
+		{
+			name:   "list item by key keeps its key fields",
+			fields: `{"f:containers": {"k:{\"name\":\"sidecar\"}": {"f:image": {}}}}`,
+			want:   `{"containers": [{"name": "sidecar", "image": "proxy:1"}]}`,
+		},
+		{
+			name:   "nested list item by key",
+			fields: `{"f:containers": {"k:{\"name\":\"app\"}": {"f:env": {"k:{\"name\":\"A\"}": {".": {}}}}}}`,
+			want:   `{"containers": [{"name": "app", "env": [{"name": "A", "value": "1"}]}]}`,
+		},
+		{
+			name:   "set item by value",
+			fields: `{"f:finalizers": {"v:\"b\"": {}}}`,
+			want:   `{"finalizers": ["b"]}`,
+		},
+		{
+			name:   "list item by position",
+			fields: `{"f:args": {"i:1": {}}}`,
+			want:   `{"args": ["y"]}`,
+		},
+		{
+			name:   "unmatched key",
+			fields: `{"f:containers": {"k:{\"name\":\"missing\"}": {"f:image": {}}}}`,
+			want:   `{"containers": null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pruneToFields(value, clusterObject(t, tt.fields))
+			if want := clusterObject(t, tt.want); !jsonEqual(got, want) {
+				t.Errorf("pruneToFields() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestMergeThreeWay(t *testing.T) {
+	tests := []struct {
+		name      string
+		base      string
+		ours      string
+		theirs    string
+		want      string
+		conflicts []string
+	}{
+		{
+			name:   "changes on both sides merge cleanly",
+			base:   `{"spec": {"replicas": 3, "image": "web:1"}, "metadata": {"labels": {"a": "1"}}}`,
+			ours:   "spec:\n  replicas: 3\n  image: web:2\nmetadata:\n  labels:\n    a: \"1\"\n    b: \"2\"\n",
+			theirs: `{"spec": {"replicas": 5, "image": "web:1"}, "metadata": {"labels": {"a": "1"}}}`,
+			want:   `{"spec": {"replicas": 5, "image": "web:2"}, "metadata": {"labels": {"a": "1", "b": "2"}}}`,
+		},
+		{
+			name:   "removal in the editor is kept",
+			base:   `{"metadata": {"labels": {"a": "1", "b": "2"}}}`,
+			ours:   "metadata:\n  labels:\n    a: \"1\"\n",
+			theirs: `{"metadata": {"labels": {"a": "1", "b": "2", "c": "3"}}}`,
+			want:   `{"metadata": {"labels": {"a": "1", "c": "3"}}}`,
+		},
+		{
+			name:      "field changed differently on both sides conflicts",
+			base:      `{"spec": {"replicas": 3}}`,
+			ours:      "spec:\n  replicas: 4\n",
+			theirs:    `{"spec": {"replicas": 5}}`,
+			want:      `{"spec": {"replicas": 4}}`,
+			conflicts: []string{".spec.replicas"},
+		},
+		{
+			name:   "empty map isn't mistaken for a missing one",
+			base:   `{"spec": {}}`,
+			ours:   "spec: {}\n",
+			theirs: `{}`,
+			want:   `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var conflicts []string
+			got := mergeThreeWay(clusterObject(t, tt.base), editorObject(t, tt.ours), clusterObject(t, tt.theirs), "", &conflicts)
+			if want := clusterObject(t, tt.want); !jsonEqual(got, want) {
+				t.Errorf("mergeThreeWay() = %v, want %v", got, want)
+			}
+			if !reflect.DeepEqual(conflicts, tt.conflicts) {
+				t.Errorf("conflicts = %v, want %v", conflicts, tt.conflicts)
+			}
+		})
+	}
+}
+
+func TestRevertField(t *testing.T) {
+	live := `{"metadata": {"annotations": {"example.com/owner": "ops"}},
+		"spec": {"replicas": 5, "template": {"spec": {"containers": [{"name": "app", "image": "web:1"}]}}, "finalizers": ["a"]}}`
+
+	tests := []struct {
+		name  string
+		obj   string
+		field string
+		want  string
+		ok    bool
+	}{
+		{
+			name:  "map field",
+			obj:   `{"spec": {"replicas": 4}}`,
+			field: ".spec.replicas",
+			want:  `{"spec": {"replicas": 5}}`,
+			ok:    true,
+		},
+		{
+			name:  "field of a list item selected by key",
+			obj:   `{"spec": {"template": {"spec": {"containers": [{"name": "app", "image": "web:2"}]}}}}`,
+			field: `.spec.template.spec.containers[name="app"].image`,
+			want:  `{"spec": {"template": {"spec": {"containers": [{"name": "app", "image": "web:1"}]}}}}`,
+			ok:    true,
+		},
+		{
+			name:  "field name with dots",
+			obj:   `{"metadata": {"annotations": {"example.com/owner": "dev"}}}`,
+			field: ".metadata.annotations.example.com/owner",
+			want:  `{"metadata": {"annotations": {"example.com/owner": "ops"}}}`,
+			ok:    true,
+		},
+		{
+			name:  "field missing from live is removed",
+			obj:   `{"spec": {"paused": true}}`,
+			field: ".spec.paused",
+			want:  `{"spec": {}}`,
+			ok:    true,
+		},
+		{
+			name:  "list item missing from the edit can't be added back",
+			obj:   `{"spec": {"finalizers": []}}`,
+			field: `.spec.finalizers[="a"]`,
+			want:  `{"spec": {"finalizers": []}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := clusterObject(t, tt.obj)
+			if ok := revertField(obj, clusterObject(t, live), tt.field); ok != tt.ok {
+				t.Errorf("revertField() = %v, want %v", ok, tt.ok)
+			}
+			if want := clusterObject(t, tt.want); !jsonEqual(obj, want) {
+				t.Errorf("object = %v, want %v", obj, want)
+			}
+		})
+	}
+}
+
+func TestConflictingFields(t *testing.T) {
+	err := apierrors.NewApplyConflict([]metav1.StatusCause{
+		{Type: metav1.CauseTypeFieldManagerConflict, Field: ".spec.replicas", Message: "conflict with \"kube-controller-manager\""},
+		{Type: metav1.CauseTypeFieldValueInvalid, Field: ".spec.other"},
+	}, "Apply failed with 1 conflict")
+
+	if got := conflictingFields(err); !reflect.DeepEqual(got, []string{".spec.replicas"}) {
+		t.Errorf("conflictingFields() = %v, want [.spec.replicas]", got)
+	}
+	if got := conflictingFields(nil); got != nil {
+		t.Errorf("conflictingFields(nil) = %v, want nil", got)
+	}
+}
@@ -40,6 +40,12 @@ func convertArgoApplicationToTrackedObject(app *unstructured.Unstructured, gvr s
 		destination = destNamespace
 	}
 
+	// Severity follows health, with healthy apps that have drifted from git flagged as warnings
+	severity := SeverityForStatus(health)
+	if severity == SeverityOK && syncStatus == "OutOfSync" {
+		severity = SeverityWarning
+	}
+
 	// Calculate age
 	age := time.Duration(0)
 	creationTime := app.GetCreationTimestamp()
@@ -52,6 +58,7 @@ func convertArgoApplicationToTrackedObject(app *unstructured.Unstructured, gvr s
 			Name:      app.GetName(),
 			Namespace: app.GetNamespace(),
 			Status:    syncStatus, // Use sync status as primary status
+			Severity:  severity,
 			Age:       age,
 			Raw:       app,
 		},
@@ -30,6 +30,8 @@ type Client struct {
 	Context     string
 	Logger      *slog.Logger
 	ScopedNS    CurrentScopedNamespace
+	// Protection limits what the client may change; it is enforced on every request the client makes
+	Protection *WriteProtection
 }
 
 // NewClient creates a new Kubernetes client
@@ -37,7 +39,7 @@ type Client struct {
 // 1. KUBECONFIG environment variable
 // 2. ~/.kube/config
 // 3. In-cluster config (when running inside a pod)
-func NewClient(logger *slog.Logger) (*Client, error) {
+func NewClient(logger *slog.Logger, protection *WriteProtection) (*Client, error) {
 	if logger == nil {
 		logger = slog.Default()
 	}
@@ -62,7 +64,10 @@ func NewClient(logger *slog.Logger) (*Client, error) {
 		clusterName = "in-cluster"
 	}
 
-	logger.Info("Loaded Kubernetes configuration", "context", context, "cluster", clusterName)
+	logger.Info("Loaded Kubernetes configuration", "context", context, "cluster", clusterName, "writeMode", protection.Mode(context))
+
+	// Refuse changes the write protection forbids on every request, whichever client makes it
+	config.Wrap(protection.wrapTransport(context))
 
 	// Create the clientset
 	clientset, err := kubernetes.NewForConfig(config)
@@ -78,6 +83,7 @@ func NewClient(logger *slog.Logger) (*Client, error) {
 		ClusterName: clusterName,
 		Context:     context,
 		Logger:      logger,
+		Protection:  protection,
 		// by default, global scope - don't care what's in k8s context
 		// could in future respect user config to load in from config
 		ScopedNS: CurrentScopedNamespace{value: "", isGlobal: true},
@@ -115,7 +121,7 @@ func GetAvailableContexts() ([]string, string, error) {
 
 // NewClientWithContext creates a new Kubernetes client with a specific context
 // The context override is in-memory only and does not modify the kubeconfig file
-func NewClientWithContext(logger *slog.Logger, contextName string) (*Client, error) {
+func NewClientWithContext(logger *slog.Logger, contextName string, protection *WriteProtection) (*Client, error) {
 	if logger == nil {
 		logger = slog.Default()
 	}
@@ -128,7 +134,10 @@ func NewClientWithContext(logger *slog.Logger, contextName string) (*Client, err
 		return nil, fmt.Errorf("failed to load kubeconfig with context %s: %w", contextName, err)
 	}
 
-	logger.Info("Loaded Kubernetes configuration", "context", contextName, "cluster", clusterName)
+	logger.Info("Loaded Kubernetes configuration", "context", contextName, "cluster", clusterName, "writeMode", protection.Mode(contextName))
+
+	// Refuse changes the write protection forbids on every request, whichever client makes it
+	config.Wrap(protection.wrapTransport(contextName))
 
 	// Create the clientset
 	clientset, err := kubernetes.NewForConfig(config)
@@ -144,6 +153,7 @@ func NewClientWithContext(logger *slog.Logger, contextName string) (*Client, err
 		ClusterName: clusterName,
 		Context:     contextName,
 		Logger:      logger,
+		Protection:  protection,
 	}, nil
 }
 
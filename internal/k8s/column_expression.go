@@ -0,0 +1,79 @@
+package k8s
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// ColumnExpression is a parsed custom column expression, either JSONPath or a Go template
+type ColumnExpression struct {
+	// JSONPath keeps evaluation state, so a parsed expression is used by one caller at a time
+	mu       sync.Mutex
+	jsonPath *jsonpath.JSONPath
+	template *template.Template
+}
+
+// ParseColumnExpression parses a custom column expression
+// Expressions containing {{ are Go templates executed against the object's fields; anything else is JSONPath,
+// with the surrounding braces optional (".metadata.labels.app" and "{.metadata.labels.app}" are equivalent)
+func ParseColumnExpression(expr string) (*ColumnExpression, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, fmt.Errorf("expression is empty")
+	}
+
+	if strings.Contains(expr, "{{") {
+		tmpl, err := template.New("column").Option("missingkey=zero").Parse(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+		return &ColumnExpression{template: tmpl}, nil
+	}
+
+	if !strings.HasPrefix(expr, "{") {
+		expr = "{" + expr + "}"
+	}
+	parser := jsonpath.New("column").AllowMissingKeys(true)
+	if err := parser.Parse(expr); err != nil {
+		return nil, fmt.Errorf("invalid JSONPath: %w", err)
+	}
+	return &ColumnExpression{jsonPath: parser}, nil
+}
+
+// Evaluate renders the expression for a resource
+// Multiple JSONPath results are joined with commas, as kubectl's custom-columns output does
+func (e *ColumnExpression) Evaluate(obj TrackedObject) string {
+	raw := obj.GetRaw()
+	if raw == nil {
+		return ""
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.template != nil {
+		var buf bytes.Buffer
+		// Execution fails when the template walks into a missing field, which is shown as empty like a missing JSONPath key
+		if err := e.template.Execute(&buf, raw.Object); err != nil {
+			return ""
+		}
+		return strings.ReplaceAll(buf.String(), "<no value>", "")
+	}
+
+	results, err := e.jsonPath.FindResults(raw.Object)
+	if err != nil {
+		return "<error>"
+	}
+	var values []string
+	for _, result := range results {
+		for _, value := range result {
+			values = append(values, fmt.Sprintf("%v", value.Interface()))
+		}
+	}
+	return strings.Join(values, ",")
+}
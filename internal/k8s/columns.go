@@ -0,0 +1,694 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/miles-w-3/lobot/internal/util"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Column widths are preferred widths; the UI stretches or shrinks the wide ones to fit the terminal
+
+var podColumns = []table.Column{
+	{Title: "NAME", Width: 40},
+	{Title: "NAMESPACE", Width: 20},
+	{Title: "READY", Width: 6},
+	{Title: "STATUS", Width: 18},
+	{Title: "RESTARTS", Width: 12},
+	{Title: "IP", Width: 15},
+	{Title: "NODE", Width: 25},
+	{Title: "AGE", Width: 6},
+}
+
+// podRowBinder binds a Pod to podColumns, following kubectl get pods -o wide
+func podRowBinder(obj TrackedObject) table.Row {
+	raw := obj.GetRaw()
+	if raw == nil {
+		return table.Row{obj.GetName(), obj.GetNamespace(), "", obj.GetStatus(), "", "", "", util.FormatAge(obj.GetAge())}
+	}
+
+	statuses, _, _ := unstructured.NestedSlice(raw.Object, "status", "containerStatuses")
+	containers, _, _ := unstructured.NestedSlice(raw.Object, "spec", "containers")
+	ready := 0
+	var restarts int64
+	var lastRestart time.Time
+	for _, s := range statuses {
+		status, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if isReady, _, _ := unstructured.NestedBool(status, "ready"); isReady {
+			ready++
+		}
+		count, _, _ := unstructured.NestedInt64(status, "restartCount")
+		restarts += count
+		if finished := rawTime(status, "lastState", "terminated", "finishedAt"); finished.After(lastRestart) {
+			lastRestart = finished
+		}
+	}
+
+	restartText := fmt.Sprintf("%d", restarts)
+	if restarts > 0 && !lastRestart.IsZero() {
+		restartText += fmt.Sprintf(" (%s ago)", util.FormatAge(time.Since(lastRestart)))
+	}
+
+	return table.Row{
+		obj.GetName(),
+		obj.GetNamespace(),
+		fmt.Sprintf("%d/%d", ready, len(containers)),
+		obj.GetStatus(),
+		restartText,
+		orNone(rawString(raw.Object, "status", "podIP")),
+		orNone(rawString(raw.Object, "spec", "nodeName")),
+		util.FormatAge(obj.GetAge()),
+	}
+}
+
+var deploymentColumns = []table.Column{
+	{Title: "NAME", Width: 40},
+	{Title: "NAMESPACE", Width: 20},
+	{Title: "READY", Width: 7},
+	{Title: "UP-TO-DATE", Width: 10},
+	{Title: "AVAILABLE", Width: 9},
+	{Title: "STATUS", Width: 18},
+	{Title: "IMAGES", Width: 30},
+	{Title: "AGE", Width: 6},
+}
+
+// deploymentRowBinder binds a Deployment to deploymentColumns
+func deploymentRowBinder(obj TrackedObject) table.Row {
+	raw := obj.GetRaw()
+	if raw == nil {
+		return table.Row{obj.GetName(), obj.GetNamespace(), "", "", "", obj.GetStatus(), "", util.FormatAge(obj.GetAge())}
+	}
+
+	return table.Row{
+		obj.GetName(),
+		obj.GetNamespace(),
+		fmt.Sprintf("%d/%d", rawInt64(raw.Object, "status", "readyReplicas"), desiredReplicas(raw)),
+		fmt.Sprintf("%d", rawInt64(raw.Object, "status", "updatedReplicas")),
+		fmt.Sprintf("%d", rawInt64(raw.Object, "status", "availableReplicas")),
+		obj.GetStatus(),
+		templateImages(raw),
+		util.FormatAge(obj.GetAge()),
+	}
+}
+
+var replicaSetColumns = []table.Column{
+	{Title: "NAME", Width: 45},
+	{Title: "NAMESPACE", Width: 20},
+	{Title: "DESIRED", Width: 7},
+	{Title: "CURRENT", Width: 7},
+	{Title: "READY", Width: 7},
+	{Title: "IMAGES", Width: 30},
+	{Title: "AGE", Width: 6},
+}
+
+// replicaSetRowBinder binds a ReplicaSet to replicaSetColumns
+func replicaSetRowBinder(obj TrackedObject) table.Row {
+	raw := obj.GetRaw()
+	if raw == nil {
+		return table.Row{obj.GetName(), obj.GetNamespace(), "", "", "", "", util.FormatAge(obj.GetAge())}
+	}
+
+	return table.Row{
+		obj.GetName(),
+		obj.GetNamespace(),
+		fmt.Sprintf("%d", desiredReplicas(raw)),
+		fmt.Sprintf("%d", rawInt64(raw.Object, "status", "replicas")),
+		fmt.Sprintf("%d", rawInt64(raw.Object, "status", "readyReplicas")),
+		templateImages(raw),
+		util.FormatAge(obj.GetAge()),
+	}
+}
+
+var statefulSetColumns = []table.Column{
+	{Title: "NAME", Width: 40},
+	{Title: "NAMESPACE", Width: 20},
+	{Title: "READY", Width: 7},
+	{Title: "STATUS", Width: 18},
+	{Title: "IMAGES", Width: 30},
+	{Title: "AGE", Width: 6},
+}
+
+// statefulSetRowBinder binds a StatefulSet to statefulSetColumns
+func statefulSetRowBinder(obj TrackedObject) table.Row {
+	raw := obj.GetRaw()
+	if raw == nil {
+		return table.Row{obj.GetName(), obj.GetNamespace(), "", obj.GetStatus(), "", util.FormatAge(obj.GetAge())}
+	}
+
+	return table.Row{
+		obj.GetName(),
+		obj.GetNamespace(),
+		fmt.Sprintf("%d/%d", rawInt64(raw.Object, "status", "readyReplicas"), desiredReplicas(raw)),
+		obj.GetStatus(),
+		templateImages(raw),
+		util.FormatAge(obj.GetAge()),
+	}
+}
+
+var daemonSetColumns = []table.Column{
+	{Title: "NAME", Width: 35},
+	{Title: "NAMESPACE", Width: 20},
+	{Title: "DESIRED", Width: 7},
+	{Title: "CURRENT", Width: 7},
+	{Title: "READY", Width: 7},
+	{Title: "UP-TO-DATE", Width: 10},
+	{Title: "AVAILABLE", Width: 9},
+	{Title: "STATUS", Width: 18},
+	{Title: "NODE SELECTOR", Width: 25},
+	{Title: "AGE", Width: 6},
+}
+
+// daemonSetRowBinder binds a DaemonSet to daemonSetColumns
+func daemonSetRowBinder(obj TrackedObject) table.Row {
+	raw := obj.GetRaw()
+	if raw == nil {
+		return table.Row{obj.GetName(), obj.GetNamespace(), "", "", "", "", "", obj.GetStatus(), "", util.FormatAge(obj.GetAge())}
+	}
+
+	selector, _, _ := unstructured.NestedStringMap(raw.Object, "spec", "template", "spec", "nodeSelector")
+
+	return table.Row{
+		obj.GetName(),
+		obj.GetNamespace(),
+		fmt.Sprintf("%d", rawInt64(raw.Object, "status", "desiredNumberScheduled")),
+		fmt.Sprintf("%d", rawInt64(raw.Object, "status", "currentNumberScheduled")),
+		fmt.Sprintf("%d", rawInt64(raw.Object, "status", "numberReady")),
+		fmt.Sprintf("%d", rawInt64(raw.Object, "status", "updatedNumberScheduled")),
+		fmt.Sprintf("%d", rawInt64(raw.Object, "status", "numberAvailable")),
+		obj.GetStatus(),
+		orNone(formatLabels(selector)),
+		util.FormatAge(obj.GetAge()),
+	}
+}
+
+var jobColumns = []table.Column{
+	{Title: "NAME", Width: 40},
+	{Title: "NAMESPACE", Width: 20},
+	{Title: "STATUS", Width: 18},
+	{Title: "COMPLETIONS", Width: 11},
+	{Title: "DURATION", Width: 8},
+	{Title: "IMAGES", Width: 30},
+	{Title: "AGE", Width: 6},
+}
+
+// jobRowBinder binds a Job to jobColumns
+func jobRowBinder(obj TrackedObject) table.Row {
+	raw := obj.GetRaw()
+	if raw == nil {
+		return table.Row{obj.GetName(), obj.GetNamespace(), obj.GetStatus(), "", "", "", util.FormatAge(obj.GetAge())}
+	}
+
+	succeeded := rawInt64(raw.Object, "status", "succeeded")
+	completions := fmt.Sprintf("%d/1", succeeded)
+	if total, found, _ := unstructured.NestedInt64(raw.Object, "spec", "completions"); found {
+		completions = fmt.Sprintf("%d/%d", succeeded, total)
+	} else if parallelism := rawInt64(raw.Object, "spec", "parallelism"); parallelism > 1 {
+		// Work-queue jobs finish after one success, however many pods run in parallel
+		completions = fmt.Sprintf("%d/1 of %d", succeeded, parallelism)
+	}
+
+	duration := ""
+	if started := rawTime(raw.Object, "status", "startTime"); !started.IsZero() {
+		finished := rawTime(raw.Object, "status", "completionTime")
+		if finished.IsZero() {
+			finished = time.Now()
+		}
+		duration = util.FormatAge(finished.Sub(started))
+	}
+
+	return table.Row{
+		obj.GetName(),
+		obj.GetNamespace(),
+		obj.GetStatus(),
+		completions,
+		duration,
+		templateImages(raw),
+		util.FormatAge(obj.GetAge()),
+	}
+}
+
+var cronJobColumns = []table.Column{
+	{Title: "NAME", Width: 40},
+	{Title: "NAMESPACE", Width: 20},
+	{Title: "SCHEDULE", Width: 15},
+	{Title: "SUSPEND", Width: 7},
+	{Title: "ACTIVE", Width: 6},
+	{Title: "LAST SCHEDULE", Width: 13},
+	{Title: "AGE", Width: 6},
+}
+
+// cronJobRowBinder binds a CronJob to cronJobColumns
+func cronJobRowBinder(obj TrackedObject) table.Row {
+	raw := obj.GetRaw()
+	if raw == nil {
+		return table.Row{obj.GetName(), obj.GetNamespace(), "", "", "", "", util.FormatAge(obj.GetAge())}
+	}
+
+	suspend, _, _ := unstructured.NestedBool(raw.Object, "spec", "suspend")
+	active, _, _ := unstructured.NestedSlice(raw.Object, "status", "active")
+
+	lastSchedule := "<none>"
+	if last := rawTime(raw.Object, "status", "lastScheduleTime"); !last.IsZero() {
+		lastSchedule = util.FormatAge(time.Since(last))
+	}
+
+	return table.Row{
+		obj.GetName(),
+		obj.GetNamespace(),
+		rawString(raw.Object, "spec", "schedule"),
+		fmt.Sprintf("%t", suspend),
+		fmt.Sprintf("%d", len(active)),
+		lastSchedule,
+		util.FormatAge(obj.GetAge()),
+	}
+}
+
+var serviceColumns = []table.Column{
+	{Title: "NAME", Width: 40},
+	{Title: "NAMESPACE", Width: 20},
+	{Title: "TYPE", Width: 12},
+	{Title: "CLUSTER-IP", Width: 15},
+	{Title: "EXTERNAL-IP", Width: 20},
+	{Title: "PORT(S)", Width: 25},
+	{Title: "AGE", Width: 6},
+}
+
+// serviceRowBinder binds a Service to serviceColumns
+func serviceRowBinder(obj TrackedObject) table.Row {
+	raw := obj.GetRaw()
+	if raw == nil {
+		return table.Row{obj.GetName(), obj.GetNamespace(), "", "", "", "", util.FormatAge(obj.GetAge())}
+	}
+
+	serviceType := rawString(raw.Object, "spec", "type")
+	if serviceType == "" {
+		serviceType = "ClusterIP"
+	}
+
+	var ports []string
+	specPorts, _, _ := unstructured.NestedSlice(raw.Object, "spec", "ports")
+	for _, p := range specPorts {
+		port, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		text := fmt.Sprintf("%d", rawInt64(port, "port"))
+		if nodePort := rawInt64(port, "nodePort"); nodePort != 0 {
+			text += fmt.Sprintf(":%d", nodePort)
+		}
+		protocol := rawString(port, "protocol")
+		if protocol == "" {
+			protocol = "TCP"
+		}
+		ports = append(ports, text+"/"+protocol)
+	}
+
+	return table.Row{
+		obj.GetName(),
+		obj.GetNamespace(),
+		serviceType,
+		orNone(rawString(raw.Object, "spec", "clusterIP")),
+		serviceExternalIP(raw, serviceType),
+		orNone(strings.Join(ports, ",")),
+		util.FormatAge(obj.GetAge()),
+	}
+}
+
+// serviceExternalIP follows kubectl: load balancer ingress, then external IPs, and <pending> while provisioning
+func serviceExternalIP(raw *unstructured.Unstructured, serviceType string) string {
+	if serviceType == "ExternalName" {
+		return rawString(raw.Object, "spec", "externalName")
+	}
+
+	var addresses []string
+	if serviceType == "LoadBalancer" {
+		addresses = loadBalancerAddresses(raw)
+	}
+	externalIPs, _, _ := unstructured.NestedStringSlice(raw.Object, "spec", "externalIPs")
+	addresses = append(addresses, externalIPs...)
+
+	if len(addresses) > 0 {
+		return strings.Join(addresses, ",")
+	}
+	if serviceType == "LoadBalancer" {
+		return "<pending>"
+	}
+	return "<none>"
+}
+
+var ingressColumns = []table.Column{
+	{Title: "NAME", Width: 35},
+	{Title: "NAMESPACE", Width: 20},
+	{Title: "CLASS", Width: 12},
+	{Title: "HOSTS", Width: 35},
+	{Title: "ADDRESS", Width: 20},
+	{Title: "PORTS", Width: 7},
+	{Title: "AGE", Width: 6},
+}
+
+// ingressRowBinder binds an Ingress to ingressColumns
+func ingressRowBinder(obj TrackedObject) table.Row {
+	raw := obj.GetRaw()
+	if raw == nil {
+		return table.Row{obj.GetName(), obj.GetNamespace(), "", "", "", "", util.FormatAge(obj.GetAge())}
+	}
+
+	var hosts []string
+	rules, _, _ := unstructured.NestedSlice(raw.Object, "spec", "rules")
+	for _, r := range rules {
+		if rule, ok := r.(map[string]interface{}); ok {
+			if host := rawString(rule, "host"); host != "" {
+				hosts = append(hosts, host)
+			}
+		}
+	}
+	if len(hosts) == 0 {
+		hosts = []string{"*"}
+	}
+
+	ports := "80"
+	if tls, _, _ := unstructured.NestedSlice(raw.Object, "spec", "tls"); len(tls) > 0 {
+		ports = "80, 443"
+	}
+
+	return table.Row{
+		obj.GetName(),
+		obj.GetNamespace(),
+		orNone(rawString(raw.Object, "spec", "ingressClassName")),
+		strings.Join(hosts, ","),
+		strings.Join(loadBalancerAddresses(raw), ","),
+		ports,
+		util.FormatAge(obj.GetAge()),
+	}
+}
+
+var persistentVolumeClaimColumns = []table.Column{
+	{Title: "NAME", Width: 40},
+	{Title: "NAMESPACE", Width: 20},
+	{Title: "STATUS", Width: 14},
+	{Title: "VOLUME", Width: 30},
+	{Title: "CAPACITY", Width: 9},
+	{Title: "ACCESS MODES", Width: 12},
+	{Title: "STORAGECLASS", Width: 15},
+	{Title: "AGE", Width: 6},
+}
+
+// persistentVolumeClaimRowBinder binds a PersistentVolumeClaim to persistentVolumeClaimColumns
+func persistentVolumeClaimRowBinder(obj TrackedObject) table.Row {
+	raw := obj.GetRaw()
+	if raw == nil {
+		return table.Row{obj.GetName(), obj.GetNamespace(), obj.GetStatus(), "", "", "", "", util.FormatAge(obj.GetAge())}
+	}
+
+	accessModes, _, _ := unstructured.NestedStringSlice(raw.Object, "status", "accessModes")
+
+	return table.Row{
+		obj.GetName(),
+		obj.GetNamespace(),
+		obj.GetStatus(),
+		rawString(raw.Object, "spec", "volumeName"),
+		rawString(raw.Object, "status", "capacity", "storage"),
+		formatAccessModes(accessModes),
+		rawString(raw.Object, "spec", "storageClassName"),
+		util.FormatAge(obj.GetAge()),
+	}
+}
+
+var persistentVolumeColumns = []table.Column{
+	{Title: "NAME", Width: 40},
+	{Title: "CAPACITY", Width: 9},
+	{Title: "ACCESS MODES", Width: 12},
+	{Title: "RECLAIM POLICY", Width: 14},
+	{Title: "STATUS", Width: 10},
+	{Title: "CLAIM", Width: 35},
+	{Title: "STORAGECLASS", Width: 15},
+	{Title: "AGE", Width: 6},
+}
+
+// persistentVolumeRowBinder binds a PersistentVolume to persistentVolumeColumns
+func persistentVolumeRowBinder(obj TrackedObject) table.Row {
+	raw := obj.GetRaw()
+	if raw == nil {
+		return table.Row{obj.GetName(), "", "", "", obj.GetStatus(), "", "", util.FormatAge(obj.GetAge())}
+	}
+
+	accessModes, _, _ := unstructured.NestedStringSlice(raw.Object, "spec", "accessModes")
+
+	claim := ""
+	if claimName := rawString(raw.Object, "spec", "claimRef", "name"); claimName != "" {
+		claim = rawString(raw.Object, "spec", "claimRef", "namespace") + "/" + claimName
+	}
+
+	return table.Row{
+		obj.GetName(),
+		rawString(raw.Object, "spec", "capacity", "storage"),
+		formatAccessModes(accessModes),
+		rawString(raw.Object, "spec", "persistentVolumeReclaimPolicy"),
+		obj.GetStatus(),
+		claim,
+		rawString(raw.Object, "spec", "storageClassName"),
+		util.FormatAge(obj.GetAge()),
+	}
+}
+
+var nodeColumns = []table.Column{
+	{Title: "NAME", Width: 40},
+	{Title: "STATUS", Width: 22},
+	{Title: "ROLES", Width: 15},
+	{Title: "VERSION", Width: 12},
+	{Title: "INTERNAL-IP", Width: 15},
+	{Title: "OS-IMAGE", Width: 25},
+	{Title: "AGE", Width: 6},
+}
+
+// nodeRoleLabelPrefix is the label prefix kubectl reads node roles from
+const nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+// nodeRowBinder binds a Node to nodeColumns
+func nodeRowBinder(obj TrackedObject) table.Row {
+	raw := obj.GetRaw()
+	if raw == nil {
+		return table.Row{obj.GetName(), obj.GetStatus(), "", "", "", "", util.FormatAge(obj.GetAge())}
+	}
+
+	var roles []string
+	for label := range raw.GetLabels() {
+		if role, ok := strings.CutPrefix(label, nodeRoleLabelPrefix); ok && role != "" {
+			roles = append(roles, role)
+		}
+	}
+	sort.Strings(roles)
+
+	internalIP := ""
+	addresses, _, _ := unstructured.NestedSlice(raw.Object, "status", "addresses")
+	for _, a := range addresses {
+		if address, ok := a.(map[string]interface{}); ok && address["type"] == "InternalIP" {
+			internalIP = rawString(address, "address")
+			break
+		}
+	}
+
+	return table.Row{
+		obj.GetName(),
+		obj.GetStatus(),
+		orNone(strings.Join(roles, ",")),
+		rawString(raw.Object, "status", "nodeInfo", "kubeletVersion"),
+		orNone(internalIP),
+		rawString(raw.Object, "status", "nodeInfo", "osImage"),
+		util.FormatAge(obj.GetAge()),
+	}
+}
+
+var horizontalPodAutoscalerColumns = []table.Column{
+	{Title: "NAME", Width: 35},
+	{Title: "NAMESPACE", Width: 20},
+	{Title: "REFERENCE", Width: 30},
+	{Title: "TARGETS", Width: 25},
+	{Title: "MINPODS", Width: 7},
+	{Title: "MAXPODS", Width: 7},
+	{Title: "REPLICAS", Width: 8},
+	{Title: "AGE", Width: 6},
+}
+
+// horizontalPodAutoscalerRowBinder binds an autoscaling/v2 HorizontalPodAutoscaler to horizontalPodAutoscalerColumns
+func horizontalPodAutoscalerRowBinder(obj TrackedObject) table.Row {
+	raw := obj.GetRaw()
+	if raw == nil {
+		return table.Row{obj.GetName(), obj.GetNamespace(), "", "", "", "", "", util.FormatAge(obj.GetAge())}
+	}
+
+	minReplicas, found, _ := unstructured.NestedInt64(raw.Object, "spec", "minReplicas")
+	if !found {
+		minReplicas = 1
+	}
+
+	return table.Row{
+		obj.GetName(),
+		obj.GetNamespace(),
+		rawString(raw.Object, "spec", "scaleTargetRef", "kind") + "/" + rawString(raw.Object, "spec", "scaleTargetRef", "name"),
+		formatHPATargets(raw),
+		fmt.Sprintf("%d", minReplicas),
+		fmt.Sprintf("%d", rawInt64(raw.Object, "spec", "maxReplicas")),
+		fmt.Sprintf("%d", rawInt64(raw.Object, "status", "currentReplicas")),
+		util.FormatAge(obj.GetAge()),
+	}
+}
+
+// formatHPATargets renders each metric as current/target, e.g. "cpu: 45%/80%"
+// Current values are matched to spec metrics by position, which is how the HPA controller reports them
+func formatHPATargets(raw *unstructured.Unstructured) string {
+	specMetrics, _, _ := unstructured.NestedSlice(raw.Object, "spec", "metrics")
+	currentMetrics, _, _ := unstructured.NestedSlice(raw.Object, "status", "currentMetrics")
+	if len(specMetrics) == 0 {
+		return "<none>"
+	}
+
+	var targets []string
+	for i, m := range specMetrics {
+		metric, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var current map[string]interface{}
+		if i < len(currentMetrics) {
+			current, _ = currentMetrics[i].(map[string]interface{})
+		}
+
+		metricType := rawString(metric, "type")
+		// Each metric type nests its fields under the type name with a lowercase first letter
+		source := strings.ToLower(metricType[:min(1, len(metricType))]) + metricType[min(1, len(metricType)):]
+
+		name := rawString(metric, source, "name")
+		if name == "" {
+			name = rawString(metric, source, "metric", "name")
+		}
+
+		target := "<unknown>"
+		currentValue := "<unknown>"
+		if utilization := rawInt64(metric, source, "target", "averageUtilization"); utilization != 0 {
+			target = fmt.Sprintf("%d%%", utilization)
+			if current != nil {
+				if value, found, _ := unstructured.NestedInt64(current, source, "current", "averageUtilization"); found {
+					currentValue = fmt.Sprintf("%d%%", value)
+				}
+			}
+		} else {
+			for _, field := range []string{"averageValue", "value"} {
+				if value := rawString(metric, source, "target", field); value != "" {
+					target = value
+					if current != nil {
+						if value := rawString(current, source, "current", field); value != "" {
+							currentValue = value
+						}
+					}
+					break
+				}
+			}
+		}
+
+		targets = append(targets, fmt.Sprintf("%s: %s/%s", name, currentValue, target))
+	}
+	return strings.Join(targets, ", ")
+}
+
+// templateImages lists the container images of a workload's pod template
+func templateImages(raw *unstructured.Unstructured) string {
+	containers, _, _ := unstructured.NestedSlice(raw.Object, "spec", "template", "spec", "containers")
+
+	images := make([]string, 0, len(containers))
+	for _, c := range containers {
+		if container, ok := c.(map[string]interface{}); ok {
+			images = append(images, rawString(container, "image"))
+		}
+	}
+	return strings.Join(images, ",")
+}
+
+// loadBalancerAddresses returns the IPs or hostnames in status.loadBalancer.ingress
+func loadBalancerAddresses(raw *unstructured.Unstructured) []string {
+	ingress, _, _ := unstructured.NestedSlice(raw.Object, "status", "loadBalancer", "ingress")
+	var addresses []string
+	for _, i := range ingress {
+		entry, ok := i.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if ip := rawString(entry, "ip"); ip != "" {
+			addresses = append(addresses, ip)
+		} else if hostname := rawString(entry, "hostname"); hostname != "" {
+			addresses = append(addresses, hostname)
+		}
+	}
+	return addresses
+}
+
+// accessModeAbbreviations are the short access mode names kubectl displays
+var accessModeAbbreviations = map[string]string{
+	"ReadWriteOnce":    "RWO",
+	"ReadOnlyMany":     "ROX",
+	"ReadWriteMany":    "RWX",
+	"ReadWriteOncePod": "RWOP",
+}
+
+// formatAccessModes abbreviates volume access modes, e.g. "RWO,ROX"
+func formatAccessModes(modes []string) string {
+	short := make([]string, 0, len(modes))
+	for _, mode := range modes {
+		if abbreviation, ok := accessModeAbbreviations[mode]; ok {
+			short = append(short, abbreviation)
+		} else {
+			short = append(short, mode)
+		}
+	}
+	return strings.Join(short, ",")
+}
+
+// formatLabels renders a label map as sorted key=value pairs
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// orNone substitutes kubectl's <none> for empty values
+func orNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
+
+// rawString reads a nested string field, returning "" when it is missing or not a string
+func rawString(obj map[string]interface{}, fields ...string) string {
+	value, _, _ := unstructured.NestedString(obj, fields...)
+	return value
+}
+
+// rawInt64 reads a nested integer field, returning 0 when it is missing or not an integer
+func rawInt64(obj map[string]interface{}, fields ...string) int64 {
+	value, _, _ := unstructured.NestedInt64(obj, fields...)
+	return value
+}
+
+// rawTime reads a nested RFC 3339 timestamp, returning the zero time when it is missing or malformed
+func rawTime(obj map[string]interface{}, fields ...string) time.Time {
+	value := rawString(obj, fields...)
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
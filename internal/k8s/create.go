@@ -0,0 +1,425 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// crdEstablishTimeout bounds waiting for a CRD created alongside its custom resources to be served
+	crdEstablishTimeout = 30 * time.Second
+	// crdEstablishPollInterval is how often discovery is checked while waiting for a CRD
+	crdEstablishPollInterval = time.Second
+)
+
+// createOrder ranks kinds so that what others depend on is applied first, following Helm's install order
+// with CustomResourceDefinitions moved up next to Namespaces; kinds not listed are applied last
+var createOrder = []string{
+	"Namespace",
+	"CustomResourceDefinition",
+	"NetworkPolicy",
+	"ResourceQuota",
+	"LimitRange",
+	"PodDisruptionBudget",
+	"ServiceAccount",
+	"Secret",
+	"ConfigMap",
+	"StorageClass",
+	"PersistentVolume",
+	"PersistentVolumeClaim",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"Role",
+	"RoleBinding",
+	"Service",
+	"DaemonSet",
+	"Pod",
+	"ReplicationController",
+	"ReplicaSet",
+	"Deployment",
+	"HorizontalPodAutoscaler",
+	"StatefulSet",
+	"Job",
+	"CronJob",
+	"IngressClass",
+	"Ingress",
+	"APIService",
+}
+
+// ManifestState is how far a manifest document has got through being created
+type ManifestState int
+
+const (
+	ManifestInvalid ManifestState = iota
+	ManifestReady
+	ManifestDeferred
+	ManifestCreated
+	ManifestConfigured
+	ManifestFailed
+)
+
+// String returns a human-readable state
+func (s ManifestState) String() string {
+	switch s {
+	case ManifestReady:
+		return "Ready"
+	case ManifestDeferred:
+		return "Deferred"
+	case ManifestCreated:
+		return "Created"
+	case ManifestConfigured:
+		return "Configured"
+	case ManifestFailed:
+		return "Failed"
+	default:
+		return "Invalid"
+	}
+}
+
+// ManifestDocument is one object of a manifest being created
+type ManifestDocument struct {
+	Index  int // Position in the source, starting at 1
+	Object *unstructured.Unstructured
+	State  ManifestState
+	// Exists is set by the dry run when the object is already on the cluster, so applying it updates it
+	Exists bool
+	// Note explains a deferred dry run
+	Note string
+	Err  error
+}
+
+// Describe names the document's object, e.g. "Deployment default/web"
+func (d *ManifestDocument) Describe() string {
+	name := d.Object.GetName()
+	if name == "" {
+		name = d.Object.GetGenerateName() + "*"
+	}
+	if namespace := d.Object.GetNamespace(); namespace != "" {
+		return fmt.Sprintf("%s %s/%s", d.Object.GetKind(), namespace, name)
+	}
+	return fmt.Sprintf("%s %s", d.Object.GetKind(), name)
+}
+
+// ParseManifests splits multi-document YAML or JSON into documents, expanding Lists
+// Documents that aren't valid objects are returned in the Invalid state rather than failing the whole set
+func ParseManifests(data []byte) ([]*ManifestDocument, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+
+	var objects []map[string]interface{}
+	for {
+		var obj map[string]interface{}
+		if err := decoder.Decode(&obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse document %d: %w", len(objects)+1, err)
+		}
+		if len(obj) == 0 {
+			continue // Empty document, e.g. a trailing ---
+		}
+
+		// A List's items are created as documents of their own
+		if items, ok := obj["items"].([]interface{}); ok && obj["kind"] == "List" {
+			for _, item := range items {
+				if itemObj, ok := item.(map[string]interface{}); ok {
+					objects = append(objects, itemObj)
+				}
+			}
+			continue
+		}
+		objects = append(objects, obj)
+	}
+
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("no objects found")
+	}
+
+	docs := make([]*ManifestDocument, len(objects))
+	for i, obj := range objects {
+		doc := &ManifestDocument{Index: i + 1, Object: &unstructured.Unstructured{Object: obj}, State: ManifestReady}
+		doc.Err = validateManifest(doc.Object)
+		if doc.Err != nil {
+			doc.State = ManifestInvalid
+		}
+		docs[i] = doc
+	}
+	return docs, nil
+}
+
+// validateManifest checks that an object has the fields needed to create it
+func validateManifest(obj *unstructured.Unstructured) error {
+	switch {
+	case obj.GetAPIVersion() == "":
+		return fmt.Errorf("missing required 'apiVersion' field")
+	case obj.GetKind() == "":
+		return fmt.Errorf("missing required 'kind' field")
+	case obj.GetName() == "" && obj.GetGenerateName() == "":
+		return fmt.Errorf("missing required 'metadata.name' field")
+	case obj.GetName() == "":
+		// Server-side apply needs a name to identify the object
+		return fmt.Errorf("metadata.generateName isn't supported; set metadata.name")
+	}
+	return nil
+}
+
+// SortManifests orders documents so that what others depend on is created first
+// Documents of the same rank keep the order they had in the source
+func SortManifests(docs []*ManifestDocument) {
+	sort.SliceStable(docs, func(i, j int) bool {
+		return createRank(docs[i].Object.GetKind()) < createRank(docs[j].Object.GetKind())
+	})
+}
+
+// createRank returns where a kind comes in createOrder; kinds not listed rank last
+func createRank(kind string) int {
+	for i, ordered := range createOrder {
+		if ordered == kind {
+			return i
+		}
+	}
+	return len(createOrder)
+}
+
+// LookupKind finds the API resource serving a kind, or returns nil if the cluster doesn't serve it
+func (c *Client) LookupKind(gvk schema.GroupVersionKind) (*metav1.APIResource, error) {
+	list, err := c.Clientset.Discovery().ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to discover %s: %w", gvk.GroupVersion(), err)
+	}
+	for i := range list.APIResources {
+		resource := &list.APIResources[i]
+		// Subresources such as deployments/scale share the kind of their parent
+		if resource.Kind == gvk.Kind && !isSubresource(resource.Name) {
+			return resource, nil
+		}
+	}
+	return nil, nil
+}
+
+// LookupResource finds the API resource for a group/version/resource, or returns nil if the cluster doesn't serve it
+func (c *Client) LookupResource(gvr schema.GroupVersionResource) (*metav1.APIResource, error) {
+	list, err := c.Clientset.Discovery().ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to discover %s: %w", gvr.GroupVersion(), err)
+	}
+	for i := range list.APIResources {
+		if list.APIResources[i].Name == gvr.Resource {
+			return &list.APIResources[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// isSubresource reports whether an API resource name names a subresource, e.g. "pods/log"
+func isSubresource(name string) bool {
+	return strings.Contains(name, "/")
+}
+
+// PrepareCreateFile writes a template for a new object of a type to a temporary file for editing
+func (c *Client) PrepareCreateFile(gvr schema.GroupVersionResource, namespace string) (*EditResult, error) {
+	resource, err := c.LookupResource(gvr)
+	if err != nil {
+		return nil, err
+	}
+	if resource == nil {
+		return nil, fmt.Errorf("the cluster doesn't serve %s", gvr.String())
+	}
+
+	template := ResourceTemplate(gvr.GroupVersion().String(), resource.Kind, resource.Namespaced, namespace)
+	content := []byte("# New " + resource.Kind + ". Save to preview it with a server-side dry run; several\n" +
+		"# documents separated by --- are created together. Quit without saving to cancel.\n" + template)
+
+	tmpfile, err := os.CreateTemp("", fmt.Sprintf("lobot-new-%s-*.yaml", resource.Kind))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer tmpfile.Close()
+
+	if _, err := tmpfile.Write(content); err != nil {
+		os.Remove(tmpfile.Name())
+		return nil, fmt.Errorf("failed to write to temporary file: %w", err)
+	}
+
+	return &EditResult{TmpFilePath: tmpfile.Name(), OriginalContent: content}, nil
+}
+
+// crdServedKinds returns the group/kinds the CustomResourceDefinitions among the documents define
+func crdServedKinds(docs []*ManifestDocument) map[schema.GroupKind]bool {
+	kinds := make(map[schema.GroupKind]bool)
+	for _, doc := range docs {
+		if doc.State == ManifestInvalid || doc.Object.GetKind() != "CustomResourceDefinition" {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(doc.Object.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(doc.Object.Object, "spec", "names", "kind")
+		kinds[schema.GroupKind{Group: group, Kind: kind}] = true
+	}
+	return kinds
+}
+
+// createdNamespaces returns the namespaces the documents create
+func createdNamespaces(docs []*ManifestDocument) map[string]bool {
+	namespaces := make(map[string]bool)
+	for _, doc := range docs {
+		if doc.State != ManifestInvalid && doc.Object.GetKind() == "Namespace" && doc.Object.GetAPIVersion() == "v1" {
+			namespaces[doc.Object.GetName()] = true
+		}
+	}
+	return namespaces
+}
+
+// DryRunManifests checks each document with a server-side dry-run apply, in place
+// Documents depending on a CRD or Namespace the same set creates can't be checked until it exists and are deferred
+// Namespaced objects without a namespace are put in defaultNamespace
+func (c *Client) DryRunManifests(ctx context.Context, docs []*ManifestDocument, defaultNamespace string) {
+	crdKinds := crdServedKinds(docs)
+	namespaces := createdNamespaces(docs)
+
+	for _, doc := range docs {
+		if doc.State == ManifestInvalid {
+			continue
+		}
+		gvk := doc.Object.GroupVersionKind()
+
+		resource, err := c.LookupKind(gvk)
+		if err != nil {
+			doc.State, doc.Err = ManifestInvalid, err
+			continue
+		}
+		if resource == nil {
+			if crdKinds[gvk.GroupKind()] {
+				doc.State, doc.Note = ManifestDeferred, "its CustomResourceDefinition is created first"
+				continue
+			}
+			doc.State, doc.Err = ManifestInvalid, fmt.Errorf("the cluster doesn't serve kind %s in %s", gvk.Kind, gvk.GroupVersion())
+			continue
+		}
+
+		setManifestNamespace(doc.Object, resource.Namespaced, defaultNamespace)
+		if namespaces[doc.Object.GetNamespace()] {
+			if _, err := c.Clientset.CoreV1().Namespaces().Get(ctx, doc.Object.GetNamespace(), metav1.GetOptions{}); apierrors.IsNotFound(err) {
+				doc.State, doc.Note = ManifestDeferred, "its Namespace is created first"
+				continue
+			}
+		}
+
+		doc.Exists, doc.Err = c.applyManifest(ctx, doc.Object, resource, true)
+		if doc.Err != nil {
+			doc.State = ManifestInvalid
+		}
+	}
+}
+
+// ApplyManifest creates or updates one document's object with server-side apply
+// A deferred document waits for a CRD it depends on to be served first
+func (c *Client) ApplyManifest(ctx context.Context, doc *ManifestDocument, defaultNamespace string) (ManifestState, error) {
+	gvk := doc.Object.GroupVersionKind()
+	obj := doc.Object.DeepCopy()
+
+	resource, err := c.LookupKind(gvk)
+	deadline := time.Now().Add(crdEstablishTimeout)
+	for err == nil && resource == nil && doc.State == ManifestDeferred && time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return ManifestFailed, ctx.Err()
+		case <-time.After(crdEstablishPollInterval):
+		}
+		resource, err = c.LookupKind(gvk)
+	}
+	if err != nil {
+		return ManifestFailed, err
+	}
+	if resource == nil {
+		return ManifestFailed, fmt.Errorf("the cluster doesn't serve kind %s in %s", gvk.Kind, gvk.GroupVersion())
+	}
+
+	setManifestNamespace(obj, resource.Namespaced, defaultNamespace)
+
+	c.Logger.Info("Applying manifest", "kind", obj.GetKind(), "name", obj.GetName(), "namespace", obj.GetNamespace())
+
+	existed, err := c.applyManifest(ctx, obj, resource, false)
+	switch {
+	case err != nil:
+		return ManifestFailed, err
+	case existed:
+		return ManifestConfigured, nil
+	default:
+		return ManifestCreated, nil
+	}
+}
+
+// setManifestNamespace puts a namespaced object without a namespace in the default one,
+// and clears the namespace of a cluster-scoped object
+func setManifestNamespace(obj *unstructured.Unstructured, namespaced bool, defaultNamespace string) {
+	switch {
+	case !namespaced:
+		obj.SetNamespace("")
+	case obj.GetNamespace() == "":
+		obj.SetNamespace(defaultNamespace)
+	}
+}
+
+// applyManifest server-side applies an object, reporting whether it already existed
+func (c *Client) applyManifest(ctx context.Context, obj *unstructured.Unstructured, resource *metav1.APIResource, dryRun bool) (bool, error) {
+	gvr := obj.GroupVersionKind().GroupVersion().WithResource(resource.Name)
+	resourceInterface, err := c.dynamicResource(gvr, obj.GetNamespace())
+	if err != nil {
+		return false, err
+	}
+
+	_, err = resourceInterface.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, describeAPIError("read", err)
+	}
+	existed := err == nil
+
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return existed, fmt.Errorf("failed to encode resource: %w", err)
+	}
+
+	force := false
+	opts := metav1.PatchOptions{FieldManager: FieldManager, Force: &force}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	if _, err := resourceInterface.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, opts); err != nil {
+		return existed, describeCreateError(err)
+	}
+	return existed, nil
+}
+
+// describeCreateError maps API errors from creating an object to friendlier messages
+func describeCreateError(err error) error {
+	switch {
+	case apierrors.IsNotFound(err):
+		return fmt.Errorf("not found: check that the object's namespace exists: %w", err)
+	case apierrors.IsConflict(err):
+		return fmt.Errorf("conflict: other field managers own fields this manifest sets: %w", err)
+	case apierrors.IsInvalid(err):
+		return fmt.Errorf("validation failed: the manifest failed Kubernetes validation: %w", err)
+	default:
+		return describeAPIError("create", err)
+	}
+}
@@ -0,0 +1,122 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// DeleteOptions controls how a resource is deleted
+type DeleteOptions struct {
+	Propagation metav1.DeletionPropagation
+	// GracePeriodSeconds overrides the resource's termination grace period; nil keeps the default
+	GracePeriodSeconds *int64
+}
+
+// resourceInterfaceFor returns the dynamic client interface for a resource's type and namespace
+// Only objects backed by a Kubernetes resource (not Helm releases) have one
+func (c *Client) resourceInterfaceFor(resource TrackedObject) (dynamic.ResourceInterface, error) {
+	gvr, err := gvrFor(resource)
+	if err != nil {
+		return nil, err
+	}
+	return c.dynamicResource(gvr, resource.GetNamespace())
+}
+
+// gvrFor returns the group/version/resource of an object backed by a Kubernetes resource
+func gvrFor(resource TrackedObject) (schema.GroupVersionResource, error) {
+	switch res := resource.(type) {
+	case *K8sResource:
+		return res.GVR, nil
+	case *ArgoCDApp:
+		return res.GVR, nil
+	default:
+		return schema.GroupVersionResource{}, fmt.Errorf("resource type %T is not a Kubernetes resource", resource)
+	}
+}
+
+// GetObject fetches the current state of a resource from the cluster
+func (c *Client) GetObject(ctx context.Context, resource TrackedObject) (*unstructured.Unstructured, error) {
+	resourceInterface, err := c.resourceInterfaceFor(resource)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := resourceInterface.Get(ctx, resource.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return nil, describeAPIError("read", err)
+	}
+	return obj, nil
+}
+
+// dynamicResource returns the dynamic client interface for a type, scoped to a namespace unless it is empty
+func (c *Client) dynamicResource(gvr schema.GroupVersionResource, namespace string) (dynamic.ResourceInterface, error) {
+	dynamicClient, err := dynamic.NewForConfig(c.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	if namespace != "" {
+		return dynamicClient.Resource(gvr).Namespace(namespace), nil
+	}
+	return dynamicClient.Resource(gvr), nil
+}
+
+// describeAPIError maps common API errors to friendlier messages
+// action is what was attempted, e.g. "delete", and completes "you don't have permission to ..."
+func describeAPIError(action string, err error) error {
+	switch {
+	case errors.IsNotFound(err):
+		return fmt.Errorf("not found: resource no longer exists on the cluster. "+
+			"It may have already been deleted: %w", err)
+	case errors.IsForbidden(err):
+		return fmt.Errorf("forbidden: you don't have permission to %s this resource: %w", action, err)
+	case errors.IsConflict(err):
+		return fmt.Errorf("conflict: resource was modified on the cluster. Please try again: %w", err)
+	case errors.IsInvalid(err):
+		return fmt.Errorf("validation failed: the request failed Kubernetes validation: %w", err)
+	default:
+		return fmt.Errorf("failed to %s resource on cluster: %w", action, err)
+	}
+}
+
+// DeleteResource deletes a resource from the cluster
+func (c *Client) DeleteResource(ctx context.Context, resource TrackedObject, opts DeleteOptions) error {
+	resourceInterface, err := c.resourceInterfaceFor(resource)
+	if err != nil {
+		return err
+	}
+
+	deleteOptions := metav1.DeleteOptions{GracePeriodSeconds: opts.GracePeriodSeconds}
+	if opts.Propagation != "" {
+		deleteOptions.PropagationPolicy = &opts.Propagation
+	}
+
+	c.Logger.Info("Deleting resource",
+		"kind", resource.GetKind(),
+		"name", resource.GetName(),
+		"namespace", resource.GetNamespace(),
+		"propagation", opts.Propagation)
+
+	if err := resourceInterface.Delete(ctx, resource.GetName(), deleteOptions); err != nil {
+		return describeAPIError("delete", err)
+	}
+	return nil
+}
+
+// RemoveFinalizers clears a resource's finalizers, letting a resource stuck in Terminating be removed
+// This skips whatever cleanup the finalizers' controllers would have done
+func (c *Client) RemoveFinalizers(ctx context.Context, resource TrackedObject) error {
+	c.Logger.Warn("Removing finalizers",
+		"kind", resource.GetKind(),
+		"name", resource.GetName(),
+		"namespace", resource.GetNamespace())
+
+	return c.patchResource(ctx, resource, "patch", map[string]interface{}{
+		"metadata": map[string]interface{}{"finalizers": nil},
+	})
+}
@@ -11,11 +11,13 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 )
 
 // ResourceDiscovery handles discovering all available resource types in the cluster
 type ResourceDiscovery struct {
 	discoveryClient discovery.DiscoveryInterface
+	dynamicClient   dynamic.Interface
 	cache           []*TrackedType
 	lastRefresh     time.Time
 	mu              sync.RWMutex
@@ -28,8 +30,15 @@ func NewResourceDiscovery(client *Client, logger *slog.Logger) *ResourceDiscover
 		logger = slog.Default()
 	}
 
+	// The dynamic client is only used to read CRD printer columns; discovery still works without it
+	dynamicClient, err := dynamic.NewForConfig(client.Config)
+	if err != nil {
+		logger.Warn("Failed to create dynamic client for CRD printer columns", "error", err)
+	}
+
 	return &ResourceDiscovery{
 		discoveryClient: client.Clientset.Discovery(),
+		dynamicClient:   dynamicClient,
 		cache:           []*TrackedType{},
 		logger:          logger,
 	}
@@ -57,6 +66,21 @@ func (rd *ResourceDiscovery) DiscoverAllResources() ([]*TrackedType, error) {
 		rd.logger.Debug("Discovery returned partial results", "error", err)
 	}
 
+	// Built-in types keep their predefined columns; custom resources use their CRD's printer columns
+	builtinTypes := make(map[schema.GroupVersionResource]*TrackedType)
+	for _, rt := range DefaultResourceTypes() {
+		builtinTypes[rt.GVR] = rt
+	}
+
+	var printerColumns map[schema.GroupVersionResource][]*printerColumn
+	if rd.dynamicClient != nil {
+		printerColumns, err = loadPrinterColumns(rd.dynamicClient)
+		if err != nil {
+			// Usually RBAC; custom resources fall back to the generic columns
+			rd.logger.Debug("Failed to load CRD printer columns", "error", err)
+		}
+	}
+
 	resourceMap := make(map[string]*TrackedType)
 
 	for _, apiResourceList := range apiResourceLists {
@@ -98,7 +122,14 @@ func (rd *ResourceDiscovery) DiscoverAllResources() ([]*TrackedType, error) {
 
 			// Use a unique key to avoid duplicates (prefer newer versions)
 			key := fmt.Sprintf("%s/%s", gv.Group, apiResource.Kind)
-			resourceMap[key] = NewTrackedType(gvr, displayName, apiResource.Namespaced)
+			if builtin, ok := builtinTypes[gvr]; ok {
+				resourceMap[key] = builtin
+			} else if columns, ok := printerColumns[gvr]; ok {
+				resourceMap[key] = NewCustomTrackedType(gvr, displayName, apiResource.Namespaced,
+					printerColumnTableParams(columns, apiResource.Namespaced))
+			} else {
+				resourceMap[key] = NewTrackedType(gvr, displayName, apiResource.Namespaced)
+			}
 		}
 	}
 
@@ -6,24 +6,54 @@ import (
 	"fmt"
 	"os"
 
-	"k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/yaml"
 )
 
+// lastAppliedAnnotation holds the configuration kubectl apply last applied, a copy of the whole object
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// editHiddenFields are the server-populated fields left out of the editor buffer unless the full object is asked for
+// They are put back from the original object when a cleaned buffer is saved
+var editHiddenFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "uid"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "selfLink"},
+	{"metadata", "annotations", lastAppliedAnnotation},
+	{"status"},
+}
+
+// editHiddenFieldsHeader is written at the top of a cleaned editor buffer
+const editHiddenFieldsHeader = "# status, managedFields, uid, resourceVersion, creationTimestamp and the last-applied annotation\n" +
+	"# are hidden and kept as they are. Edit with ctrl+e to see the full object.\n"
+
 // EditResult contains information about the edit operation
 type EditResult struct {
 	TmpFilePath     string
 	OriginalContent []byte
+	Cleaned         bool // Set when the fields hidden from the editor were left out of the buffer
+}
+
+// cleanForEdit returns a copy of an object without the fields hidden from the editor
+func cleanForEdit(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	clean := obj.DeepCopy()
+	for _, path := range editHiddenFields {
+		unstructured.RemoveNestedField(clean.Object, path...)
+	}
+	if len(clean.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(clean.Object, "metadata", "annotations")
+	}
+	return clean
 }
 
 // PrepareEditFile creates a temporary file with the resource YAML for editing
 // This should be called BEFORE suspending the TUI
 // Only resources with Raw objects can be edited (not Helm releases)
-func (c *Client) PrepareEditFile(resource TrackedObject) (*EditResult, error) {
+// Server-populated fields are hidden from the buffer unless full is set
+func (c *Client) PrepareEditFile(resource TrackedObject, full bool) (*EditResult, error) {
 	raw := resource.GetRaw()
 	if raw == nil {
 		return nil, fmt.Errorf("resource cannot be edited (no underlying Kubernetes object)")
@@ -32,13 +62,22 @@ func (c *Client) PrepareEditFile(resource TrackedObject) (*EditResult, error) {
 	c.Logger.Info("Preparing resource for editing",
 		"name", resource.GetName(),
 		"namespace", resource.GetNamespace(),
-		"kind", resource.GetKind())
+		"kind", resource.GetKind(),
+		"full", full)
+
+	obj := raw
+	if !full {
+		obj = cleanForEdit(raw)
+	}
 
 	// Marshal resource to YAML
-	yamlBytes, err := yaml.Marshal(raw.Object)
+	yamlBytes, err := yaml.Marshal(obj.Object)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal resource to YAML: %w", err)
 	}
+	if !full {
+		yamlBytes = append([]byte(editHiddenFieldsHeader), yamlBytes...)
+	}
 
 	// Create temporary file
 	tmpfile, err := os.CreateTemp("", fmt.Sprintf("lobot-%s-%s-*.yaml", resource.GetKind(), resource.GetName()))
@@ -60,14 +99,15 @@ func (c *Client) PrepareEditFile(resource TrackedObject) (*EditResult, error) {
 	return &EditResult{
 		TmpFilePath:     tmpfilePath,
 		OriginalContent: yamlBytes,
+		Cleaned:         !full,
 	}, nil
 }
 
-// ProcessEditedFile reads, validates, and applies the edited resource
-// This should be called AFTER the editor exits
-func (c *Client) ProcessEditedFile(ctx context.Context, resource TrackedObject, editResult *EditResult) error {
+// ProcessEditedFile reads and validates the edited resource, then previews it with a server-side dry run
+// This should be called AFTER the editor exits; it returns a nil preview when nothing was changed
+func (c *Client) ProcessEditedFile(ctx context.Context, resource TrackedObject, editResult *EditResult) (*EditPreview, error) {
 	if editResult == nil {
-		return fmt.Errorf("invalid edit result")
+		return nil, fmt.Errorf("invalid edit result")
 	}
 
 	c.Logger.Info("Processing edited file", "path", editResult.TmpFilePath)
@@ -75,13 +115,13 @@ func (c *Client) ProcessEditedFile(ctx context.Context, resource TrackedObject,
 	// Read edited content
 	editedBytes, err := os.ReadFile(editResult.TmpFilePath)
 	if err != nil {
-		return fmt.Errorf("failed to read edited file: %w", err)
+		return nil, fmt.Errorf("failed to read edited file: %w", err)
 	}
 
 	// Check if content actually changed
 	if bytes.Equal(editedBytes, editResult.OriginalContent) {
 		c.Logger.Info("No changes detected, edit cancelled or no modifications made")
-		return nil // Not an error - user cancelled or made no changes
+		return nil, nil // Not an error - user cancelled or made no changes
 	}
 
 	c.Logger.Info("Changes detected, validating edited content")
@@ -89,27 +129,23 @@ func (c *Client) ProcessEditedFile(ctx context.Context, resource TrackedObject,
 	// Parse edited YAML
 	var editedObj map[string]interface{}
 	if err := yaml.Unmarshal(editedBytes, &editedObj); err != nil {
-		return fmt.Errorf("failed to parse edited YAML (syntax error): %w", err)
+		return nil, fmt.Errorf("failed to parse edited YAML (syntax error): %w", err)
 	}
 
 	// Validate the edited manifest
-	if err := c.ValidateEditedManifest(resource, editedObj); err != nil {
-		return err
+	if err := c.ValidateEditedManifest(resource, editedObj, editResult.Cleaned); err != nil {
+		return nil, err
 	}
 
-	c.Logger.Info("Validation passed, applying changes to cluster")
-
-	// Apply the changes
-	if err := c.UpdateResource(ctx, resource, editedObj); err != nil {
-		return err
-	}
+	c.Logger.Info("Validation passed, previewing changes with a server-side dry run")
 
-	c.Logger.Info("Resource updated successfully")
-	return nil
+	return c.PreviewEdit(ctx, resource, resource.GetRaw(), &unstructured.Unstructured{Object: editedObj})
 }
 
 // ValidateEditedManifest validates that the edited manifest is a valid Kubernetes resource
-func (c *Client) ValidateEditedManifest(original TrackedObject, editedObj map[string]interface{}) error {
+// When the buffer was cleaned, the fields hidden from the editor are put back from the original; a full edit
+// is taken as saved, so hidden fields deleted from it stay deleted
+func (c *Client) ValidateEditedManifest(original TrackedObject, editedObj map[string]interface{}, cleaned bool) error {
 	// Check required fields
 	apiVersion, ok := editedObj["apiVersion"].(string)
 	if !ok || apiVersion == "" {
@@ -149,84 +185,27 @@ func (c *Client) ValidateEditedManifest(original TrackedObject, editedObj map[st
 		}
 	}
 
-	// Ensure resourceVersion is preserved from original for optimistic locking
-	raw := original.GetRaw()
-	if raw != nil {
-		if origMetadata, ok := raw.Object["metadata"].(map[string]interface{}); ok {
-			if resourceVersion, ok := origMetadata["resourceVersion"].(string); ok {
-				metadata["resourceVersion"] = resourceVersion
-				c.Logger.Debug("Preserved resourceVersion for optimistic locking", "version", resourceVersion)
-			}
-		}
+	// Put back the fields hidden from the editor, so the edited object is complete again
+	if raw := original.GetRaw(); cleaned && raw != nil {
+		restoreHiddenFields(raw, editedObj)
 	}
 
 	c.Logger.Debug("Manifest validation passed", "name", name, "kind", kind)
 	return nil
 }
 
-// UpdateResource updates a Kubernetes resource with new content
-func (c *Client) UpdateResource(ctx context.Context, originalResource TrackedObject, editedObj map[string]interface{}) error {
-	// Create dynamic client
-	dynamicClient, err := dynamic.NewForConfig(c.Config)
-	if err != nil {
-		return fmt.Errorf("failed to create dynamic client: %w", err)
-	}
-
-	// Convert edited object to unstructured
-	unstructuredObj := &unstructured.Unstructured{Object: editedObj}
-
-	// Get GVR (GroupVersionResource) from the resource
-	// Both K8sResource and ArgoCDApp have GVR fields
-	var gvr schema.GroupVersionResource
-	switch res := originalResource.(type) {
-	case *K8sResource:
-		gvr = res.GVR
-	case *ArgoCDApp:
-		gvr = res.GVR
-	default:
-		return fmt.Errorf("resource type %T cannot be edited", originalResource)
-	}
-
-	// Get resource interface
-	var resourceInterface dynamic.ResourceInterface
-	namespace := originalResource.GetNamespace()
-	if namespace != "" {
-		resourceInterface = dynamicClient.Resource(gvr).Namespace(namespace)
-	} else {
-		resourceInterface = dynamicClient.Resource(gvr)
-	}
-
-	c.Logger.Debug("Updating resource",
-		"gvr", gvr.String(),
-		"name", originalResource.GetName(),
-		"namespace", namespace)
-
-	// Update the resource
-	_, err = resourceInterface.Update(ctx, unstructuredObj, metav1.UpdateOptions{})
-	if err != nil {
-		// Provide helpful error messages based on error type
-		if errors.IsConflict(err) {
-			return fmt.Errorf("conflict: resource was modified on the cluster after you opened the editor. "+
-				"The resource version has changed. Please try editing again to get the latest version: %w", err)
-		}
-
-		if errors.IsInvalid(err) {
-			return fmt.Errorf("validation failed: the edited manifest failed Kubernetes validation. "+
-				"Check that all required fields are present and valid: %w", err)
-		}
-
-		if errors.IsNotFound(err) {
-			return fmt.Errorf("not found: resource no longer exists on the cluster. "+
-				"It may have been deleted while you were editing: %w", err)
+// restoreHiddenFields copies the fields hidden from the editor from the original object into the edited one
+// Fields the edit added back are left as edited
+func restoreHiddenFields(original *unstructured.Unstructured, editedObj map[string]interface{}) {
+	for _, path := range editHiddenFields {
+		if _, found, _ := unstructured.NestedFieldNoCopy(editedObj, path...); found {
+			continue
 		}
-
-		if errors.IsForbidden(err) {
-			return fmt.Errorf("forbidden: you don't have permission to update this resource: %w", err)
+		value, found, _ := unstructured.NestedFieldCopy(original.Object, path...)
+		if !found {
+			continue
 		}
-
-		return fmt.Errorf("failed to update resource on cluster: %w", err)
+		// A path blocked by an edited non-map value is left for the server's validation to report
+		_ = unstructured.SetNestedField(editedObj, value, path...)
 	}
-
-	return nil
 }
-
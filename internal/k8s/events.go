@@ -0,0 +1,106 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/miles-w-3/lobot/internal/util"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// EventInfo holds the fields of a core/v1 Event that are shown in the UI
+type EventInfo struct {
+	Namespace string
+	Type      string // "Normal" or "Warning"
+	Reason    string
+	Message   string
+	Object    string // Kind/Name of the involved object
+	ObjectUID string
+	Count     int64
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// GetEventInfo extracts event fields from an Event's cached manifest
+// Events recorded through events.k8s.io/v1 leave count and lastTimestamp empty and use series and eventTime instead
+func GetEventInfo(event TrackedObject) EventInfo {
+	raw := event.GetRaw()
+	if raw == nil {
+		return EventInfo{Namespace: event.GetNamespace()}
+	}
+
+	info := EventInfo{Namespace: raw.GetNamespace()}
+	info.Type, _, _ = unstructured.NestedString(raw.Object, "type")
+	info.Reason, _, _ = unstructured.NestedString(raw.Object, "reason")
+	info.Message, _, _ = unstructured.NestedString(raw.Object, "message")
+	info.ObjectUID, _, _ = unstructured.NestedString(raw.Object, "involvedObject", "uid")
+
+	kind, _, _ := unstructured.NestedString(raw.Object, "involvedObject", "kind")
+	name, _, _ := unstructured.NestedString(raw.Object, "involvedObject", "name")
+	info.Object = fmt.Sprintf("%s/%s", kind, name)
+
+	info.Count, _, _ = unstructured.NestedInt64(raw.Object, "count")
+	if info.Count == 0 {
+		info.Count, _, _ = unstructured.NestedInt64(raw.Object, "series", "count")
+	}
+	if info.Count == 0 {
+		info.Count = 1
+	}
+
+	info.FirstSeen = eventTimestamp(raw, []string{"firstTimestamp"}, []string{"eventTime"})
+	if info.FirstSeen.IsZero() {
+		info.FirstSeen = raw.GetCreationTimestamp().Time
+	}
+	info.LastSeen = eventTimestamp(raw, []string{"series", "lastObservedTime"}, []string{"lastTimestamp"}, []string{"eventTime"})
+	if info.LastSeen.IsZero() {
+		info.LastSeen = info.FirstSeen
+	}
+
+	return info
+}
+
+// eventTimestamp returns the first of the given timestamp fields that is set
+func eventTimestamp(raw *unstructured.Unstructured, fields ...[]string) time.Time {
+	for _, field := range fields {
+		value, found, _ := unstructured.NestedString(raw.Object, field...)
+		if !found || value == "" {
+			continue
+		}
+		// eventTime and series timestamps carry microseconds, the legacy fields don't
+		for _, layout := range []string{time.RFC3339Nano, time.RFC3339} {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// eventRowBinder binds an Event to the columns of EventResource
+func eventRowBinder(event TrackedObject) table.Row {
+	info := GetEventInfo(event)
+
+	lastSeen := "<unknown>"
+	if !info.LastSeen.IsZero() {
+		lastSeen = util.FormatAge(time.Since(info.LastSeen))
+	}
+
+	return table.Row{
+		info.Namespace,
+		info.Type,
+		util.Truncate(info.Reason, 20),
+		util.Truncate(info.Object, 35),
+		fmt.Sprintf("%d", info.Count),
+		lastSeen,
+		info.Message,
+	}
+}
+
+// sortEventsByLastSeen orders events from oldest to newest
+func sortEventsByLastSeen(events []EventInfo) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LastSeen.Before(events[j].LastSeen)
+	})
+}
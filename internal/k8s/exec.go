@@ -0,0 +1,131 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// DefaultShells are the shells tried, in order, when no shell is configured
+var DefaultShells = []string{"/bin/bash", "/bin/sh"}
+
+// ExecOptions describes an interactive command session in a container
+type ExecOptions struct {
+	Namespace string
+	Pod       string
+	Container string
+	Command   []string
+	Stdin     io.Reader
+	Stdout    io.Writer
+	Stderr    io.Writer
+	TTY       bool
+	SizeQueue remotecommand.TerminalSizeQueue // Propagates terminal resizes when TTY is set
+}
+
+// Exec runs a command in a container, streaming stdio until the command exits
+// The WebSocket protocol is preferred, falling back to SPDY for API servers that don't support it
+func (c *Client) Exec(ctx context.Context, opts ExecOptions) error {
+	req := c.Clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(opts.Pod).
+		Namespace(opts.Namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: opts.Container,
+			Command:   opts.Command,
+			Stdin:     opts.Stdin != nil,
+			Stdout:    opts.Stdout != nil,
+			Stderr:    opts.Stderr != nil && !opts.TTY, // stderr is merged into stdout with a TTY
+			TTY:       opts.TTY,
+		}, scheme.ParameterCodec)
+
+	spdyExecutor, err := remotecommand.NewSPDYExecutor(c.Config, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to create SPDY executor: %w", err)
+	}
+
+	websocketExecutor, err := remotecommand.NewWebSocketExecutor(c.Config, "GET", req.URL().String())
+	if err != nil {
+		return fmt.Errorf("failed to create WebSocket executor: %w", err)
+	}
+
+	executor, err := remotecommand.NewFallbackExecutor(websocketExecutor, spdyExecutor, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	streamOpts := remotecommand.StreamOptions{
+		Stdin:             opts.Stdin,
+		Stdout:            opts.Stdout,
+		Tty:               opts.TTY,
+		TerminalSizeQueue: opts.SizeQueue,
+	}
+	if !opts.TTY {
+		streamOpts.Stderr = opts.Stderr
+	}
+
+	return executor.StreamWithContext(ctx, streamOpts)
+}
+
+// ExecShell starts an interactive shell in a container
+// Shells are probed in order and the first one that exists in the container is started
+func (c *Client) ExecShell(ctx context.Context, shells []string, opts ExecOptions) error {
+	if len(shells) == 0 {
+		shells = DefaultShells
+	}
+
+	for _, shell := range shells {
+		available, err := c.probeShell(ctx, opts, shell)
+		if err != nil {
+			return err
+		}
+		if !available {
+			c.Logger.Debug("Shell not available in container", "shell", shell, "container", opts.Container)
+			continue
+		}
+
+		opts.Command = []string{shell}
+		return c.Exec(ctx, opts)
+	}
+
+	return fmt.Errorf("no usable shell found in container %s (tried %s)", opts.Container, strings.Join(shells, ", "))
+}
+
+// probeShell checks whether a shell can be started in the container by running a no-op command
+// Probing first avoids confusing a missing shell with an interactive session that exited non-zero
+func (c *Client) probeShell(ctx context.Context, opts ExecOptions, shell string) (bool, error) {
+	err := c.Exec(ctx, ExecOptions{
+		Namespace: opts.Namespace,
+		Pod:       opts.Pod,
+		Container: opts.Container,
+		Command:   []string{shell, "-c", "exit 0"},
+		Stdout:    io.Discard,
+		Stderr:    io.Discard,
+	})
+	if err == nil {
+		return true, nil
+	}
+
+	// A non-zero exit (typically 126/127) or a runtime start failure means the shell is missing
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) && exitErr.Exited() {
+		return false, nil
+	}
+	msg := strings.ToLower(err.Error())
+	if strings.Contains(msg, "no such file or directory") ||
+		strings.Contains(msg, "executable file not found") {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("failed to start shell %s: %w", shell, err)
+}
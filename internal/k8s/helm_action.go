@@ -0,0 +1,578 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miles-w-3/lobot/internal/helmutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// helmResourcePolicyAnnotation set to "keep" on a chart's resource stops Helm deleting it
+	helmResourcePolicyAnnotation = "helm.sh/resource-policy"
+	// helmDeployedStatus is the status of the revision a release is running
+	helmDeployedStatus = "deployed"
+)
+
+// helmRolloutKinds are the kinds whose rollout is followed after a rollback applies them
+var helmRolloutKinds = map[string]bool{
+	"Deployment":  true,
+	"StatefulSet": true,
+	"DaemonSet":   true,
+}
+
+// HelmAction is a change made to a Helm release
+type HelmAction int
+
+const (
+	HelmActionRollback HelmAction = iota
+	HelmActionUninstall
+)
+
+// String returns a human-readable action
+func (a HelmAction) String() string {
+	if a == HelmActionUninstall {
+		return "Uninstall"
+	}
+	return "Rollback"
+}
+
+// HelmStepAction is what one step of a Helm action does
+type HelmStepAction int
+
+const (
+	HelmStepCreate HelmStepAction = iota
+	HelmStepUpdate
+	HelmStepUnchanged
+	HelmStepDelete
+	HelmStepKeep
+)
+
+// String returns a human-readable step action
+func (a HelmStepAction) String() string {
+	switch a {
+	case HelmStepCreate:
+		return "create"
+	case HelmStepUpdate:
+		return "update"
+	case HelmStepDelete:
+		return "delete"
+	case HelmStepKeep:
+		return "keep"
+	default:
+		return "unchanged"
+	}
+}
+
+// HelmStepState is how far one step of a Helm action has got
+type HelmStepState int
+
+const (
+	HelmStepPending HelmStepState = iota
+	HelmStepRunning
+	HelmStepWaiting
+	HelmStepDone
+	HelmStepSkipped
+	HelmStepFailed
+)
+
+// String returns a human-readable state
+func (s HelmStepState) String() string {
+	switch s {
+	case HelmStepRunning:
+		return "Running"
+	case HelmStepWaiting:
+		return "Waiting"
+	case HelmStepDone:
+		return "Done"
+	case HelmStepSkipped:
+		return "Skipped"
+	case HelmStepFailed:
+		return "Failed"
+	default:
+		return "Pending"
+	}
+}
+
+// HelmActionStep is one change a Helm action makes, to a resource of the release or to one of its release records
+type HelmActionStep struct {
+	Action    HelmStepAction
+	Kind      string
+	Namespace string
+	Name      string
+	State     HelmStepState
+	Message   string // Why the step was skipped, or how far what it waits for has got
+	Err       error
+
+	object   *unstructured.Unstructured // Resource to apply or delete, with its namespace set
+	resource *metav1.APIResource
+	record   int // Revision whose release record the step writes or deletes, or 0 for a resource
+}
+
+// Describe returns the step's kind, namespace and name for display
+func (s *HelmActionStep) Describe() string {
+	if s.Namespace != "" {
+		return fmt.Sprintf("%s %s/%s", s.Kind, s.Namespace, s.Name)
+	}
+	return fmt.Sprintf("%s %s", s.Kind, s.Name)
+}
+
+// IsRecord reports whether the step changes a release record rather than a resource of the release
+func (s *HelmActionStep) IsRecord() bool {
+	return s.record != 0
+}
+
+// HelmActionPlan is a previewed rollback or uninstall of a Helm release, broken into steps that are run in order
+type HelmActionPlan struct {
+	Action    HelmAction
+	Name      string
+	Namespace string
+	Storage   string
+	Current   *HelmReleaseRevision // Revision the release is at
+	Target    *HelmReleaseRevision // Revision rolled back to; nil when uninstalling
+	Revision  int                  // Revision a rollback records, after the latest one
+	Steps     []*HelmActionStep
+	// Diff shows how the live resources will change, resource by resource
+	Diff *HelmDiff
+	// SkippedHooks are the hooks Helm would run for the action, which lobot doesn't run
+	SkippedHooks []string
+}
+
+// Progress counts the steps that have finished, failed and are waiting for the cluster
+func (p *HelmActionPlan) Progress() (finished, failed, waiting int) {
+	for _, step := range p.Steps {
+		switch step.State {
+		case HelmStepDone, HelmStepSkipped:
+			finished++
+		case HelmStepFailed:
+			finished++
+			failed++
+		case HelmStepWaiting:
+			waiting++
+		}
+	}
+	return finished, failed, waiting
+}
+
+// failedResources counts the resource steps that failed
+func (p *HelmActionPlan) failedResources() int {
+	failed := 0
+	for _, step := range p.Steps {
+		if !step.IsRecord() && step.State == HelmStepFailed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// currentHelmRevision returns the revision a release is at: the deployed one, or the latest if none is deployed
+// Revisions are ordered newest first
+func currentHelmRevision(revisions []*HelmReleaseRevision) *HelmReleaseRevision {
+	for _, revision := range revisions {
+		if revision.Status == helmDeployedStatus {
+			return revision
+		}
+	}
+	return revisions[0]
+}
+
+// PlanHelmRollback previews rolling a release back to an earlier revision the way helm rollback does:
+// the target's resources are applied, resources only the current revision has are deleted, and a new revision
+// copying the target is recorded. Resources are compared with the live cluster and dry-run, so the preview
+// shows what will actually change. Revisions are ordered newest first
+func (c *Client) PlanHelmRollback(ctx context.Context, revisions []*HelmReleaseRevision, target *HelmReleaseRevision) (*HelmActionPlan, error) {
+	if err := checkHelmStorageWritable(target.Storage); err != nil {
+		return nil, err
+	}
+	current := currentHelmRevision(revisions)
+	if target.Revision == current.Revision && current.Status == helmDeployedStatus {
+		return nil, fmt.Errorf("revision %d is already deployed", target.Revision)
+	}
+
+	targetObjects, err := parseHelmManifest(target.Manifest)
+	if err != nil {
+		return nil, fmt.Errorf("revision %d: %w", target.Revision, err)
+	}
+	currentObjects, err := parseHelmManifest(current.Manifest)
+	if err != nil {
+		return nil, fmt.Errorf("revision %d: %w", current.Revision, err)
+	}
+
+	// Resources the target renders are applied; those only the current revision renders are deleted
+	targetKeys := make(map[string]bool, len(targetObjects))
+	for _, obj := range targetObjects {
+		targetKeys[helmObjectKey(obj)] = true
+	}
+	var removed []*unstructured.Unstructured
+	for _, obj := range currentObjects {
+		if !targetKeys[helmObjectKey(obj)] {
+			removed = append(removed, obj)
+		}
+	}
+
+	plan := &HelmActionPlan{
+		Action:    HelmActionRollback,
+		Name:      target.Name,
+		Namespace: target.Namespace,
+		Storage:   target.Storage,
+		Current:   current,
+		Target:    target,
+		Revision:  revisions[0].Revision + 1,
+		Diff:      &HelmDiff{From: "live", To: fmt.Sprintf("revision %d", target.Revision)},
+	}
+	plan.SkippedHooks = helmHooksFor(target, "pre-rollback", "post-rollback")
+	c.planHelmApplies(ctx, plan, targetObjects)
+	c.planHelmDeletes(ctx, plan, removed)
+
+	if current.Status == helmDeployedStatus {
+		plan.Steps = append(plan.Steps, plan.newRecordStep(HelmStepUpdate, current.Revision,
+			fmt.Sprintf("marks revision %d superseded", current.Revision)))
+	}
+	plan.Steps = append(plan.Steps, plan.newRecordStep(HelmStepCreate, plan.Revision,
+		fmt.Sprintf("records revision %d as a copy of revision %d", plan.Revision, target.Revision)))
+
+	sortHelmResourceDiffs(plan.Diff.Resources)
+	return plan, nil
+}
+
+// PlanHelmUninstall previews uninstalling a release the way helm uninstall does: the current revision's resources
+// are deleted, except those annotated to be kept, and then every release record. Revisions are ordered newest first
+func (c *Client) PlanHelmUninstall(ctx context.Context, revisions []*HelmReleaseRevision) (*HelmActionPlan, error) {
+	current := currentHelmRevision(revisions)
+	if err := checkHelmStorageWritable(current.Storage); err != nil {
+		return nil, err
+	}
+
+	objects, err := parseHelmManifest(current.Manifest)
+	if err != nil {
+		return nil, fmt.Errorf("revision %d: %w", current.Revision, err)
+	}
+
+	plan := &HelmActionPlan{
+		Action:    HelmActionUninstall,
+		Name:      current.Name,
+		Namespace: current.Namespace,
+		Storage:   current.Storage,
+		Current:   current,
+		Diff:      &HelmDiff{From: "live", To: "uninstalled"},
+	}
+	plan.SkippedHooks = helmHooksFor(current, "pre-delete", "post-delete")
+	c.planHelmDeletes(ctx, plan, objects)
+
+	for _, revision := range revisions {
+		plan.Steps = append(plan.Steps, plan.newRecordStep(HelmStepDelete, revision.Revision,
+			fmt.Sprintf("deletes the record of revision %d", revision.Revision)))
+	}
+
+	sortHelmResourceDiffs(plan.Diff.Resources)
+	return plan, nil
+}
+
+// planHelmApplies adds a step applying each object, in Helm's install order
+// Objects are compared with the live cluster to tell creates from updates, and dry-run to catch failures up front
+func (c *Client) planHelmApplies(ctx context.Context, plan *HelmActionPlan, objects []*unstructured.Unstructured) {
+	objects = append([]*unstructured.Unstructured(nil), objects...)
+	sort.SliceStable(objects, func(i, j int) bool {
+		return createRank(objects[i].GetKind()) < createRank(objects[j].GetKind())
+	})
+
+	for _, obj := range objects {
+		obj = plan.ownedObject(obj)
+		step, diff := newHelmActionStep(obj), newHelmResourceDiff(obj)
+		plan.Steps = append(plan.Steps, step)
+		plan.Diff.Resources = append(plan.Diff.Resources, diff)
+
+		apiResource, live, err := c.getHelmLiveObject(ctx, obj, plan.Namespace)
+		step.Namespace, diff.Namespace = obj.GetNamespace(), obj.GetNamespace()
+		step.object, step.resource = obj, apiResource
+		if err != nil {
+			step.State, step.Err, diff.Err = HelmStepFailed, err, err
+			continue
+		}
+
+		diff.New = helmManifestYAML(obj)
+		if live != nil {
+			diff.Old = helmLiveYAML(live, obj)
+		}
+		diff.Change = compareHelmManifests(diff.Old, diff.New)
+		switch diff.Change {
+		case HelmResourceAdded:
+			step.Action = HelmStepCreate
+		case HelmResourceUnchanged:
+			step.Action, step.State = HelmStepUnchanged, HelmStepSkipped
+			step.Message = "already matches " + plan.Diff.To
+			continue
+		default:
+			step.Action = HelmStepUpdate
+		}
+
+		if err := c.applyHelmObject(ctx, obj, apiResource, true); err != nil {
+			step.State, step.Err = HelmStepFailed, fmt.Errorf("dry run: %w", err)
+		}
+	}
+}
+
+// planHelmDeletes adds a step deleting each object that is still live, in the reverse of Helm's install order
+// Objects annotated with helm.sh/resource-policy: keep are kept, as Helm keeps them
+func (c *Client) planHelmDeletes(ctx context.Context, plan *HelmActionPlan, objects []*unstructured.Unstructured) {
+	objects = append([]*unstructured.Unstructured(nil), objects...)
+	sort.SliceStable(objects, func(i, j int) bool {
+		return createRank(objects[i].GetKind()) > createRank(objects[j].GetKind())
+	})
+
+	for _, obj := range objects {
+		obj = obj.DeepCopy()
+		step, diff := newHelmActionStep(obj), newHelmResourceDiff(obj)
+		step.Action = HelmStepDelete
+		plan.Steps = append(plan.Steps, step)
+
+		apiResource, live, err := c.getHelmLiveObject(ctx, obj, plan.Namespace)
+		step.Namespace, diff.Namespace = obj.GetNamespace(), obj.GetNamespace()
+		step.object, step.resource = obj, apiResource
+		switch {
+		case err != nil:
+			step.State, step.Err, diff.Err = HelmStepFailed, err, err
+		case live == nil:
+			step.State, step.Message = HelmStepSkipped, "already deleted"
+			continue
+		case obj.GetAnnotations()[helmResourcePolicyAnnotation] == "keep":
+			step.Action, step.State = HelmStepKeep, HelmStepSkipped
+			step.Message = "kept, as " + helmResourcePolicyAnnotation + " is keep"
+			diff.Old = helmLiveYAML(live, obj)
+			diff.New = diff.Old
+		default:
+			diff.Old = helmLiveYAML(live, obj)
+			diff.Change = HelmResourceRemoved
+		}
+		plan.Diff.Resources = append(plan.Diff.Resources, diff)
+	}
+}
+
+// newHelmActionStep starts a step changing an object
+func newHelmActionStep(obj *unstructured.Unstructured) *HelmActionStep {
+	return &HelmActionStep{Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName()}
+}
+
+// newRecordStep returns a step writing or deleting the release record of a revision
+func (p *HelmActionPlan) newRecordStep(action HelmStepAction, revision int, message string) *HelmActionStep {
+	return &HelmActionStep{
+		Action:    action,
+		Kind:      helmRecordKind(p.Storage),
+		Namespace: p.Namespace,
+		Name:      helmutil.ReleaseObjectName(p.Name, revision),
+		Message:   message,
+		record:    revision,
+	}
+}
+
+// ownedObject returns a copy of a manifest object with the metadata Helm adds to mark it as the release's
+func (p *HelmActionPlan) ownedObject(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	owned := obj.DeepCopy()
+	annotations := owned.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations["meta.helm.sh/release-name"] = p.Name
+	annotations["meta.helm.sh/release-namespace"] = p.Namespace
+	owned.SetAnnotations(annotations)
+
+	labels := owned.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels["app.kubernetes.io/managed-by"] = "Helm"
+	owned.SetLabels(labels)
+	return owned
+}
+
+// helmHooksFor lists the hooks of a revision that run on any of the given events, e.g. "Job migrate (pre-rollback)"
+func helmHooksFor(revision *HelmReleaseRevision, events ...string) []string {
+	var hooks []string
+	for _, hook := range revision.Hooks {
+		var matched []string
+		for _, event := range hook.Events {
+			for _, wanted := range events {
+				if event == wanted {
+					matched = append(matched, event)
+				}
+			}
+		}
+		if len(matched) > 0 {
+			hooks = append(hooks, fmt.Sprintf("%s %s (%s)", hook.Kind, hook.Name, strings.Join(matched, ", ")))
+		}
+	}
+	return hooks
+}
+
+// RunHelmStep makes the change of one step of a plan, returning the state it reached and a note on it
+// Steps are run one at a time in order, and the release records, written last once no resource is still waiting,
+// record how the resources went
+func (c *Client) RunHelmStep(ctx context.Context, plan *HelmActionPlan, step *HelmActionStep) (HelmStepState, string, error) {
+	if step.IsRecord() {
+		return c.runHelmRecordStep(ctx, plan, step)
+	}
+
+	switch step.Action {
+	case HelmStepCreate, HelmStepUpdate:
+		c.Logger.Info("Applying Helm release resource",
+			"release", plan.Name, "kind", step.Kind, "name", step.Name, "namespace", step.Namespace)
+		if err := c.applyHelmObject(ctx, step.object, step.resource, false); err != nil {
+			return HelmStepFailed, "", err
+		}
+		if helmRolloutKinds[step.Kind] && step.object.GroupVersionKind().Group == "apps" {
+			return HelmStepWaiting, "waiting for the rollout", nil
+		}
+		return HelmStepDone, "", nil
+
+	case HelmStepDelete:
+		c.Logger.Info("Deleting Helm release resource",
+			"release", plan.Name, "kind", step.Kind, "name", step.Name, "namespace", step.Namespace)
+		resourceInterface, err := c.dynamicResource(step.gvr(), step.Namespace)
+		if err != nil {
+			return HelmStepFailed, "", err
+		}
+		propagation := metav1.DeletePropagationBackground
+		err = resourceInterface.Delete(ctx, step.Name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+		if apierrors.IsNotFound(err) {
+			return HelmStepDone, "already deleted", nil
+		}
+		if err != nil {
+			return HelmStepFailed, "", describeAPIError("delete", err)
+		}
+		return HelmStepWaiting, "waiting for it to be deleted", nil
+	}
+	return step.State, step.Message, nil
+}
+
+// runHelmRecordStep writes or deletes a release record, as the action and the outcome of its resource steps call for
+func (c *Client) runHelmRecordStep(ctx context.Context, plan *HelmActionPlan, step *HelmActionStep) (HelmStepState, string, error) {
+	failed := plan.failedResources()
+
+	switch {
+	case plan.Action == HelmActionUninstall:
+		// Keeping the records lets the uninstall be retried with the manifest Helm knows
+		if failed > 0 {
+			return HelmStepSkipped, fmt.Sprintf("kept, as %d resource(s) failed to delete; uninstall again to retry", failed), nil
+		}
+		if err := c.deleteHelmRecord(ctx, plan.Storage, plan.Namespace, plan.Name, step.record); err != nil {
+			return HelmStepFailed, "", err
+		}
+		return HelmStepDone, fmt.Sprintf("deleted the record of revision %d", step.record), nil
+
+	case step.record == plan.Revision:
+		release, err := c.readHelmRecord(ctx, plan.Storage, plan.Namespace, plan.Name, plan.Target.Revision)
+		if err != nil {
+			return HelmStepFailed, "", err
+		}
+		status, description := helmDeployedStatus, fmt.Sprintf("Rollback to %d", plan.Target.Revision)
+		if failed > 0 {
+			status = "failed"
+			description = fmt.Sprintf("Rollback %q failed: %d resource(s) failed to apply or roll out", plan.Name, failed)
+		}
+		release["version"] = plan.Revision
+		setHelmRecordInfo(release, "status", status)
+		setHelmRecordInfo(release, "description", description)
+		setHelmRecordInfo(release, "last_deployed", time.Now().Format(time.RFC3339Nano))
+		setHelmRecordInfo(release, "deleted", "")
+		if err := c.writeHelmRecord(ctx, plan.Storage, plan.Namespace, plan.Name, plan.Revision, status, release); err != nil {
+			return HelmStepFailed, "", err
+		}
+		return HelmStepDone, fmt.Sprintf("recorded revision %d as %s", plan.Revision, status), nil
+
+	default:
+		release, err := c.readHelmRecord(ctx, plan.Storage, plan.Namespace, plan.Name, step.record)
+		if err != nil {
+			return HelmStepFailed, "", err
+		}
+		setHelmRecordInfo(release, "status", "superseded")
+		if err := c.writeHelmRecord(ctx, plan.Storage, plan.Namespace, plan.Name, step.record, "superseded", release); err != nil {
+			return HelmStepFailed, "", err
+		}
+		return HelmStepDone, fmt.Sprintf("marked revision %d superseded", step.record), nil
+	}
+}
+
+// setHelmRecordInfo sets a field of a release record's info
+func setHelmRecordInfo(release map[string]interface{}, field, value string) {
+	info, ok := release["info"].(map[string]interface{})
+	if !ok {
+		info = map[string]interface{}{}
+		release["info"] = info
+	}
+	info[field] = value
+}
+
+// CheckHelmStep checks on a step waiting for the cluster: a rollout to finish, or a deleted resource to be gone
+func (c *Client) CheckHelmStep(ctx context.Context, step *HelmActionStep) (HelmStepState, string, error) {
+	resourceInterface, err := c.dynamicResource(step.gvr(), step.Namespace)
+	if err != nil {
+		return HelmStepFailed, "", err
+	}
+	live, err := resourceInterface.Get(ctx, step.Name, metav1.GetOptions{})
+
+	if step.Action == HelmStepDelete {
+		switch {
+		case apierrors.IsNotFound(err):
+			return HelmStepDone, "deleted", nil
+		case err != nil:
+			return HelmStepWaiting, "couldn't check: " + err.Error(), nil
+		case len(live.GetFinalizers()) > 0:
+			return HelmStepWaiting, "terminating, waiting for finalizers: " + strings.Join(live.GetFinalizers(), ", "), nil
+		default:
+			return HelmStepWaiting, "terminating", nil
+		}
+	}
+
+	if err != nil {
+		return HelmStepWaiting, "couldn't check: " + err.Error(), nil
+	}
+	status := GetRolloutStatus(live)
+	switch {
+	case status.Failed:
+		return HelmStepFailed, "", errors.New(status.Message)
+	case status.Done:
+		return HelmStepDone, status.Message, nil
+	default:
+		return HelmStepWaiting, status.Message, nil
+	}
+}
+
+// gvr returns the group/version/resource of the step's resource
+func (s *HelmActionStep) gvr() schema.GroupVersionResource {
+	return s.object.GroupVersionKind().GroupVersion().WithResource(s.resource.Name)
+}
+
+// applyHelmObject server-side applies an object of a release
+// Conflicts are forced: fields Helm set are taken over, as helm rollback would overwrite them
+func (c *Client) applyHelmObject(ctx context.Context, obj *unstructured.Unstructured, resource *metav1.APIResource, dryRun bool) error {
+	gvr := obj.GroupVersionKind().GroupVersion().WithResource(resource.Name)
+	resourceInterface, err := c.dynamicResource(gvr, obj.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return fmt.Errorf("failed to encode resource: %w", err)
+	}
+
+	force := true
+	opts := metav1.PatchOptions{FieldManager: FieldManager, Force: &force}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	if _, err := resourceInterface.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, opts); err != nil {
+		return describeCreateError(err)
+	}
+	return nil
+}
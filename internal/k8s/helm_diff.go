@@ -0,0 +1,328 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// helmIgnoredAnnotations are annotations Helm and controllers add to released objects, which aren't part of the chart
+var helmIgnoredAnnotations = []string{
+	"meta.helm.sh/release-name",
+	"meta.helm.sh/release-namespace",
+	"deployment.kubernetes.io/revision",
+}
+
+// helmIgnoredLabels are labels Helm adds to released objects, which aren't part of the chart
+var helmIgnoredLabels = []string{
+	"app.kubernetes.io/managed-by",
+}
+
+// HelmResourceChange is how a resource of a Helm release differs between two manifests
+type HelmResourceChange int
+
+const (
+	HelmResourceUnchanged HelmResourceChange = iota
+	HelmResourceAdded
+	HelmResourceRemoved
+	HelmResourceChanged
+)
+
+// String returns a human-readable change
+func (c HelmResourceChange) String() string {
+	switch c {
+	case HelmResourceAdded:
+		return "added"
+	case HelmResourceRemoved:
+		return "removed"
+	case HelmResourceChanged:
+		return "changed"
+	default:
+		return "unchanged"
+	}
+}
+
+// HelmResourceDiff compares one resource of a Helm release between two manifests
+type HelmResourceDiff struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Change    HelmResourceChange
+	Old       string // Normalized YAML on the old side, "" if the resource isn't there
+	New       string // Normalized YAML on the new side, "" if the resource isn't there
+	Err       error  // Set if the resource couldn't be compared
+}
+
+// Describe returns the resource's kind, namespace and name for display
+func (d *HelmResourceDiff) Describe() string {
+	if d.Namespace != "" {
+		return fmt.Sprintf("%s %s/%s", d.Kind, d.Namespace, d.Name)
+	}
+	return fmt.Sprintf("%s %s", d.Kind, d.Name)
+}
+
+// HelmDiff compares the resources of a Helm release between two manifests, resource by resource
+type HelmDiff struct {
+	From      string // What the old side is, e.g. "revision 3"
+	To        string // What the new side is, e.g. "revision 4" or "live"
+	Resources []*HelmResourceDiff
+}
+
+// Counts returns how many resources were added, removed, changed and couldn't be compared
+func (d *HelmDiff) Counts() (added, removed, changed, failed int) {
+	for _, resource := range d.Resources {
+		switch {
+		case resource.Err != nil:
+			failed++
+		case resource.Change == HelmResourceAdded:
+			added++
+		case resource.Change == HelmResourceRemoved:
+			removed++
+		case resource.Change == HelmResourceChanged:
+			changed++
+		}
+	}
+	return added, removed, changed, failed
+}
+
+// DiffHelmRevisions compares the rendered manifests of two revisions of a release
+func DiffHelmRevisions(from, to *HelmReleaseRevision) (*HelmDiff, error) {
+	oldObjects, err := parseHelmManifest(from.Manifest)
+	if err != nil {
+		return nil, fmt.Errorf("revision %d: %w", from.Revision, err)
+	}
+	newObjects, err := parseHelmManifest(to.Manifest)
+	if err != nil {
+		return nil, fmt.Errorf("revision %d: %w", to.Revision, err)
+	}
+
+	oldByKey := make(map[string]*unstructured.Unstructured, len(oldObjects))
+	for _, obj := range oldObjects {
+		oldByKey[helmObjectKey(obj)] = obj
+	}
+
+	diff := &HelmDiff{From: fmt.Sprintf("revision %d", from.Revision), To: fmt.Sprintf("revision %d", to.Revision)}
+	for _, obj := range newObjects {
+		key := helmObjectKey(obj)
+		resource := newHelmResourceDiff(obj)
+		resource.New = helmManifestYAML(obj)
+		if old, ok := oldByKey[key]; ok {
+			resource.Old = helmManifestYAML(old)
+			delete(oldByKey, key)
+		}
+		resource.Change = compareHelmManifests(resource.Old, resource.New)
+		diff.Resources = append(diff.Resources, resource)
+	}
+	for _, obj := range oldObjects {
+		if _, ok := oldByKey[helmObjectKey(obj)]; !ok {
+			continue // Matched above
+		}
+		resource := newHelmResourceDiff(obj)
+		resource.Old = helmManifestYAML(obj)
+		resource.Change = HelmResourceRemoved
+		diff.Resources = append(diff.Resources, resource)
+	}
+
+	sortHelmResourceDiffs(diff.Resources)
+	return diff, nil
+}
+
+// DiffHelmRevisionLive compares a revision's rendered manifest with the objects live in the cluster, to show drift
+// Only the fields the revision sets are compared, so defaults the API server fills in don't show as drift;
+// labels and annotations are compared in full, since additions to them are usually manual.
+// Resources the revision renders but the cluster doesn't have are reported as removed
+func (c *Client) DiffHelmRevisionLive(ctx context.Context, revision *HelmReleaseRevision) (*HelmDiff, error) {
+	objects, err := parseHelmManifest(revision.Manifest)
+	if err != nil {
+		return nil, fmt.Errorf("revision %d: %w", revision.Revision, err)
+	}
+
+	diff := &HelmDiff{From: fmt.Sprintf("revision %d", revision.Revision), To: "live"}
+	for _, obj := range objects {
+		obj = obj.DeepCopy()
+		resource := newHelmResourceDiff(obj)
+		diff.Resources = append(diff.Resources, resource)
+
+		_, live, err := c.getHelmLiveObject(ctx, obj, revision.Namespace)
+		resource.Namespace = obj.GetNamespace()
+		if err != nil {
+			resource.Err = err
+			continue
+		}
+		resource.Old = helmManifestYAML(obj)
+		if live == nil {
+			resource.Change = HelmResourceRemoved
+			continue
+		}
+		resource.New = helmLiveYAML(live, obj)
+		resource.Change = compareHelmManifests(resource.Old, resource.New)
+	}
+
+	sortHelmResourceDiffs(diff.Resources)
+	return diff, nil
+}
+
+// getHelmLiveObject reads the live object for an object of a release's manifest, or returns nil if there isn't one
+// The object's namespace is set the way Helm sets it, so it must be a copy
+func (c *Client) getHelmLiveObject(ctx context.Context, obj *unstructured.Unstructured, namespace string) (*metav1.APIResource, *unstructured.Unstructured, error) {
+	apiResource, err := c.LookupKind(obj.GroupVersionKind())
+	if err != nil {
+		return nil, nil, err
+	}
+	if apiResource == nil {
+		return nil, nil, fmt.Errorf("the cluster doesn't serve kind %s in %s", obj.GetKind(), obj.GetAPIVersion())
+	}
+	setManifestNamespace(obj, apiResource.Namespaced, namespace)
+
+	gvr := obj.GroupVersionKind().GroupVersion().WithResource(apiResource.Name)
+	resourceInterface, err := c.dynamicResource(gvr, obj.GetNamespace())
+	if err != nil {
+		return apiResource, nil, err
+	}
+	live, err := resourceInterface.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return apiResource, nil, nil
+	}
+	if err != nil {
+		return apiResource, nil, describeAPIError("read", err)
+	}
+	return apiResource, live, nil
+}
+
+// helmLiveYAML renders a live object as YAML for comparison with the manifest object it was created from,
+// keeping only the fields the manifest sets
+func helmLiveYAML(live, manifest *unstructured.Unstructured) string {
+	pruned := pruneToManifest(normalizeHelmObject(live).Object, normalizeHelmObject(manifest).Object, nil)
+	return helmManifestYAML(&unstructured.Unstructured{Object: pruned.(map[string]interface{})})
+}
+
+// parseHelmManifest splits the rendered manifest of a revision into its objects
+func parseHelmManifest(manifest string) ([]*unstructured.Unstructured, error) {
+	if strings.TrimSpace(manifest) == "" {
+		return nil, nil
+	}
+	docs, err := ParseManifests([]byte(manifest))
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]*unstructured.Unstructured, 0, len(docs))
+	for _, doc := range docs {
+		if doc.State != ManifestInvalid {
+			objects = append(objects, doc.Object)
+		}
+	}
+	return objects, nil
+}
+
+// helmObjectKey identifies an object across revisions by its group, kind, namespace and name
+func helmObjectKey(obj *unstructured.Unstructured) string {
+	gvk := obj.GroupVersionKind()
+	return gvk.Group + "/" + gvk.Kind + "/" + obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// newHelmResourceDiff starts the comparison of an object
+func newHelmResourceDiff(obj *unstructured.Unstructured) *HelmResourceDiff {
+	return &HelmResourceDiff{Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName()}
+}
+
+// normalizeHelmObject returns a copy of an object without server-populated fields and Helm's bookkeeping metadata
+func normalizeHelmObject(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	clean := cleanForEdit(obj)
+	for _, annotation := range helmIgnoredAnnotations {
+		unstructured.RemoveNestedField(clean.Object, "metadata", "annotations", annotation)
+	}
+	for _, label := range helmIgnoredLabels {
+		unstructured.RemoveNestedField(clean.Object, "metadata", "labels", label)
+	}
+	if len(clean.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(clean.Object, "metadata", "annotations")
+	}
+	if len(clean.GetLabels()) == 0 {
+		unstructured.RemoveNestedField(clean.Object, "metadata", "labels")
+	}
+	return clean
+}
+
+// helmManifestYAML renders a normalized object as YAML for comparison
+func helmManifestYAML(obj *unstructured.Unstructured) string {
+	data, err := yaml.Marshal(normalizeHelmObject(obj).Object)
+	if err != nil {
+		return fmt.Sprintf("# failed to encode resource: %v\n", err)
+	}
+	return string(data)
+}
+
+// pruneToManifest drops the fields of a live value that the manifest doesn't set
+// Map keys missing from the manifest are dropped, except under metadata.labels and metadata.annotations;
+// list items are pruned against the manifest item at the same index, and extra live items are kept
+func pruneToManifest(live, manifest interface{}, path []string) interface{} {
+	switch manifestValue := manifest.(type) {
+	case map[string]interface{}:
+		liveMap, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		if len(path) == 2 && path[0] == "metadata" && (path[1] == "labels" || path[1] == "annotations") {
+			return liveMap
+		}
+		pruned := make(map[string]interface{}, len(manifestValue))
+		for key, value := range manifestValue {
+			if liveValue, ok := liveMap[key]; ok {
+				pruned[key] = pruneToManifest(liveValue, value, append(path, key))
+			}
+		}
+		return pruned
+
+	case []interface{}:
+		liveList, ok := live.([]interface{})
+		if !ok {
+			return live
+		}
+		pruned := make([]interface{}, len(liveList))
+		for i, item := range liveList {
+			if i < len(manifestValue) {
+				pruned[i] = pruneToManifest(item, manifestValue[i], append(path, "[]"))
+			} else {
+				pruned[i] = item
+			}
+		}
+		return pruned
+
+	default:
+		return live
+	}
+}
+
+// compareHelmManifests returns how a resource changed from its old to its new YAML
+func compareHelmManifests(oldYAML, newYAML string) HelmResourceChange {
+	switch {
+	case oldYAML == "":
+		return HelmResourceAdded
+	case newYAML == "":
+		return HelmResourceRemoved
+	case oldYAML != newYAML:
+		return HelmResourceChanged
+	default:
+		return HelmResourceUnchanged
+	}
+}
+
+// sortHelmResourceDiffs orders compared resources by kind, namespace and name
+func sortHelmResourceDiffs(resources []*HelmResourceDiff) {
+	sort.Slice(resources, func(i, j int) bool {
+		a, b := resources[i], resources[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
@@ -2,6 +2,7 @@ package k8s
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/miles-w-3/lobot/internal/helmutil"
@@ -26,16 +27,97 @@ func convertHelmReleaseToTrackedObject(rel *helmutil.HelmRelease) TrackedObject
 			Name:      rel.Name,
 			Namespace: rel.Namespace,
 			Status:    rel.Info.Status,
+			Severity:  SeverityForStatus(rel.Info.Status),
 			Age:       age,
 			Raw:       nil, // Helm releases don't have a k8s object
 		},
 		HelmChart:    chartName,
 		HelmRevision: rel.Version,
 		HelmManifest: rel.Manifest,
+		HelmStorage:  rel.Storage,
 		GVR:          HelmReleaseResource.GVR,
 	}
 }
 
+// helmReleaseKey identifies a release across its revisions; a release kept by two storage drivers is two releases
+func helmReleaseKey(storage, namespace, name string) string {
+	return storage + "/" + namespace + "/" + name
+}
+
+// HelmReleaseRevision is one revision of a Helm release, as recorded by its storage driver
+type HelmReleaseRevision struct {
+	Name         string
+	Namespace    string
+	Storage      string // Helm storage driver the revision was read from
+	Revision     int
+	Status       string
+	Chart        string
+	ChartVersion string
+	AppVersion   string
+	Deployed     time.Time
+	Description  string
+	Manifest     string
+
+	ChartDescription string
+	Dependencies     []helmutil.ChartDependency
+	Values           map[string]interface{} // Values supplied by the user
+	ChartValues      map[string]interface{} // Default values of the chart
+	Notes            string
+	Hooks            []helmutil.Hook
+}
+
+// convertHelmReleaseToRevision converts a decoded Helm release to a revision for the release history
+func convertHelmReleaseToRevision(rel *helmutil.HelmRelease) *HelmReleaseRevision {
+	return &HelmReleaseRevision{
+		Name:         rel.Name,
+		Namespace:    rel.Namespace,
+		Storage:      rel.Storage,
+		Revision:     rel.Version,
+		Status:       rel.Info.Status,
+		Chart:        rel.Chart.Metadata.Name,
+		ChartVersion: rel.Chart.Metadata.Version,
+		AppVersion:   rel.Chart.Metadata.AppVersion,
+		Deployed:     rel.Info.LastDeployed,
+		Description:  rel.Info.Description,
+		Manifest:     rel.Manifest,
+
+		ChartDescription: rel.Chart.Metadata.Description,
+		Dependencies:     rel.Chart.Metadata.Dependencies,
+		Values:           rel.Config,
+		ChartValues:      rel.Chart.Values,
+		Notes:            rel.Info.Notes,
+		Hooks:            rel.Hooks,
+	}
+}
+
+// sortHelmRevisions orders revisions newest first
+func sortHelmRevisions(revisions []*HelmReleaseRevision) {
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision > revisions[j].Revision
+	})
+}
+
+// helmHistoryChanged checks if any release gained, lost or changed the status of a revision
+func helmHistoryChanged(old, new map[string][]*HelmReleaseRevision) bool {
+	if len(old) != len(new) {
+		return true
+	}
+	for key, newRevisions := range new {
+		oldRevisions, exists := old[key]
+		if !exists || len(oldRevisions) != len(newRevisions) {
+			return true
+		}
+		// Both are sorted newest first
+		for i := range newRevisions {
+			if oldRevisions[i].Revision != newRevisions[i].Revision ||
+				oldRevisions[i].Status != newRevisions[i].Status {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // helmReleasesChanged checks if the Helm releases have actually changed
 func helmReleasesChanged(old, new []TrackedObject) bool {
 	if len(old) != len(new) {
@@ -46,7 +128,7 @@ func helmReleasesChanged(old, new []TrackedObject) bool {
 	oldMap := make(map[string]*HelmRelease)
 	for _, res := range old {
 		if helmRes, ok := res.(*HelmRelease); ok {
-			key := helmRes.GetNamespace() + "/" + helmRes.GetName()
+			key := helmReleaseKey(helmRes.HelmStorage, helmRes.GetNamespace(), helmRes.GetName())
 			oldMap[key] = helmRes
 		}
 	}
@@ -54,7 +136,7 @@ func helmReleasesChanged(old, new []TrackedObject) bool {
 	newMap := make(map[string]*HelmRelease)
 	for _, res := range new {
 		if helmRes, ok := res.(*HelmRelease); ok {
-			key := helmRes.GetNamespace() + "/" + helmRes.GetName()
+			key := helmReleaseKey(helmRes.HelmStorage, helmRes.GetNamespace(), helmRes.GetName())
 			newMap[key] = helmRes
 		}
 	}
@@ -0,0 +1,187 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/miles-w-3/lobot/internal/helmutil"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// helmSecretType is the type of the Secrets Helm's Secret driver stores releases in
+const helmSecretType = "helm.sh/release.v1"
+
+// checkHelmStorageWritable returns an error if lobot can't change releases kept by a storage driver
+// Releases in SQL storage are only read, as lobot doesn't hold write access to the database
+func checkHelmStorageWritable(storage string) error {
+	switch storage {
+	case helmutil.StorageSecret, helmutil.StorageConfigMap:
+		return nil
+	default:
+		return fmt.Errorf("releases stored with the %s driver can't be changed from lobot; use the helm CLI", storage)
+	}
+}
+
+// helmRecordKind returns the kind of object a storage driver keeps each revision of a release in
+func helmRecordKind(storage string) string {
+	if storage == helmutil.StorageConfigMap {
+		return "ConfigMap"
+	}
+	return "Secret"
+}
+
+// readHelmRecord reads a revision of a release as Helm stored it
+// The release is kept as generic JSON fields so that writing it back doesn't drop fields lobot doesn't decode
+func (c *Client) readHelmRecord(ctx context.Context, storage, namespace, name string, revision int) (map[string]interface{}, error) {
+	objectName := helmutil.ReleaseObjectName(name, revision)
+
+	var data string
+	switch storage {
+	case helmutil.StorageSecret:
+		secret, err := c.Clientset.CoreV1().Secrets(namespace).Get(ctx, objectName, metav1.GetOptions{})
+		if err != nil {
+			return nil, describeAPIError("read", err)
+		}
+		data = string(secret.Data["release"])
+	case helmutil.StorageConfigMap:
+		configMap, err := c.Clientset.CoreV1().ConfigMaps(namespace).Get(ctx, objectName, metav1.GetOptions{})
+		if err != nil {
+			return nil, describeAPIError("read", err)
+		}
+		data = configMap.Data["release"]
+	default:
+		return nil, checkHelmStorageWritable(storage)
+	}
+
+	decoded, err := helmutil.DecodeReleaseJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", objectName, err)
+	}
+	var release map[string]interface{}
+	if err := json.Unmarshal(decoded, &release); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", objectName, err)
+	}
+	return release, nil
+}
+
+// writeHelmRecord stores a revision of a release, creating the object that holds it or updating the existing one
+// The labels Helm queries releases by are set from the revision and its status
+func (c *Client) writeHelmRecord(ctx context.Context, storage, namespace, name string, revision int, status string, release map[string]interface{}) error {
+	objectName := helmutil.ReleaseObjectName(name, revision)
+
+	decoded, err := json.Marshal(release)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", objectName, err)
+	}
+	data, err := helmutil.EncodeReleaseJSON(decoded)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", objectName, err)
+	}
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	labels := map[string]string{
+		"name":       name,
+		"owner":      "helm",
+		"status":     status,
+		"version":    strconv.Itoa(revision),
+		"modifiedAt": now,
+	}
+
+	c.Logger.Info("Writing Helm release record",
+		"storage", storage,
+		"namespace", namespace,
+		"name", objectName,
+		"status", status)
+
+	switch storage {
+	case helmutil.StorageSecret:
+		secrets := c.Clientset.CoreV1().Secrets(namespace)
+		secret, err := secrets.Get(ctx, objectName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			labels["createdAt"] = now
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: objectName, Namespace: namespace, Labels: labels},
+				Type:       helmSecretType,
+				Data:       map[string][]byte{"release": []byte(data)},
+			}
+			_, err = secrets.Create(ctx, secret, metav1.CreateOptions{FieldManager: FieldManager})
+			return describeHelmRecordError("create", err)
+		}
+		if err != nil {
+			return describeAPIError("read", err)
+		}
+		secret.Labels = mergeLabels(secret.Labels, labels)
+		secret.Data = map[string][]byte{"release": []byte(data)}
+		_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{FieldManager: FieldManager})
+		return describeHelmRecordError("update", err)
+
+	case helmutil.StorageConfigMap:
+		configMaps := c.Clientset.CoreV1().ConfigMaps(namespace)
+		configMap, err := configMaps.Get(ctx, objectName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			labels["createdAt"] = now
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: objectName, Namespace: namespace, Labels: labels},
+				Data:       map[string]string{"release": data},
+			}
+			_, err = configMaps.Create(ctx, configMap, metav1.CreateOptions{FieldManager: FieldManager})
+			return describeHelmRecordError("create", err)
+		}
+		if err != nil {
+			return describeAPIError("read", err)
+		}
+		configMap.Labels = mergeLabels(configMap.Labels, labels)
+		configMap.Data = map[string]string{"release": data}
+		_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{FieldManager: FieldManager})
+		return describeHelmRecordError("update", err)
+
+	default:
+		return checkHelmStorageWritable(storage)
+	}
+}
+
+// deleteHelmRecord deletes the stored revision of a release; a revision that is already gone isn't an error
+func (c *Client) deleteHelmRecord(ctx context.Context, storage, namespace, name string, revision int) error {
+	objectName := helmutil.ReleaseObjectName(name, revision)
+
+	c.Logger.Info("Deleting Helm release record", "storage", storage, "namespace", namespace, "name", objectName)
+
+	var err error
+	switch storage {
+	case helmutil.StorageSecret:
+		err = c.Clientset.CoreV1().Secrets(namespace).Delete(ctx, objectName, metav1.DeleteOptions{})
+	case helmutil.StorageConfigMap:
+		err = c.Clientset.CoreV1().ConfigMaps(namespace).Delete(ctx, objectName, metav1.DeleteOptions{})
+	default:
+		return checkHelmStorageWritable(storage)
+	}
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return describeHelmRecordError("delete", err)
+}
+
+// describeHelmRecordError maps an error writing a release record to a friendlier message, passing nil through
+func describeHelmRecordError(action string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return describeAPIError(action, err)
+}
+
+// mergeLabels returns existing labels with changes applied over them
+func mergeLabels(existing, changes map[string]string) map[string]string {
+	merged := make(map[string]string, len(existing)+len(changes))
+	for key, value := range existing {
+		merged[key] = value
+	}
+	for key, value := range changes {
+		merged[key] = value
+	}
+	return merged
+}
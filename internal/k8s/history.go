@@ -0,0 +1,244 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// historyPerObject is how many backups are kept for each object; older ones are pruned as new ones are recorded
+	historyPerObject = 20
+	// historyTimeFormat prefixes backup file names, so that sorting names sorts backups by time
+	historyTimeFormat = "20060102-150405.000000000"
+	// clusterScopedDir stands in for the namespace of cluster-scoped objects in the history directory
+	clusterScopedDir = "_cluster"
+)
+
+// History stores objects as they were before lobot changed them, so that a change can be reverted
+// Backups are kept as YAML files under dir/<context>/<resource.version.group>/<namespace>/<name>/
+type History struct {
+	dir string
+}
+
+// HistoryEntry is one recorded change
+type HistoryEntry struct {
+	Path     string                      `json:"-"`
+	Context  string                      `json:"context"`
+	Action   string                      `json:"action"`
+	Recorded time.Time                   `json:"recorded"`
+	GVR      schema.GroupVersionResource `json:"gvr"`
+	Object   *unstructured.Unstructured  `json:"object"`
+}
+
+// Describe names the entry's object, e.g. "Deployment default/web"
+func (e *HistoryEntry) Describe() string {
+	if namespace := e.Object.GetNamespace(); namespace != "" {
+		return fmt.Sprintf("%s %s/%s", e.Object.GetKind(), namespace, e.Object.GetName())
+	}
+	return fmt.Sprintf("%s %s", e.Object.GetKind(), e.Object.GetName())
+}
+
+// NewHistory creates a history stored in dir, which is created on the first backup
+func NewHistory(dir string) *History {
+	return &History{dir: dir}
+}
+
+// Dir returns where the history is stored
+func (h *History) Dir() string {
+	return h.dir
+}
+
+// Snapshot fetches a resource as it is before a change, to be recorded once the change succeeds
+func (c *Client) Snapshot(ctx context.Context, resource TrackedObject) (*HistoryEntry, error) {
+	gvr, err := gvrFor(resource)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := c.GetObject(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+	return &HistoryEntry{Context: c.Context, GVR: gvr, Object: obj}, nil
+}
+
+// Record stores a snapshot as the state before an action changed it, returning the backup's path
+func (h *History) Record(snapshot *HistoryEntry, action string) (string, error) {
+	backup := snapshot.Object.DeepCopy()
+	backup.SetManagedFields(nil)
+
+	now := time.Now()
+	entry := HistoryEntry{Context: snapshot.Context, Action: action, Recorded: now, GVR: snapshot.GVR, Object: backup}
+	data, err := yaml.Marshal(entry)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode backup: %w", err)
+	}
+
+	dir := h.objectDir(entry.Context, entry.GVR, backup.GetNamespace(), backup.GetName())
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.yaml", now.UTC().Format(historyTimeFormat), pathSegment(strings.ToLower(action)))
+	path := filepath.Join(dir, name)
+	// Backups can hold Secrets, so they are only readable by the user
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	h.prune(dir)
+	return path, nil
+}
+
+// List returns the most recent entries across all contexts, newest first
+// Entries that can't be read are skipped
+func (h *History) List(limit int) ([]*HistoryEntry, error) {
+	var paths []string
+	err := filepath.WalkDir(h.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".yaml") {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	sort.Slice(paths, func(i, j int) bool {
+		return filepath.Base(paths[i]) > filepath.Base(paths[j])
+	})
+
+	var entries []*HistoryEntry
+	for _, path := range paths {
+		if limit > 0 && len(entries) >= limit {
+			break
+		}
+		entry, err := readHistoryEntry(path)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// readHistoryEntry reads one backup file
+func readHistoryEntry(path string) (*HistoryEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	entry := &HistoryEntry{}
+	if err := yaml.Unmarshal(data, entry); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if entry.Object == nil {
+		return nil, fmt.Errorf("%s holds no object", path)
+	}
+	entry.Path = path
+	return entry, nil
+}
+
+// objectDir returns the directory holding an object's backups
+func (h *History) objectDir(context string, gvr schema.GroupVersionResource, namespace, name string) string {
+	resource := gvr.Resource + "." + gvr.Version
+	if gvr.Group != "" {
+		resource += "." + gvr.Group
+	}
+	if namespace == "" {
+		namespace = clusterScopedDir
+	}
+	return filepath.Join(h.dir, pathSegment(context), pathSegment(resource), pathSegment(namespace), pathSegment(name))
+}
+
+// prune removes all but the newest historyPerObject backups in an object's directory
+func (h *History) prune(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) <= historyPerObject {
+		return
+	}
+	// ReadDir sorts by name, so the oldest come first
+	for _, entry := range entries[:len(entries)-historyPerObject] {
+		os.Remove(filepath.Join(dir, entry.Name()))
+	}
+}
+
+// pathSegment makes a name safe to use as a single path element
+// Kube context names in particular often hold slashes and colons, e.g. EKS cluster ARNs
+func pathSegment(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// RevertToBackup restores an object to the state recorded in a history entry
+// An object that still exists is replaced with the backup; a deleted one is created again from it
+// A snapshot of the object being replaced is returned so the revert itself can be recorded; it is nil when the object was recreated
+func (c *Client) RevertToBackup(ctx context.Context, entry *HistoryEntry) (*HistoryEntry, error) {
+	if entry.Context != c.Context {
+		return nil, fmt.Errorf("this change was made in context %q; switch to it to revert", entry.Context)
+	}
+
+	resourceInterface, err := c.dynamicResource(entry.GVR, entry.Object.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	obj := entry.Object.DeepCopy()
+	// The backup's resourceVersion is stale, and the rest of these are assigned by the server
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+	unstructured.RemoveNestedField(obj.Object, "status")
+
+	c.Logger.Info("Reverting resource to backup",
+		"kind", obj.GetKind(),
+		"name", obj.GetName(),
+		"namespace", obj.GetNamespace(),
+		"backup", entry.Path)
+
+	live, err := resourceInterface.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		obj.SetUID("")
+		obj.SetCreationTimestamp(metav1.Time{})
+		obj.SetDeletionTimestamp(nil)
+		obj.SetDeletionGracePeriodSeconds(nil)
+		if _, err := resourceInterface.Create(ctx, obj, metav1.CreateOptions{FieldManager: FieldManager}); err != nil {
+			return nil, describeAPIError("create", err)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, describeAPIError("read", err)
+	}
+
+	// The backup replaces whatever is on the cluster now, like an update that ignores concurrent changes
+	obj.SetResourceVersion(live.GetResourceVersion())
+	obj.SetUID(live.GetUID())
+	if _, err := resourceInterface.Update(ctx, obj, metav1.UpdateOptions{FieldManager: FieldManager}); err != nil {
+		return nil, describeAPIError("update", err)
+	}
+	return &HistoryEntry{Context: c.Context, GVR: entry.GVR, Object: live}, nil
+}
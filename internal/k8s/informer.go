@@ -2,6 +2,7 @@ package k8s
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log/slog"
 	"sort"
@@ -22,15 +23,17 @@ import (
 // Common resource types
 var (
 	// Core resources
-	PodResource = NewTrackedType(
+	PodResource = NewCustomTrackedType(
 		schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"},
 		"Pods",
 		true,
+		TableParams{columnOverride: podColumns, rowBinder: podRowBinder},
 	)
-	ServiceResource = NewTrackedType(
+	ServiceResource = NewCustomTrackedType(
 		schema.GroupVersionResource{Group: "", Version: "v1", Resource: "services"},
 		"Services",
 		true,
+		TableParams{columnOverride: serviceColumns, rowBinder: serviceRowBinder},
 	)
 	ConfigMapResource = NewTrackedType(
 		schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"},
@@ -42,10 +45,11 @@ var (
 		"Secrets",
 		true,
 	)
-	PersistentVolumeClaimResource = NewTrackedType(
+	PersistentVolumeClaimResource = NewCustomTrackedType(
 		schema.GroupVersionResource{Group: "", Version: "v1", Resource: "persistentvolumeclaims"},
 		"PersistentVolumeClaims",
 		true,
+		TableParams{columnOverride: persistentVolumeClaimColumns, rowBinder: persistentVolumeClaimRowBinder},
 	)
 	ServiceAccountResource = NewTrackedType(
 		schema.GroupVersionResource{Group: "", Version: "v1", Resource: "serviceaccounts"},
@@ -54,51 +58,59 @@ var (
 	)
 
 	// Apps resources
-	DeploymentResource = NewTrackedType(
+	DeploymentResource = NewCustomTrackedType(
 		schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
 		"Deployments",
 		true,
+		TableParams{columnOverride: deploymentColumns, rowBinder: deploymentRowBinder},
 	)
-	ReplicaSetResource = NewTrackedType(
+	ReplicaSetResource = NewCustomTrackedType(
 		schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"},
 		"ReplicaSets",
 		true,
+		TableParams{columnOverride: replicaSetColumns, rowBinder: replicaSetRowBinder},
 	)
-	StatefulSetResource = NewTrackedType(
+	StatefulSetResource = NewCustomTrackedType(
 		schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"},
 		"StatefulSets",
 		true,
+		TableParams{columnOverride: statefulSetColumns, rowBinder: statefulSetRowBinder},
 	)
-	DaemonSetResource = NewTrackedType(
+	DaemonSetResource = NewCustomTrackedType(
 		schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"},
 		"DaemonSets",
 		true,
+		TableParams{columnOverride: daemonSetColumns, rowBinder: daemonSetRowBinder},
 	)
 
 	// Batch resources
-	JobResource = NewTrackedType(
+	JobResource = NewCustomTrackedType(
 		schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"},
 		"Jobs",
 		true,
+		TableParams{columnOverride: jobColumns, rowBinder: jobRowBinder},
 	)
-	CronJobResource = NewTrackedType(
+	CronJobResource = NewCustomTrackedType(
 		schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"},
 		"CronJobs",
 		true,
+		TableParams{columnOverride: cronJobColumns, rowBinder: cronJobRowBinder},
 	)
 
 	// Networking resources
-	IngressResource = NewTrackedType(
+	IngressResource = NewCustomTrackedType(
 		schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"},
 		"Ingresses",
 		true,
+		TableParams{columnOverride: ingressColumns, rowBinder: ingressRowBinder},
 	)
 
 	// Autoscaling resources
-	HorizontalPodAutoscalerResource = NewTrackedType(
+	HorizontalPodAutoscalerResource = NewCustomTrackedType(
 		schema.GroupVersionResource{Group: "autoscaling", Version: "v2", Resource: "horizontalpodautoscalers"},
 		"HorizontalPodAutoscalers",
 		true,
+		TableParams{columnOverride: horizontalPodAutoscalerColumns, rowBinder: horizontalPodAutoscalerRowBinder},
 	)
 
 	// Cluster-scoped resources
@@ -107,15 +119,36 @@ var (
 		"Namespaces",
 		false,
 	)
-	NodeResource = NewTrackedType(
+	NodeResource = NewCustomTrackedType(
 		schema.GroupVersionResource{Group: "", Version: "v1", Resource: "nodes"},
 		"Nodes",
 		false,
+		TableParams{columnOverride: nodeColumns, rowBinder: nodeRowBinder},
 	)
-	PersistentVolumeResource = NewTrackedType(
+	PersistentVolumeResource = NewCustomTrackedType(
 		schema.GroupVersionResource{Group: "", Version: "v1", Resource: "persistentvolumes"},
 		"PersistentVolumes",
 		false,
+		TableParams{columnOverride: persistentVolumeColumns, rowBinder: persistentVolumeRowBinder},
+	)
+
+	// Events
+	EventResource = NewCustomTrackedType(
+		schema.GroupVersionResource{Group: "", Version: "v1", Resource: "events"},
+		"Events",
+		true,
+		TableParams{
+			columnOverride: []table.Column{
+				{Title: "NAMESPACE", Width: 15},
+				{Title: "TYPE", Width: 8},
+				{Title: "REASON", Width: 20},
+				{Title: "OBJECT", Width: 35},
+				{Title: "COUNT", Width: 6},
+				{Title: "LAST SEEN", Width: 10},
+				{Title: "MESSAGE", Width: 60},
+			},
+			rowBinder: eventRowBinder,
+		},
 	)
 
 	// Special resource types
@@ -131,6 +164,7 @@ var (
 				{Title: "STATUS", Width: 12},
 				{Title: "CHART", Width: 25},
 				{Title: "REV", Width: 5},
+				{Title: "STORAGE", Width: 10},
 			},
 			rowBinder: nil, // Use default (DefaultRowBinding on HelmRelease)
 		},
@@ -152,6 +186,9 @@ var (
 	)
 )
 
+// helmSQLQueryTimeout bounds reading the releases in Helm's SQL storage
+const helmSQLQueryTimeout = 10 * time.Second
+
 // DefaultResourceTypes returns a list of commonly used resource types
 func DefaultResourceTypes() []*TrackedType {
 	return []*TrackedType{
@@ -187,6 +224,9 @@ func DefaultResourceTypes() []*TrackedType {
 		// Autoscaling resources
 		HorizontalPodAutoscalerResource,
 
+		// Events (explain why resources are pending or failing)
+		EventResource,
+
 		// Cluster-scoped resources
 		NamespaceResource,
 		NodeResource,
@@ -205,9 +245,14 @@ type InformerManager struct {
 	resources          map[schema.GroupVersionResource][]TrackedObject
 	activeInformers    map[schema.GroupVersionResource]cache.SharedIndexInformer
 	updateCallback     UpdateCallback
-	ownerIndex         map[string][]TrackedObject // Maps owner UID to owned resources
-	helmResources      []TrackedObject            // Cached Helm releases (decoded from secrets)
-	helmPollingStarted bool                       // Tracks if Helm polling goroutine has been started
+	ownerIndex         map[string][]TrackedObject        // Maps owner UID to owned resources
+	eventIndex         map[string][]TrackedObject        // Maps involved object UID to its events
+	helmResources      []TrackedObject                   // Cached Helm releases (decoded from secrets)
+	helmHistory        map[string][]*HelmReleaseRevision // Every decoded revision of each release, newest first, keyed by helmReleaseKey
+	helmSQLConnection  string                            // Connection string of the Helm SQL storage of the cluster, if it has one
+	helmSQLDB          *sql.DB
+	helmSQLReleases    []*helmutil.HelmRelease // Releases last read from the Helm SQL storage
+	helmPollingStarted bool                    // Tracks if Helm polling goroutine has been started
 	isInitialized      bool
 	lastUpdateTime     map[schema.GroupVersionResource]time.Time // Tracks when each resource type was last updated
 }
@@ -234,6 +279,7 @@ func NewInformerManager(client *Client, logger *slog.Logger, updateCallback Upda
 		resources:       make(map[schema.GroupVersionResource][]TrackedObject),
 		activeInformers: make(map[schema.GroupVersionResource]cache.SharedIndexInformer),
 		ownerIndex:      make(map[string][]TrackedObject),
+		eventIndex:      make(map[string][]TrackedObject),
 		helmResources:   []TrackedObject{},
 		updateCallback:  updateCallback,
 		isInitialized:   false,
@@ -281,17 +327,17 @@ func (im *InformerManager) StartInformer(ctx context.Context, resourceType *Trac
 	informer := im.factory.ForResource(resourceType.GVR).Informer()
 
 	// Add event handlers
-	// Special case: For Secrets, also watch for Helm release secrets and trigger refresh
-	if resourceType.GVR == SecretResource.GVR {
+	// Special case: For Secrets and ConfigMaps, also watch for Helm releases and trigger refresh
+	if resourceType.GVR == SecretResource.GVR || resourceType.GVR == ConfigMapResource.GVR {
 		_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
-				im.handleSecretUpdate(obj, resourceType.GVR)
+				im.handleHelmStorageUpdate(obj, resourceType.GVR)
 			},
 			UpdateFunc: func(oldObj, newObj interface{}) {
-				im.handleSecretUpdate(newObj, resourceType.GVR)
+				im.handleHelmStorageUpdate(newObj, resourceType.GVR)
 			},
 			DeleteFunc: func(obj interface{}) {
-				im.handleSecretUpdate(obj, resourceType.GVR)
+				im.handleHelmStorageUpdate(obj, resourceType.GVR)
 			},
 		})
 		if err != nil {
@@ -359,24 +405,28 @@ func (im *InformerManager) checkResourceExists(gvr schema.GroupVersionResource)
 	return true, nil
 }
 
-// handleSecretUpdate handles Secret updates and checks for Helm release secrets
-func (im *InformerManager) handleSecretUpdate(obj interface{}, gvr schema.GroupVersionResource) {
-	// First, handle the secret update normally
+// handleHelmStorageUpdate handles Secret and ConfigMap updates and checks for Helm releases stored in them
+func (im *InformerManager) handleHelmStorageUpdate(obj interface{}, gvr schema.GroupVersionResource) {
+	// First, handle the update normally
 	im.handleResourceUpdate(gvr)
 
-	// Check if this is a Helm release secret
+	// Check if this is a Helm release secret, or a configmap of Helm's ConfigMap driver
 	if unstructuredObj, ok := obj.(*unstructured.Unstructured); ok {
-		secretType, found, _ := unstructured.NestedString(unstructuredObj.Object, "type")
-		if found && secretType == "helm.sh/release.v1" {
-			// This is a Helm release secret - trigger Helm refresh
-			im.logger.Debug("Helm release secret detected, triggering refresh",
+		isRelease := helmutil.IsHelmReleaseSecret(unstructuredObj)
+		if gvr == ConfigMapResource.GVR {
+			isRelease = helmutil.IsHelmReleaseConfigMap(unstructuredObj)
+		}
+		if isRelease {
+			// This holds a Helm release - trigger Helm refresh
+			im.logger.Debug("Helm release storage change detected, triggering refresh",
+				"kind", unstructuredObj.GetKind(),
 				"name", unstructuredObj.GetName(),
 				"namespace", unstructuredObj.GetNamespace())
 
 			// Refresh Helm releases asynchronously to avoid blocking the informer
 			go func() {
 				if err := im.refreshHelmReleases(); err != nil {
-					im.logger.Error("Failed to refresh Helm releases after secret change", "error", err)
+					im.logger.Error("Failed to refresh Helm releases after storage change", "error", err)
 				}
 			}()
 		}
@@ -423,6 +473,7 @@ func (im *InformerManager) handleResourceUpdate(gvr schema.GroupVersionResource)
 
 	// Incrementally update owner index instead of full rebuild
 	im.updateOwnerIndexForGVR(gvr, oldResources, resources)
+	im.updateEventIndex(gvr, resources)
 	im.mu.Unlock()
 
 	im.sendCallback(ServiceUpdate{Type: ServiceUpdateResources})
@@ -460,6 +511,7 @@ func (im *InformerManager) forceRefreshFromAPI(ctx context.Context, gvr schema.G
 	im.resources[gvr] = resources
 	im.lastUpdateTime[gvr] = time.Now()
 	im.updateOwnerIndexForGVR(gvr, oldResources, resources)
+	im.updateEventIndex(gvr, resources)
 	im.mu.Unlock()
 
 	// Notify UI of the update
@@ -490,6 +542,13 @@ func (im *InformerManager) GetResources(gvr schema.GroupVersionResource) []Track
 // Stop stops all informers
 func (im *InformerManager) Stop() {
 	close(im.stopCh)
+
+	im.mu.Lock()
+	defer im.mu.Unlock()
+	if im.helmSQLDB != nil {
+		im.helmSQLDB.Close()
+		im.helmSQLDB = nil
+	}
 }
 
 // ConvertUnstructuredToTrackedObject converts an unstructured object to a TrackedObject
@@ -499,27 +558,14 @@ func ConvertUnstructuredToTrackedObject(obj *unstructured.Unstructured, gvr sche
 		return convertArgoApplicationToTrackedObject(obj, gvr)
 	}
 
-	// Extract status if available
-	status := "Unknown"
-	if statusMap, found, _ := unstructured.NestedMap(obj.Object, "status"); found {
-		// Try common status fields
-		if phase, found, _ := unstructured.NestedString(statusMap, "phase"); found {
-			status = phase
-		} else if conditions, found, _ := unstructured.NestedSlice(statusMap, "conditions"); found && len(conditions) > 0 {
-			// Get the last condition status
-			if lastCond, ok := conditions[len(conditions)-1].(map[string]interface{}); ok {
-				if condStatus, found := lastCond["status"]; found {
-					status = fmt.Sprintf("%v", condStatus)
-				}
-			}
-		}
-	}
+	status := EvaluateStatus(obj)
 
 	return &K8sResource{
 		CoreFields: CoreFields{
 			Name:      obj.GetName(),
 			Namespace: obj.GetNamespace(),
-			Status:    status,
+			Status:    status.Text,
+			Severity:  status.Severity,
 			Age:       time.Since(obj.GetCreationTimestamp().Time),
 			Raw:       obj,
 		},
@@ -700,6 +746,37 @@ func (im *InformerManager) GetResourcesByOwnerUID(ownerUID string) []TrackedObje
 	return result
 }
 
+// updateEventIndex rebuilds the involved object index when the cached events change
+// Must be called with im.mu locked
+func (im *InformerManager) updateEventIndex(gvr schema.GroupVersionResource, resources []TrackedObject) {
+	if gvr != EventResource.GVR {
+		return
+	}
+
+	im.eventIndex = make(map[string][]TrackedObject)
+	for _, event := range resources {
+		raw := event.GetRaw()
+		if raw == nil {
+			continue
+		}
+		uid, _, _ := unstructured.NestedString(raw.Object, "involvedObject", "uid")
+		if uid != "" {
+			im.eventIndex[uid] = append(im.eventIndex[uid], event)
+		}
+	}
+}
+
+// GetEventsByInvolvedUID returns the cached events about the object with the given UID
+func (im *InformerManager) GetEventsByInvolvedUID(uid string) []TrackedObject {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+
+	events := im.eventIndex[uid]
+	result := make([]TrackedObject, len(events))
+	copy(result, events)
+	return result
+}
+
 // GetLastUpdateTime returns the last time a resource type was updated
 func (im *InformerManager) GetLastUpdateTime(gvr schema.GroupVersionResource) time.Time {
 	im.mu.RLock()
@@ -755,6 +832,7 @@ func (im *InformerManager) startHelmReleasePolling(ctx context.Context) error {
 		im.mu.Unlock()
 		// Still refresh to update the data when user presses refresh
 		// Force timestamp update so user sees the refresh happened
+		im.refreshHelmSQLReleases(ctx)
 		return im.refreshHelmReleasesWithTimestamp(true)
 	}
 	im.helmPollingStarted = true
@@ -762,6 +840,7 @@ func (im *InformerManager) startHelmReleasePolling(ctx context.Context) error {
 
 	// Import helm package here to avoid circular dependency
 	// We'll do the initial load synchronously
+	im.refreshHelmSQLReleases(ctx)
 	if err := im.refreshHelmReleases(); err != nil {
 		return fmt.Errorf("failed initial Helm release fetch: %w", err)
 	}
@@ -779,6 +858,7 @@ func (im *InformerManager) startHelmReleasePolling(ctx context.Context) error {
 			case <-im.stopCh:
 				return
 			case <-ticker.C:
+				im.refreshHelmSQLReleases(ctx)
 				if err := im.refreshHelmReleases(); err != nil {
 					// Log error but don't stop polling
 					im.logger.Error("Error refreshing Helm releases during safety poll", "error", err)
@@ -790,58 +870,91 @@ func (im *InformerManager) startHelmReleasePolling(ctx context.Context) error {
 	return nil
 }
 
-// refreshHelmReleases decodes Helm releases from Secrets and updates the cache
-// This reads helm.sh/release.v1 secrets directly instead of using the Helm SDK
-// If forceUpdateTimestamp is true, the timestamp will be updated even if data hasn't changed
-func (im *InformerManager) refreshHelmReleasesWithTimestamp(forceUpdateTimestamp bool) error {
-	// Get all secrets from the cache
-	secrets := im.GetResources(SecretResource.GVR)
-
-	im.logger.Debug("Refreshing Helm releases", "totalSecrets", len(secrets))
+// SetHelmSQLConnection points the manager at the database of Helm's SQL storage driver for its cluster
+// It must be called before informers are started
+func (im *InformerManager) SetHelmSQLConnection(connection string) {
+	im.helmSQLConnection = connection
+}
 
-	helmResources := []TrackedObject{}
-	helmSecretCount := 0
+// refreshHelmSQLReleases reads the releases stored with Helm's SQL driver, if the cluster has SQL storage configured
+// Failures are logged and keep the releases last read
+func (im *InformerManager) refreshHelmSQLReleases(ctx context.Context) {
+	if im.helmSQLConnection == "" {
+		return
+	}
 
-	// Decode each Helm release secret
-	for _, secret := range secrets {
-		// Check if this is a Helm release secret
-		raw := secret.GetRaw()
-		if raw == nil {
-			continue
+	im.mu.Lock()
+	if im.helmSQLDB == nil {
+		db, err := helmutil.OpenSQLStorage(im.helmSQLConnection)
+		if err != nil {
+			im.mu.Unlock()
+			im.logger.Error("Failed to open Helm SQL storage", "error", err)
+			return
 		}
+		im.helmSQLDB = db
+	}
+	db := im.helmSQLDB
+	im.mu.Unlock()
 
-		// Use helper to check secret type
-		if !helmutil.IsHelmReleaseSecret(raw) {
-			continue
-		}
+	queryCtx, cancel := context.WithTimeout(ctx, helmSQLQueryTimeout)
+	defer cancel()
+	releases, err := helmutil.ListSQLReleases(queryCtx, db, im.logger)
+	if err != nil {
+		im.logger.Error("Failed to read Helm SQL storage", "error", err)
+		return
+	}
 
-		helmSecretCount++
+	im.mu.Lock()
+	im.helmSQLReleases = releases
+	im.mu.Unlock()
+	im.logger.Debug("Read Helm SQL storage", "releases", len(releases))
+}
 
-		// Decode the Helm release directly from cached unstructured data
-		// This avoids making individual API calls that cause rate limiting
-		release, err := helmutil.DecodeHelmSecretFromUnstructured(raw, im.logger)
-		if err != nil {
-			im.logger.Warn("Failed to decode Helm secret",
-				"name", secret.GetName(),
-				"namespace", secret.GetNamespace(),
-				"error", err)
-			continue
-		}
+// refreshHelmReleases decodes Helm releases from Secrets, ConfigMaps and SQL storage and updates the cache
+// This reads the storage drivers' records directly instead of using the Helm SDK
+// If forceUpdateTimestamp is true, the timestamp will be updated even if data hasn't changed
+func (im *InformerManager) refreshHelmReleasesWithTimestamp(forceUpdateTimestamp bool) error {
+	// Get all secrets and configmaps from the cache
+	secrets := im.GetResources(SecretResource.GVR)
+	configMaps := im.GetResources(ConfigMapResource.GVR)
+
+	im.logger.Debug("Refreshing Helm releases", "totalSecrets", len(secrets), "totalConfigMaps", len(configMaps))
 
+	// Decode each Helm release secret, and each configmap of Helm's ConfigMap driver
+	// This decodes directly from cached unstructured data, avoiding individual API calls that cause rate limiting
+	releases := im.decodeHelmReleases(secrets, helmutil.IsHelmReleaseSecret, helmutil.DecodeHelmSecretFromUnstructured)
+	releases = append(releases,
+		im.decodeHelmReleases(configMaps, helmutil.IsHelmReleaseConfigMap, helmutil.DecodeHelmConfigMapFromUnstructured)...)
+
+	// Releases of the SQL driver are read when polling, as they don't come through an informer
+	im.mu.RLock()
+	releases = append(releases, im.helmSQLReleases...)
+	im.mu.RUnlock()
+
+	helmResources := []TrackedObject{}
+	helmHistory := make(map[string][]*HelmReleaseRevision)
+	for _, release := range releases {
 		// Convert to our Resource type
 		helmResource := convertHelmReleaseToTrackedObject(release)
 		helmResources = append(helmResources, helmResource)
+
+		// Keep every revision for the release history
+		key := helmReleaseKey(release.Storage, release.Namespace, release.Name)
+		helmHistory[key] = append(helmHistory[key], convertHelmReleaseToRevision(release))
+	}
+	for _, revisions := range helmHistory {
+		sortHelmRevisions(revisions)
 	}
 
 	// Filter to keep only the latest revision of each release
-	// Group by namespace/name, keep highest revision
+	// Group by storage/namespace/name, keep highest revision
 	latestRevisions := make(map[string]TrackedObject)
 	for _, res := range helmResources {
 		helmRel, ok := res.(*HelmRelease)
 		if !ok {
 			continue
 		}
-		key := helmRel.Namespace + "/" + helmRel.Name
+		key := helmReleaseKey(helmRel.HelmStorage, helmRel.Namespace, helmRel.Name)
 		if existing, exists := latestRevisions[key]; exists {
 			existingRel := existing.(*HelmRelease)
 			if helmRel.HelmRevision > existingRel.HelmRevision {
@@ -859,7 +972,6 @@ func (im *InformerManager) refreshHelmReleasesWithTimestamp(forceUpdateTimestamp
 	}
 
 	im.logger.Debug("Helm release enumeration complete",
-		"helmSecretsFound", helmSecretCount,
 		"releasesDecoded", len(helmResources),
 		"uniqueReleases", len(filteredResources))
 
@@ -867,10 +979,12 @@ func (im *InformerManager) refreshHelmReleasesWithTimestamp(forceUpdateTimestamp
 	im.mu.Lock()
 	oldHelmResources := im.helmResources
 
-	changed := helmReleasesChanged(oldHelmResources, filteredResources)
+	changed := helmReleasesChanged(oldHelmResources, filteredResources) ||
+		helmHistoryChanged(im.helmHistory, helmHistory)
 
 	if changed {
 		im.helmResources = filteredResources
+		im.helmHistory = helmHistory
 		im.lastUpdateTime[HelmReleaseResource.GVR] = time.Now()
 		im.mu.Unlock()
 
@@ -889,6 +1003,45 @@ func (im *InformerManager) refreshHelmReleasesWithTimestamp(forceUpdateTimestamp
 	return nil
 }
 
+// GetHelmReleaseHistory returns every revision of a Helm release in a storage driver, newest first
+func (im *InformerManager) GetHelmReleaseHistory(storage, namespace, name string) []*HelmReleaseRevision {
+	im.mu.RLock()
+	defer im.mu.RUnlock()
+
+	revisions := im.helmHistory[helmReleaseKey(storage, namespace, name)]
+	result := make([]*HelmReleaseRevision, len(revisions))
+	copy(result, revisions)
+	return result
+}
+
+// decodeHelmReleases decodes the Helm releases held by cached objects of one storage driver
+// Objects that fail to decode are logged and skipped
+func (im *InformerManager) decodeHelmReleases(
+	objects []TrackedObject,
+	isRelease func(*unstructured.Unstructured) bool,
+	decode func(*unstructured.Unstructured, *slog.Logger) (*helmutil.HelmRelease, error),
+) []*helmutil.HelmRelease {
+	var releases []*helmutil.HelmRelease
+	for _, object := range objects {
+		raw := object.GetRaw()
+		if raw == nil || !isRelease(raw) {
+			continue
+		}
+
+		release, err := decode(raw, im.logger)
+		if err != nil {
+			im.logger.Warn("Failed to decode Helm release",
+				"kind", raw.GetKind(),
+				"name", object.GetName(),
+				"namespace", object.GetNamespace(),
+				"error", err)
+			continue
+		}
+		releases = append(releases, release)
+	}
+	return releases
+}
+
 // refreshHelmReleases is a convenience wrapper for automatic polling
 func (im *InformerManager) refreshHelmReleases() error {
 	return im.refreshHelmReleasesWithTimestamp(false)
@@ -0,0 +1,257 @@
+package k8s
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ContainerInfo describes a container whose logs can be streamed
+type ContainerInfo struct {
+	Name         string
+	Init         bool
+	Ephemeral    bool
+	RestartCount int64
+}
+
+// DisplayName returns the container name annotated with its container type
+func (ci ContainerInfo) DisplayName() string {
+	switch {
+	case ci.Init:
+		return ci.Name + " (init)"
+	case ci.Ephemeral:
+		return ci.Name + " (ephemeral)"
+	default:
+		return ci.Name
+	}
+}
+
+// LogOptions controls how container logs are streamed
+type LogOptions struct {
+	Container  string
+	Previous   bool  // Stream logs from the previous (terminated) instance of the container
+	Follow     bool  // Keep the stream open and receive new lines as they are written
+	TailLines  int64 // Number of lines from the end of the log to start with (0 = all)
+	Timestamps bool
+}
+
+// LogTarget identifies a single container stream
+type LogTarget struct {
+	Namespace string
+	Pod       string
+	Container string
+}
+
+// Key returns a unique key for the target
+func (t LogTarget) Key() string {
+	return t.Namespace + "/" + t.Pod + "/" + t.Container
+}
+
+// LogLine is a single line of container output
+// Err is set (and Text empty) when the stream for the target ended with an error,
+// and Done is set when the stream ended normally (e.g. the container exited)
+type LogLine struct {
+	Target LogTarget
+	Text   string
+	Err    error
+	Done   bool
+}
+
+// GetPodContainers returns the containers of a pod from its cached manifest
+// Init containers are listed first, followed by regular and ephemeral containers
+func GetPodContainers(pod TrackedObject) []ContainerInfo {
+	raw := pod.GetRaw()
+	if raw == nil {
+		return nil
+	}
+
+	restartCounts := make(map[string]int64)
+	for _, statusField := range []string{"initContainerStatuses", "containerStatuses", "ephemeralContainerStatuses"} {
+		statuses, _, _ := unstructured.NestedSlice(raw.Object, "status", statusField)
+		for _, s := range statuses {
+			if status, ok := s.(map[string]interface{}); ok {
+				name, _, _ := unstructured.NestedString(status, "name")
+				count, _, _ := unstructured.NestedInt64(status, "restartCount")
+				restartCounts[name] = count
+			}
+		}
+	}
+
+	var containers []ContainerInfo
+	addContainers := func(field string, init, ephemeral bool) {
+		specs, _, _ := unstructured.NestedSlice(raw.Object, "spec", field)
+		for _, s := range specs {
+			if spec, ok := s.(map[string]interface{}); ok {
+				name, _, _ := unstructured.NestedString(spec, "name")
+				containers = append(containers, ContainerInfo{
+					Name:         name,
+					Init:         init,
+					Ephemeral:    ephemeral,
+					RestartCount: restartCounts[name],
+				})
+			}
+		}
+	}
+
+	addContainers("initContainers", true, false)
+	addContainers("containers", false, false)
+	addContainers("ephemeralContainers", false, true)
+
+	return containers
+}
+
+// StreamPodLogs opens a log stream for a single container of a pod
+// The caller is responsible for closing the returned reader
+func (c *Client) StreamPodLogs(ctx context.Context, namespace, pod string, opts LogOptions) (io.ReadCloser, error) {
+	podLogOpts := &corev1.PodLogOptions{
+		Container:  opts.Container,
+		Follow:     opts.Follow && !opts.Previous, // Previous instances are terminated and cannot be followed
+		Previous:   opts.Previous,
+		Timestamps: opts.Timestamps,
+	}
+	if opts.TailLines > 0 {
+		tail := opts.TailLines
+		podLogOpts.TailLines = &tail
+	}
+
+	stream, err := c.Clientset.CoreV1().Pods(namespace).GetLogs(pod, podLogOpts).Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream logs for %s/%s (%s): %w", namespace, pod, opts.Container, err)
+	}
+
+	return stream, nil
+}
+
+// LogStreamer fans one or more container log streams into a single channel of lines
+// Streams can be started and stopped individually while the streamer is running
+type LogStreamer struct {
+	client  *Client
+	logger  *slog.Logger
+	ctx     context.Context
+	cancel  context.CancelFunc
+	lines   chan LogLine
+	mu      sync.Mutex
+	streams map[string]context.CancelFunc // Keyed by LogTarget.Key()
+}
+
+// NewLogStreamer creates a log streamer bound to the given client
+func NewLogStreamer(ctx context.Context, client *Client, logger *slog.Logger) *LogStreamer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+
+	return &LogStreamer{
+		client:  client,
+		logger:  logger,
+		ctx:     streamCtx,
+		cancel:  cancel,
+		lines:   make(chan LogLine, 256),
+		streams: make(map[string]context.CancelFunc),
+	}
+}
+
+// Lines returns the channel that receives lines from all active streams
+func (ls *LogStreamer) Lines() <-chan LogLine {
+	return ls.lines
+}
+
+// Done returns a channel that is closed when the streamer is closed
+func (ls *LogStreamer) Done() <-chan struct{} {
+	return ls.ctx.Done()
+}
+
+// Start begins streaming logs for the target, replacing any existing stream for it
+func (ls *LogStreamer) Start(target LogTarget, opts LogOptions) {
+	ls.mu.Lock()
+	if cancel, exists := ls.streams[target.Key()]; exists {
+		cancel()
+	}
+	streamCtx, cancel := context.WithCancel(ls.ctx)
+	ls.streams[target.Key()] = cancel
+	ls.mu.Unlock()
+
+	opts.Container = target.Container
+
+	go ls.run(streamCtx, target, opts)
+}
+
+// Stop ends the stream for the target if it is running
+func (ls *LogStreamer) Stop(target LogTarget) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if cancel, exists := ls.streams[target.Key()]; exists {
+		cancel()
+		delete(ls.streams, target.Key())
+	}
+}
+
+// IsStreaming reports whether a stream for the target has been started and not stopped
+func (ls *LogStreamer) IsStreaming(target LogTarget) bool {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	_, exists := ls.streams[target.Key()]
+	return exists
+}
+
+// Close stops all streams
+func (ls *LogStreamer) Close() {
+	ls.cancel()
+
+	ls.mu.Lock()
+	ls.streams = make(map[string]context.CancelFunc)
+	ls.mu.Unlock()
+}
+
+// run reads a single container stream line by line until it ends or is cancelled
+func (ls *LogStreamer) run(ctx context.Context, target LogTarget, opts LogOptions) {
+	ls.logger.Debug("Starting log stream", "target", target.Key(), "previous", opts.Previous)
+
+	stream, err := ls.client.StreamPodLogs(ctx, target.Namespace, target.Pod, opts)
+	if err != nil {
+		ls.send(ctx, LogLine{Target: target, Err: err})
+		return
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	// Allow long lines (e.g. JSON logs) up to 1MB
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		if !ls.send(ctx, LogLine{Target: target, Text: scanner.Text()}) {
+			return
+		}
+	}
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	if err := scanner.Err(); err != nil {
+		ls.send(ctx, LogLine{Target: target, Err: fmt.Errorf("log stream interrupted: %w", err)})
+	} else {
+		ls.send(ctx, LogLine{Target: target, Done: true})
+	}
+
+	ls.logger.Debug("Log stream ended", "target", target.Key())
+}
+
+// send delivers a line unless the stream has been cancelled
+func (ls *LogStreamer) send(ctx context.Context, line LogLine) bool {
+	select {
+	case ls.lines <- line:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
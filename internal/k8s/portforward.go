@@ -0,0 +1,693 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// portForwardStopTimeout bounds how long stopping a forward waits for its connection to close
+const portForwardStopTimeout = 5 * time.Second
+
+// PortForwardState represents the lifecycle state of a port-forward
+type PortForwardState int
+
+const (
+	PortForwardStarting PortForwardState = iota
+	PortForwardActive
+	PortForwardStopped
+	PortForwardFailed
+)
+
+// String returns the display name of the state
+func (s PortForwardState) String() string {
+	switch s {
+	case PortForwardStarting:
+		return "Starting"
+	case PortForwardActive:
+		return "Active"
+	case PortForwardStopped:
+		return "Stopped"
+	case PortForwardFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// PortForwardRequest describes the resource and ports to forward
+type PortForwardRequest struct {
+	Namespace  string
+	Kind       string // "Pod" or "Service"
+	Name       string
+	LocalPort  int // 0 picks a free local port
+	RemotePort int // The container port for a Pod, or the service port for a Service
+}
+
+// PortForward is a snapshot of a port-forward session
+type PortForward struct {
+	ID            int
+	Request       PortForwardRequest
+	Pod           string // Pod the traffic is forwarded to (resolved from the selector for Services)
+	LocalPort     int
+	PodPort       int
+	State         PortForwardState
+	Connections   int64
+	BytesSent     int64 // Local -> pod
+	BytesReceived int64 // Pod -> local
+	Err           error // Why the forward failed, or the last error reported by the pod
+	StartedAt     time.Time
+}
+
+// ParsePortMapping parses a kubectl-style port mapping
+// "8080:80" forwards local 8080 to 80, "80" uses the same port locally and ":80" picks a free local port
+func ParsePortMapping(mapping string) (local, remote int, err error) {
+	mapping = strings.TrimSpace(mapping)
+	localPart, remotePart, hasLocal := strings.Cut(mapping, ":")
+	if !hasLocal {
+		remotePart = localPart
+	}
+
+	remote, err = strconv.Atoi(remotePart)
+	if err != nil || remote < 1 || remote > 65535 {
+		return 0, 0, fmt.Errorf("invalid remote port %q", remotePart)
+	}
+
+	if !hasLocal {
+		return remote, remote, nil
+	}
+	if localPart == "" {
+		return 0, remote, nil
+	}
+
+	local, err = strconv.Atoi(localPart)
+	if err != nil || local < 0 || local > 65535 {
+		return 0, 0, fmt.Errorf("invalid local port %q", localPart)
+	}
+	return local, remote, nil
+}
+
+// GetForwardablePorts returns the TCP ports declared by a Pod's containers or a Service's spec
+func GetForwardablePorts(resource TrackedObject) []int {
+	raw := resource.GetRaw()
+	if raw == nil {
+		return nil
+	}
+
+	var ports []int
+	addPort := func(port map[string]interface{}, field string) {
+		if protocol, _, _ := unstructured.NestedString(port, "protocol"); protocol != "" && protocol != string(corev1.ProtocolTCP) {
+			return
+		}
+		if value, found, _ := unstructured.NestedInt64(port, field); found {
+			ports = append(ports, int(value))
+		}
+	}
+
+	switch resource.GetKind() {
+	case "Pod":
+		containers, _, _ := unstructured.NestedSlice(raw.Object, "spec", "containers")
+		for _, c := range containers {
+			container, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			containerPorts, _, _ := unstructured.NestedSlice(container, "ports")
+			for _, p := range containerPorts {
+				if port, ok := p.(map[string]interface{}); ok {
+					addPort(port, "containerPort")
+				}
+			}
+		}
+	case "Service":
+		servicePorts, _, _ := unstructured.NestedSlice(raw.Object, "spec", "ports")
+		for _, p := range servicePorts {
+			if port, ok := p.(map[string]interface{}); ok {
+				addPort(port, "port")
+			}
+		}
+	}
+
+	return ports
+}
+
+// PortForwardManager runs port-forwards in the background and tracks their state
+// Forwards keep running until they are stopped, fail, or the manager is stopped
+type PortForwardManager struct {
+	logger   *slog.Logger
+	onChange func() // Called whenever a forward changes state
+	mu       sync.Mutex
+	nextID   int
+	sessions map[int]*portForwardSession
+}
+
+// portForwardSession is a single forward, which may be run several times through restarts
+type portForwardSession struct {
+	id      int
+	client  *Client
+	request PortForwardRequest
+
+	mu        sync.Mutex
+	pod       string
+	localPort int
+	podPort   int
+	state     PortForwardState
+	err       error
+	startedAt time.Time
+	run       *portForwardRun
+
+	connections   atomic.Int64
+	bytesSent     atomic.Int64
+	bytesReceived atomic.Int64
+}
+
+// portForwardRun is one forwarding attempt of a session
+type portForwardRun struct {
+	stopCh   chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+	stopping atomic.Bool
+}
+
+// stop signals the forwarder to shut down
+func (r *portForwardRun) stop() {
+	r.stopOnce.Do(func() {
+		r.stopping.Store(true)
+		close(r.stopCh)
+	})
+}
+
+// NewPortForwardManager creates an empty port-forward manager
+func NewPortForwardManager(logger *slog.Logger, onChange func()) *PortForwardManager {
+	return &PortForwardManager{
+		logger:   logger,
+		onChange: onChange,
+		nextID:   1,
+		sessions: make(map[int]*portForwardSession),
+	}
+}
+
+// Start begins forwarding with the given client and waits until the local port is listening
+// The context only bounds startup; the forward keeps running after Start returns
+// A forward that fails to start is kept in the list so it can be restarted
+func (pfm *PortForwardManager) Start(ctx context.Context, client *Client, req PortForwardRequest) (PortForward, error) {
+	pfm.mu.Lock()
+	session := &portForwardSession{
+		id:      pfm.nextID,
+		client:  client,
+		request: req,
+	}
+	pfm.nextID++
+	pfm.sessions[session.id] = session
+	pfm.mu.Unlock()
+
+	err := pfm.run(ctx, session)
+	return session.snapshot(), err
+}
+
+// Restart stops a forward if it is running and starts it again
+// Service selectors are resolved again, so a restart picks up a replacement pod
+func (pfm *PortForwardManager) Restart(ctx context.Context, id int) (PortForward, error) {
+	session, err := pfm.get(id)
+	if err != nil {
+		return PortForward{}, err
+	}
+
+	pfm.stopSession(session)
+	err = pfm.run(ctx, session)
+	return session.snapshot(), err
+}
+
+// Stop stops a running forward, keeping it in the list
+func (pfm *PortForwardManager) Stop(id int) error {
+	session, err := pfm.get(id)
+	if err != nil {
+		return err
+	}
+
+	pfm.stopSession(session)
+	return nil
+}
+
+// Remove stops a forward and removes it from the list
+func (pfm *PortForwardManager) Remove(id int) error {
+	session, err := pfm.get(id)
+	if err != nil {
+		return err
+	}
+
+	pfm.stopSession(session)
+
+	pfm.mu.Lock()
+	delete(pfm.sessions, id)
+	pfm.mu.Unlock()
+
+	pfm.notify()
+	return nil
+}
+
+// List returns a snapshot of all forwards ordered by ID
+func (pfm *PortForwardManager) List() []PortForward {
+	pfm.mu.Lock()
+	sessions := make([]*portForwardSession, 0, len(pfm.sessions))
+	for _, session := range pfm.sessions {
+		sessions = append(sessions, session)
+	}
+	pfm.mu.Unlock()
+
+	forwards := make([]PortForward, 0, len(sessions))
+	for _, session := range sessions {
+		forwards = append(forwards, session.snapshot())
+	}
+	sort.Slice(forwards, func(i, j int) bool {
+		return forwards[i].ID < forwards[j].ID
+	})
+	return forwards
+}
+
+// StopAll stops and removes every forward
+func (pfm *PortForwardManager) StopAll() {
+	pfm.mu.Lock()
+	sessions := pfm.sessions
+	pfm.sessions = make(map[int]*portForwardSession)
+	pfm.mu.Unlock()
+
+	if len(sessions) == 0 {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, session := range sessions {
+		wg.Add(1)
+		go func(s *portForwardSession) {
+			defer wg.Done()
+			pfm.stopSession(s)
+		}(session)
+	}
+	wg.Wait()
+
+	pfm.logger.Info("Stopped all port-forwards", "count", len(sessions))
+	pfm.notify()
+}
+
+// get looks up a session by ID
+func (pfm *PortForwardManager) get(id int) (*portForwardSession, error) {
+	pfm.mu.Lock()
+	defer pfm.mu.Unlock()
+
+	session, exists := pfm.sessions[id]
+	if !exists {
+		return nil, fmt.Errorf("port-forward %d not found", id)
+	}
+	return session, nil
+}
+
+// run resolves the target pod and starts a new forwarding attempt for the session
+func (pfm *PortForwardManager) run(ctx context.Context, session *portForwardSession) error {
+	req := session.request
+
+	session.mu.Lock()
+	// Keep the local port stable across restarts, even when it was picked automatically
+	if session.localPort != 0 {
+		req.LocalPort = session.localPort
+	}
+	session.state = PortForwardStarting
+	session.err = nil
+	session.startedAt = time.Now()
+	session.mu.Unlock()
+	session.connections.Store(0)
+	session.bytesSent.Store(0)
+	session.bytesReceived.Store(0)
+	pfm.notify()
+
+	pod, podPort, err := session.client.resolvePortForwardTarget(ctx, req)
+	if err != nil {
+		return pfm.fail(session, err)
+	}
+
+	dialer, err := session.client.portForwardDialer(req.Namespace, pod)
+	if err != nil {
+		return pfm.fail(session, err)
+	}
+
+	run := &portForwardRun{
+		stopCh: make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	readyCh := make(chan struct{})
+
+	ports := []string{fmt.Sprintf("%d:%d", req.LocalPort, podPort)}
+	forwarder, err := portforward.NewOnAddresses(&countingDialer{Dialer: dialer, session: session},
+		[]string{"localhost"}, ports, run.stopCh, readyCh, io.Discard, io.Discard)
+	if err != nil {
+		return pfm.fail(session, fmt.Errorf("failed to create port-forward: %w", err))
+	}
+
+	session.mu.Lock()
+	session.pod = pod
+	session.podPort = podPort
+	session.run = run
+	session.mu.Unlock()
+
+	go func() {
+		pfm.finish(session, run, forwarder.ForwardPorts())
+		close(run.done)
+	}()
+
+	select {
+	case <-readyCh:
+	case <-run.done:
+		if err := session.snapshot().Err; err != nil {
+			return err
+		}
+		return errors.New("port-forward was stopped before it started")
+	case <-ctx.Done():
+		run.stop()
+		<-run.done
+		return pfm.fail(session, fmt.Errorf("port-forward did not start: %w", ctx.Err()))
+	}
+
+	localPort := req.LocalPort
+	if forwarded, err := forwarder.GetPorts(); err == nil && len(forwarded) > 0 {
+		localPort = int(forwarded[0].Local)
+	}
+
+	session.mu.Lock()
+	if session.run == run && session.state == PortForwardStarting {
+		session.state = PortForwardActive
+		session.localPort = localPort
+	}
+	session.mu.Unlock()
+
+	pfm.logger.Info("Port-forward started",
+		"id", session.id, "namespace", req.Namespace, "pod", pod,
+		"localPort", localPort, "podPort", podPort)
+	pfm.notify()
+	return nil
+}
+
+// finish records the outcome of a forwarding attempt once the forwarder returns
+func (pfm *PortForwardManager) finish(session *portForwardSession, run *portForwardRun, err error) {
+	session.mu.Lock()
+	if session.run != run {
+		// The session has been restarted since this attempt began
+		session.mu.Unlock()
+		return
+	}
+
+	if run.stopping.Load() {
+		session.state = PortForwardStopped
+	} else {
+		session.state = PortForwardFailed
+		// A connection closed by the pod is reported with the pod's own error message when there is one
+		if session.err == nil || !errors.Is(err, portforward.ErrLostConnectionToPod) {
+			session.err = err
+		}
+		if session.err == nil {
+			session.err = portforward.ErrLostConnectionToPod
+		}
+	}
+	state, id := session.state, session.id
+	session.mu.Unlock()
+
+	if state == PortForwardFailed {
+		pfm.logger.Warn("Port-forward failed", "id", id, "error", err)
+	} else {
+		pfm.logger.Info("Port-forward stopped", "id", id)
+	}
+	pfm.notify()
+}
+
+// fail marks a session as failed before its forwarder started
+func (pfm *PortForwardManager) fail(session *portForwardSession, err error) error {
+	session.mu.Lock()
+	session.state = PortForwardFailed
+	session.err = err
+	session.mu.Unlock()
+
+	pfm.logger.Warn("Port-forward failed to start", "id", session.id, "error", err)
+	pfm.notify()
+	return err
+}
+
+// stopSession stops the session's current attempt and waits for it to shut down
+func (pfm *PortForwardManager) stopSession(session *portForwardSession) {
+	session.mu.Lock()
+	run := session.run
+	session.mu.Unlock()
+
+	if run == nil {
+		return
+	}
+
+	run.stop()
+	select {
+	case <-run.done:
+	case <-time.After(portForwardStopTimeout):
+		pfm.logger.Warn("Timed out waiting for port-forward to stop", "id", session.id)
+	}
+}
+
+// notify reports a state change to the owner
+func (pfm *PortForwardManager) notify() {
+	if pfm.onChange != nil {
+		pfm.onChange()
+	}
+}
+
+// snapshot copies the session's current state
+func (s *portForwardSession) snapshot() PortForward {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return PortForward{
+		ID:            s.id,
+		Request:       s.request,
+		Pod:           s.pod,
+		LocalPort:     s.localPort,
+		PodPort:       s.podPort,
+		State:         s.state,
+		Connections:   s.connections.Load(),
+		BytesSent:     s.bytesSent.Load(),
+		BytesReceived: s.bytesReceived.Load(),
+		Err:           s.err,
+		StartedAt:     s.startedAt,
+	}
+}
+
+// recordError stores an error reported by the pod for one of the forwarded connections
+func (s *portForwardSession) recordError(err error) {
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+}
+
+// resolvePortForwardTarget returns the pod and pod port that a request forwards to
+// Services are resolved to a ready pod matching their selector, and the service port is mapped to its target port
+func (c *Client) resolvePortForwardTarget(ctx context.Context, req PortForwardRequest) (string, int, error) {
+	switch req.Kind {
+	case "Pod":
+		return req.Name, req.RemotePort, nil
+	case "Service":
+	default:
+		return "", 0, fmt.Errorf("cannot port-forward to a %s", req.Kind)
+	}
+
+	svc, err := c.Clientset.CoreV1().Services(req.Namespace).Get(ctx, req.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get service %s/%s: %w", req.Namespace, req.Name, err)
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return "", 0, fmt.Errorf("service %s/%s has no selector", req.Namespace, req.Name)
+	}
+
+	var servicePort *corev1.ServicePort
+	for i := range svc.Spec.Ports {
+		if int(svc.Spec.Ports[i].Port) == req.RemotePort {
+			servicePort = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if servicePort == nil {
+		return "", 0, fmt.Errorf("service %s/%s does not expose port %d", req.Namespace, req.Name, req.RemotePort)
+	}
+	if servicePort.Protocol != "" && servicePort.Protocol != corev1.ProtocolTCP {
+		return "", 0, fmt.Errorf("port %d of service %s/%s uses %s; only TCP can be forwarded", req.RemotePort, req.Namespace, req.Name, servicePort.Protocol)
+	}
+
+	pods, err := c.Clientset.CoreV1().Pods(req.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to list pods for service %s/%s: %w", req.Namespace, req.Name, err)
+	}
+
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !isPodReady(pod) {
+			continue
+		}
+		podPort, err := resolveTargetPort(pod, servicePort)
+		if err != nil {
+			return "", 0, err
+		}
+		return pod.Name, podPort, nil
+	}
+
+	return "", 0, fmt.Errorf("no ready pods found for service %s/%s", req.Namespace, req.Name)
+}
+
+// isPodReady reports whether a pod is running, not terminating and passing its readiness checks
+func isPodReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// resolveTargetPort maps a service port to the matching container port of a pod
+func resolveTargetPort(pod *corev1.Pod, servicePort *corev1.ServicePort) (int, error) {
+	switch {
+	case servicePort.TargetPort.Type == intstr.Int && servicePort.TargetPort.IntVal != 0:
+		return int(servicePort.TargetPort.IntVal), nil
+	case servicePort.TargetPort.Type == intstr.String && servicePort.TargetPort.StrVal != "":
+		for _, container := range pod.Spec.Containers {
+			for _, port := range container.Ports {
+				if port.Name == servicePort.TargetPort.StrVal && (port.Protocol == "" || port.Protocol == corev1.ProtocolTCP) {
+					return int(port.ContainerPort), nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("pod %s has no container port named %q", pod.Name, servicePort.TargetPort.StrVal)
+	default:
+		// An unset target port defaults to the service port
+		return int(servicePort.Port), nil
+	}
+}
+
+// portForwardDialer creates a dialer for a pod's portforward subresource
+// SPDY tunneled over WebSockets is preferred, falling back to plain SPDY for API servers that don't support it
+func (c *Client) portForwardDialer(namespace, pod string) (httpstream.Dialer, error) {
+	req := c.Clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("portforward")
+
+	transport, upgrader, err := spdy.RoundTripperFor(c.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SPDY transport: %w", err)
+	}
+	spdyDialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
+
+	tunnelingDialer, err := portforward.NewSPDYOverWebsocketDialer(req.URL(), c.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create WebSocket dialer: %w", err)
+	}
+
+	return portforward.NewFallbackDialer(tunnelingDialer, spdyDialer, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	}), nil
+}
+
+// countingDialer wraps a dialer so traffic on forwarded connections is recorded on the session
+type countingDialer struct {
+	httpstream.Dialer
+	session *portForwardSession
+}
+
+// Dial opens the connection and wraps it for accounting
+func (d *countingDialer) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	conn, protocol, err := d.Dialer.Dial(protocols...)
+	if err != nil {
+		return nil, protocol, err
+	}
+	return &countingConnection{Connection: conn, session: d.session}, protocol, nil
+}
+
+// countingConnection wraps the data and error streams created for each forwarded connection
+type countingConnection struct {
+	httpstream.Connection
+	session *portForwardSession
+}
+
+// CreateStream creates a stream and wraps it according to its type
+func (c *countingConnection) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	stream, err := c.Connection.CreateStream(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	switch headers.Get(corev1.StreamType) {
+	case corev1.StreamTypeData:
+		c.session.connections.Add(1)
+		return &countingStream{Stream: stream, session: c.session}, nil
+	case corev1.StreamTypeError:
+		return &errorStream{Stream: stream, session: c.session}, nil
+	default:
+		return stream, nil
+	}
+}
+
+// countingStream counts the bytes copied through a data stream
+type countingStream struct {
+	httpstream.Stream
+	session *portForwardSession
+}
+
+// Read counts bytes received from the pod
+func (s *countingStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	s.session.bytesReceived.Add(int64(n))
+	return n, err
+}
+
+// Write counts bytes sent to the pod
+func (s *countingStream) Write(p []byte) (int, error) {
+	n, err := s.Stream.Write(p)
+	s.session.bytesSent.Add(int64(n))
+	return n, err
+}
+
+// errorStream captures the message the pod reports when a forwarded connection fails
+type errorStream struct {
+	httpstream.Stream
+	session *portForwardSession
+	message []byte
+}
+
+// Read collects the error message and records it once the stream ends
+func (s *errorStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	s.message = append(s.message, p[:n]...)
+	if err == io.EOF && len(s.message) > 0 {
+		s.session.recordError(errors.New(strings.TrimSpace(string(s.message))))
+	}
+	return n, err
+}
@@ -0,0 +1,194 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/miles-w-3/lobot/internal/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// customResourceDefinitionGVR is the GVR CRDs are listed from to read their printer columns
+var customResourceDefinitionGVR = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1",
+	Resource: "customresourcedefinitions",
+}
+
+// crdListTimeout bounds how long listing CRDs may delay resource discovery
+const crdListTimeout = 10 * time.Second
+
+// creationTimestampPath is the JSONPath CRDs use for their Age column
+const creationTimestampPath = ".metadata.creationTimestamp"
+
+// printerColumn is a parsed additionalPrinterColumns entry of a CRD version
+type printerColumn struct {
+	title    string
+	colType  string
+	jsonPath string
+	width    int
+
+	// JSONPath keeps evaluation state, so a parsed expression is used by one caller at a time
+	mu   sync.Mutex
+	path *jsonpath.JSONPath
+}
+
+// loadPrinterColumns lists the cluster's CRDs and returns the default (priority 0) printer columns
+// of each served version that declares any
+func loadPrinterColumns(client dynamic.Interface) (map[schema.GroupVersionResource][]*printerColumn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), crdListTimeout)
+	defer cancel()
+
+	list, err := client.Resource(customResourceDefinitionGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom resource definitions: %w", err)
+	}
+
+	columns := make(map[schema.GroupVersionResource][]*printerColumn)
+	for i := range list.Items {
+		crd := &list.Items[i]
+		group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+		plural, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "plural")
+		versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+
+		for _, v := range versions {
+			version, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(version, "name")
+			specs, _, _ := unstructured.NestedSlice(version, "additionalPrinterColumns")
+
+			parsed := parsePrinterColumns(specs)
+			if len(parsed) == 0 {
+				continue
+			}
+			columns[schema.GroupVersionResource{Group: group, Version: name, Resource: plural}] = parsed
+		}
+	}
+	return columns, nil
+}
+
+// parsePrinterColumns parses the priority 0 columns kubectl shows by default, skipping invalid JSONPath expressions
+func parsePrinterColumns(specs []interface{}) []*printerColumn {
+	var columns []*printerColumn
+	for _, s := range specs {
+		spec, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if priority, _, _ := unstructured.NestedInt64(spec, "priority"); priority != 0 {
+			continue
+		}
+
+		name, _, _ := unstructured.NestedString(spec, "name")
+		colType, _, _ := unstructured.NestedString(spec, "type")
+		path, _, _ := unstructured.NestedString(spec, "jsonPath")
+
+		parser := jsonpath.New(name).AllowMissingKeys(true)
+		if err := parser.Parse(fmt.Sprintf("{%s}", path)); err != nil {
+			continue
+		}
+
+		title := strings.ToUpper(name)
+		columns = append(columns, &printerColumn{
+			title:    title,
+			colType:  colType,
+			jsonPath: path,
+			width:    printerColumnWidth(title, colType, path),
+			path:     parser,
+		})
+	}
+	return columns
+}
+
+// printerColumnWidth picks a preferred width from the column type, giving messages room to be read
+func printerColumnWidth(title, colType, path string) int {
+	width := 8
+	switch colType {
+	case "date":
+		width = 6
+	case "string":
+		width = 15
+		if lower := strings.ToLower(path); strings.HasSuffix(lower, "message") || strings.HasSuffix(lower, "reason") {
+			width = 40
+		}
+	}
+	return max(width, len(title))
+}
+
+// value evaluates the column against an object the way the API server's table output does:
+// only the first result is shown, and dates are shown as ages
+func (c *printerColumn) value(raw *unstructured.Unstructured) string {
+	c.mu.Lock()
+	results, err := c.path.FindResults(raw.Object)
+	c.mu.Unlock()
+	if err != nil || len(results) == 0 || len(results[0]) == 0 {
+		return ""
+	}
+
+	value := results[0][0].Interface()
+	if c.colType == "date" {
+		s, _ := value.(string)
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return "<unknown>"
+		}
+		return util.FormatAge(time.Since(t))
+	}
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// printerColumnTableParams builds the table of a custom resource from its CRD's printer columns
+// NAME (and NAMESPACE) come first; AGE is appended unless the CRD already shows the creation timestamp
+func printerColumnTableParams(printerColumns []*printerColumn, namespaced bool) TableParams {
+	columns := []table.Column{{Title: "NAME", Width: 40}}
+	if namespaced {
+		columns = append(columns, table.Column{Title: "NAMESPACE", Width: 20})
+	}
+
+	showAge := true
+	for _, col := range printerColumns {
+		columns = append(columns, table.Column{Title: col.title, Width: col.width})
+		if col.jsonPath == creationTimestampPath {
+			showAge = false
+		}
+	}
+	if showAge {
+		columns = append(columns, table.Column{Title: "AGE", Width: 6})
+	}
+
+	rowBinder := func(obj TrackedObject) table.Row {
+		row := table.Row{obj.GetName()}
+		if namespaced {
+			row = append(row, obj.GetNamespace())
+		}
+
+		raw := obj.GetRaw()
+		for _, col := range printerColumns {
+			if raw == nil {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, col.value(raw))
+		}
+
+		if showAge {
+			row = append(row, util.FormatAge(obj.GetAge()))
+		}
+		return row
+	}
+
+	return TableParams{columnOverride: columns, rowBinder: rowBinder}
+}
@@ -0,0 +1,166 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// WriteMode is what lobot may change on a cluster
+type WriteMode int
+
+const (
+	// WriteAllowed lets changes through
+	WriteAllowed WriteMode = iota
+	// WriteConfirm lets changes through only once they have been confirmed by typing the context name
+	WriteConfirm
+	// WriteDenied refuses all changes
+	WriteDenied
+)
+
+// String returns a human-readable write mode
+func (m WriteMode) String() string {
+	switch m {
+	case WriteConfirm:
+		return "protected"
+	case WriteDenied:
+		return "read-only"
+	default:
+		return "read-write"
+	}
+}
+
+// ErrWriteProtected is returned for API calls that would change a cluster lobot may not change
+var ErrWriteProtected = errors.New("write protected")
+
+// readOnlyResources are resources that are created only to ask the API server a question
+var readOnlyResources = map[string]bool{
+	"selfsubjectaccessreviews": true,
+	"selfsubjectrulesreviews":  true,
+	"selfsubjectreviews":       true,
+}
+
+// WriteProtection decides which contexts lobot may change
+type WriteProtection struct {
+	// ReadOnly refuses changes in every context
+	ReadOnly bool
+	// protected matches the names of contexts where changes must be confirmed
+	protected []*regexp.Regexp
+}
+
+// NewWriteProtection creates the write protection for the --read-only flag and the protected context patterns
+// Patterns are regular expressions that must match the whole context name
+func NewWriteProtection(readOnly bool, protectedContexts []string) (*WriteProtection, error) {
+	p := &WriteProtection{ReadOnly: readOnly}
+	for _, pattern := range protectedContexts {
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid protected context pattern %q: %w", pattern, err)
+		}
+		p.protected = append(p.protected, re)
+	}
+	return p, nil
+}
+
+// Mode returns what may be changed in a context
+func (p *WriteProtection) Mode(context string) WriteMode {
+	if p == nil {
+		return WriteAllowed
+	}
+	if p.ReadOnly {
+		return WriteDenied
+	}
+	for _, re := range p.protected {
+		if re.MatchString(context) {
+			return WriteConfirm
+		}
+	}
+	return WriteAllowed
+}
+
+// confirmedWritesKey marks a context.Context whose requests were confirmed for a protected context
+type confirmedWritesKey struct{}
+
+// ConfirmWrites marks a context so that changes made with it pass the confirmation a protected context requires
+// It has no effect in read-only mode
+func ConfirmWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, confirmedWritesKey{}, true)
+}
+
+// writesConfirmed reports whether a context was marked with ConfirmWrites
+func writesConfirmed(ctx context.Context) bool {
+	confirmed, _ := ctx.Value(confirmedWritesKey{}).(bool)
+	return confirmed
+}
+
+// writeGuard is an HTTP transport that refuses requests that would change the cluster, as the write mode requires
+// Every client built from the rest config goes through it, so no code path can make a change the mode forbids
+type writeGuard struct {
+	next    http.RoundTripper
+	mode    WriteMode
+	context string
+}
+
+// wrapTransport returns a rest config transport wrapper enforcing the write mode of a context
+func (p *WriteProtection) wrapTransport(context string) func(http.RoundTripper) http.RoundTripper {
+	mode := p.Mode(context)
+	return func(next http.RoundTripper) http.RoundTripper {
+		if mode == WriteAllowed {
+			return next
+		}
+		return &writeGuard{next: next, mode: mode, context: context}
+	}
+}
+
+// RoundTrip refuses requests that would change the cluster unless the write mode lets them through
+func (g *writeGuard) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isWriteRequest(req) {
+		switch {
+		case g.mode == WriteDenied:
+			return nil, fmt.Errorf("%w: lobot is in read-only mode", ErrWriteProtected)
+		case g.mode == WriteConfirm && !writesConfirmed(req.Context()):
+			return nil, fmt.Errorf("%w: changes to context %q must be confirmed", ErrWriteProtected, g.context)
+		}
+	}
+	return g.next.RoundTrip(req)
+}
+
+// isWriteRequest reports whether an API request would change the cluster or run something in it
+// Exec and attach count as writes even though websocket exec is a GET; dry runs and port-forwards don't
+func isWriteRequest(req *http.Request) bool {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	switch podSubresource(segments) {
+	case "exec", "attach":
+		return true
+	case "portforward":
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	case http.MethodPost:
+		if readOnlyResources[segments[len(segments)-1]] {
+			return false
+		}
+	}
+	return !req.URL.Query().Has("dryRun")
+}
+
+// podSubresource returns the subresource a request path addresses on a pod, e.g. "exec", or "" for other paths
+func podSubresource(segments []string) string {
+	// .../namespaces/<namespace>/pods/<name>/<subresource>
+	n := len(segments)
+	if n >= 5 && segments[n-5] == "namespaces" && segments[n-3] == "pods" {
+		return segments[n-1]
+	}
+	return ""
+}
+
+// WriteMode returns what the client may change on its cluster
+func (c *Client) WriteMode() WriteMode {
+	return c.Protection.Mode(c.Context)
+}
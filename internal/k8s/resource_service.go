@@ -18,6 +18,7 @@ const (
 	ServiceUpdateResources ServiceUpdateType = iota
 	ServiceUpdateReady
 	ServiceUpdateError
+	ServiceUpdatePortForwards
 )
 
 // ServiceUpdate represents an update from the service
@@ -27,6 +28,9 @@ type ServiceUpdate struct {
 	Error   error
 }
 
+// maxOwnedPodDepth bounds how many ownership levels are followed when collecting pods
+const maxOwnedPodDepth = 5
+
 // UpdateCallback is called when the resource service has updates
 type UpdateCallback func(ServiceUpdate)
 
@@ -40,6 +44,10 @@ type ResourceService struct {
 	discovery *ResourceDiscovery
 	logger    *slog.Logger
 	ctx       context.Context
+
+	portForwards *PortForwardManager // Outlives UI modes; torn down on Close and SwitchContext
+
+	helmSQLConnections map[string]string // Helm SQL storage connection strings, keyed by kube context
 }
 
 // NewResourceService creates a new resource service
@@ -53,6 +61,11 @@ func NewResourceService(ctx context.Context, client *Client, logger *slog.Logger
 		logger: logger,
 		ctx:    ctx,
 	}
+	svc.portForwards = NewPortForwardManager(logger, func() {
+		if svc.onUpdate != nil {
+			svc.onUpdate(ServiceUpdate{Type: ServiceUpdatePortForwards})
+		}
+	})
 
 	return svc, nil
 }
@@ -96,6 +109,17 @@ func (svc *ResourceService) GetResources(gvr schema.GroupVersionResource) []Trac
 	return svc.informer.GetResources(gvr)
 }
 
+// GetHelmReleaseHistory returns every revision of a Helm release, newest first
+func (svc *ResourceService) GetHelmReleaseHistory(release *HelmRelease) []*HelmReleaseRevision {
+	return svc.informer.GetHelmReleaseHistory(release.HelmStorage, release.Namespace, release.Name)
+}
+
+// SetHelmSQLConnections sets the connection strings of Helm SQL storage, keyed by the kube context they belong to
+// It must be called before FinalizeConfiguration
+func (svc *ResourceService) SetHelmSQLConnections(connections map[string]string) {
+	svc.helmSQLConnections = connections
+}
+
 // GetNamespaces returns all namespaces in the cluster
 func (svc *ResourceService) GetNamespaces() []string {
 	return svc.informer.GetNamespaces()
@@ -138,16 +162,107 @@ func (svc *ResourceService) GetAllResourceTypes() ([]*TrackedType, error) {
 	return svc.discovery.DiscoverAllResources()
 }
 
-// PrepareEditFile prepares a resource for editing
-func (svc *ResourceService) PrepareEditFile(resource TrackedObject) (*EditResult, error) {
-	return svc.client.PrepareEditFile(resource)
+// PrepareEditFile prepares a resource for editing, with server-populated fields hidden unless full is set
+func (svc *ResourceService) PrepareEditFile(resource TrackedObject, full bool) (*EditResult, error) {
+	return svc.client.PrepareEditFile(resource, full)
 }
 
-// ProcessEditedFile processes an edited resource file
-func (svc *ResourceService) ProcessEditedFile(ctx context.Context, resource TrackedObject, editResult *EditResult) error {
+// ProcessEditedFile previews an edited resource file with a server-side dry run
+func (svc *ResourceService) ProcessEditedFile(ctx context.Context, resource TrackedObject, editResult *EditResult) (*EditPreview, error) {
 	return svc.client.ProcessEditedFile(ctx, resource, editResult)
 }
 
+// GetEventsForObject returns the events about a resource, oldest first
+func (svc *ResourceService) GetEventsForObject(resource TrackedObject) []EventInfo {
+	raw := resource.GetRaw()
+	if raw == nil || raw.GetUID() == "" || svc.informer == nil {
+		return nil
+	}
+
+	cached := svc.informer.GetEventsByInvolvedUID(string(raw.GetUID()))
+	events := make([]EventInfo, 0, len(cached))
+	for _, event := range cached {
+		events = append(events, GetEventInfo(event))
+	}
+	sortEventsByLastSeen(events)
+	return events
+}
+
+// GetOwnedPods returns the pods owned by the given resource, following ownership
+// through intermediate resources (e.g. Deployment -> ReplicaSet -> Pod)
+// A pod passed as the owner is returned as-is
+func (svc *ResourceService) GetOwnedPods(owner TrackedObject) []TrackedObject {
+	if owner.GetKind() == "Pod" {
+		return []TrackedObject{owner}
+	}
+
+	raw := owner.GetRaw()
+	if raw == nil {
+		return nil
+	}
+
+	var pods []TrackedObject
+	visited := make(map[string]bool)
+
+	var walk func(uid string, depth int)
+	walk = func(uid string, depth int) {
+		if depth >= maxOwnedPodDepth || visited[uid] {
+			return
+		}
+		visited[uid] = true
+
+		for _, owned := range svc.informer.GetResourcesByOwnerUID(uid) {
+			if owned.GetKind() == "Pod" {
+				pods = append(pods, owned)
+				continue
+			}
+			if ownedRaw := owned.GetRaw(); ownedRaw != nil {
+				walk(string(ownedRaw.GetUID()), depth+1)
+			}
+		}
+	}
+	walk(string(raw.GetUID()), 0)
+
+	return pods
+}
+
+// NewLogStreamer creates a log streamer for the current context
+// The streamer is stopped when the service context is cancelled
+func (svc *ResourceService) NewLogStreamer() *LogStreamer {
+	return NewLogStreamer(svc.ctx, svc.client, svc.logger)
+}
+
+// ExecShell starts an interactive shell in a pod container
+func (svc *ResourceService) ExecShell(ctx context.Context, shells []string, opts ExecOptions) error {
+	return svc.client.ExecShell(ctx, shells, opts)
+}
+
+// StartPortForward starts a background port-forward to a Pod or Service in the current context
+// The context only bounds startup; the forward runs until it is stopped or the context is switched
+func (svc *ResourceService) StartPortForward(ctx context.Context, req PortForwardRequest) (PortForward, error) {
+	return svc.portForwards.Start(ctx, svc.client, req)
+}
+
+// RestartPortForward restarts a port-forward, re-resolving its target pod
+func (svc *ResourceService) RestartPortForward(ctx context.Context, id int) (PortForward, error) {
+	return svc.portForwards.Restart(ctx, id)
+}
+
+// StopPortForward stops a port-forward, keeping it in the list so it can be restarted
+func (svc *ResourceService) StopPortForward(id int) error {
+	return svc.portForwards.Stop(id)
+}
+
+// RemovePortForward stops a port-forward and removes it from the list
+func (svc *ResourceService) RemovePortForward(id int) error {
+	return svc.portForwards.Remove(id)
+}
+
+// ListPortForwards returns all port-forwards, including stopped and failed ones
+func (svc *ResourceService) ListPortForwards() []PortForward {
+	return svc.portForwards.List()
+}
+
 // GetAllNamespaces queries the Kubernetes API for all namespace names
 func (svc *ResourceService) GetAllNamespaces(ctx context.Context) ([]string, error) {
 	namespaceList, err := svc.client.Clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
@@ -177,6 +292,9 @@ func (svc *ResourceService) GetCurrentContext() string {
 func (svc *ResourceService) SwitchContext(contextName string) error {
 	svc.logger.Info("Switching context", "context", contextName)
 
+	// Forwards target pods in the old cluster
+	svc.portForwards.StopAll()
+
 	// Stop old informer
 	svc.mu.Lock()
 	if svc.informer != nil {
@@ -186,7 +304,7 @@ func (svc *ResourceService) SwitchContext(contextName string) error {
 	svc.mu.Unlock()
 
 	// Create new client for the context
-	newClient, err := NewClientWithContext(svc.logger, contextName)
+	newClient, err := NewClientWithContext(svc.logger, contextName, svc.client.Protection)
 	if err != nil {
 		svc.logger.Error("Failed to create client for new context", "context", contextName, "error", err)
 		svc.onUpdate(ServiceUpdate{
@@ -243,6 +361,8 @@ func (svc *ResourceService) GetLastUpdateTime(gvr schema.GroupVersionResource) t
 
 // Close cleans up the service
 func (svc *ResourceService) Close() {
+	svc.portForwards.StopAll()
+
 	svc.mu.Lock()
 	defer svc.mu.Unlock()
 
@@ -279,6 +399,7 @@ func (svc *ResourceService) initializeInformer() error {
 	}
 
 	svc.logger.Debug("Initializing informer manager")
+	informer.SetHelmSQLConnection(svc.helmSQLConnections[svc.client.Context])
 
 	svc.mu.Lock()
 	svc.informer = informer
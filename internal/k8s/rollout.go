@@ -0,0 +1,242 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// deploymentRevisionAnnotation numbers a Deployment's ReplicaSets, and the Deployment's current one
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+	// podTemplateHashLabel is added to pod templates by the Deployment controller and must not be rolled back
+	podTemplateHashLabel = "pod-template-hash"
+)
+
+var (
+	replicaSetGVR         = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}
+	controllerRevisionGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "controllerrevisions"}
+)
+
+// RolloutStatus is the progress of a workload towards its latest spec
+type RolloutStatus struct {
+	Message string
+	Done    bool
+	Failed  bool
+}
+
+// GetRolloutStatus reports how far a workload's rollout has got, following kubectl rollout status
+func GetRolloutStatus(obj *unstructured.Unstructured) RolloutStatus {
+	if !generationObserved(obj) {
+		return RolloutStatus{Message: "waiting for the controller to observe the change"}
+	}
+
+	desired := desiredReplicas(obj)
+	ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+	updated, _, _ := unstructured.NestedInt64(obj.Object, "status", "updatedReplicas")
+	available, _, _ := unstructured.NestedInt64(obj.Object, "status", "availableReplicas")
+
+	switch obj.GetKind() {
+	case "Deployment":
+		if cond, found := findCondition(obj, "Progressing"); found && cond.reason == "ProgressDeadlineExceeded" {
+			return RolloutStatus{Message: "rollout exceeded its progress deadline", Failed: true}
+		}
+		if paused, _, _ := unstructured.NestedBool(obj.Object, "spec", "paused"); paused {
+			return RolloutStatus{Message: "rollout is paused"}
+		}
+		total, _, _ := unstructured.NestedInt64(obj.Object, "status", "replicas")
+		switch {
+		case updated < desired:
+			return RolloutStatus{Message: fmt.Sprintf("%d of %d new replicas updated", updated, desired)}
+		case total > updated:
+			return RolloutStatus{Message: fmt.Sprintf("%d old replicas pending termination", total-updated)}
+		case available < updated:
+			return RolloutStatus{Message: fmt.Sprintf("%d of %d updated replicas available", available, updated)}
+		}
+
+	case "StatefulSet":
+		strategy, _, _ := unstructured.NestedString(obj.Object, "spec", "updateStrategy", "type")
+		if strategy == "OnDelete" {
+			return RolloutStatus{Message: "OnDelete update strategy; pods update as they are deleted", Done: true}
+		}
+		if ready < desired {
+			return RolloutStatus{Message: fmt.Sprintf("%d of %d pods ready", ready, desired)}
+		}
+		partition, _, _ := unstructured.NestedInt64(obj.Object, "spec", "updateStrategy", "rollingUpdate", "partition")
+		if partition > 0 {
+			if updated < desired-partition {
+				return RolloutStatus{Message: fmt.Sprintf("%d of %d partitioned pods updated", updated, desired-partition)}
+			}
+			return RolloutStatus{Message: fmt.Sprintf("partitioned rollout complete: %d new pods updated", updated), Done: true}
+		}
+		currentRevision, _, _ := unstructured.NestedString(obj.Object, "status", "currentRevision")
+		updateRevision, _, _ := unstructured.NestedString(obj.Object, "status", "updateRevision")
+		if currentRevision != updateRevision {
+			return RolloutStatus{Message: fmt.Sprintf("%d of %d pods updated to %s", updated, desired, updateRevision)}
+		}
+
+	case "DaemonSet":
+		strategy, _, _ := unstructured.NestedString(obj.Object, "spec", "updateStrategy", "type")
+		if strategy == "OnDelete" {
+			return RolloutStatus{Message: "OnDelete update strategy; pods update as they are deleted", Done: true}
+		}
+		scheduled, _, _ := unstructured.NestedInt64(obj.Object, "status", "desiredNumberScheduled")
+		updated, _, _ = unstructured.NestedInt64(obj.Object, "status", "updatedNumberScheduled")
+		available, _, _ = unstructured.NestedInt64(obj.Object, "status", "numberAvailable")
+		switch {
+		case updated < scheduled:
+			return RolloutStatus{Message: fmt.Sprintf("%d of %d updated pods scheduled", updated, scheduled)}
+		case available < scheduled:
+			return RolloutStatus{Message: fmt.Sprintf("%d of %d updated pods available", available, scheduled)}
+		}
+
+	default:
+		// Plain ReplicaSets have no rollout, only a replica count to reach
+		if ready != desired {
+			return RolloutStatus{Message: fmt.Sprintf("%d of %d replicas ready", ready, desired)}
+		}
+	}
+
+	return RolloutStatus{Message: "successfully rolled out", Done: true}
+}
+
+// WorkloadRevision is a past version of a workload's pod template that it can be rolled back to
+type WorkloadRevision struct {
+	Revision int64
+	Name     string // Name of the ReplicaSet or ControllerRevision holding the revision
+	Created  time.Time
+	Images   string // Comma-separated container images of the revision's pod template
+	Current  bool
+	source   *unstructured.Unstructured
+}
+
+// ListWorkloadRevisions returns the revisions of a Deployment, StatefulSet or DaemonSet, newest first
+// Deployments keep their revisions as ReplicaSets, the others as ControllerRevisions
+func (c *Client) ListWorkloadRevisions(ctx context.Context, resource TrackedObject) ([]WorkloadRevision, error) {
+	raw := resource.GetRaw()
+	if raw == nil || !IsRestartable(resource) {
+		return nil, fmt.Errorf("%s has no revisions; only Deployments, StatefulSets and DaemonSets do", resource.GetKind())
+	}
+
+	gvr := controllerRevisionGVR
+	if resource.GetKind() == "Deployment" {
+		gvr = replicaSetGVR
+	}
+	resourceInterface, err := c.dynamicResource(gvr, resource.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	list, err := resourceInterface.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, describeAPIError("list revisions of", err)
+	}
+
+	var revisions []WorkloadRevision
+	for i := range list.Items {
+		item := &list.Items[i]
+		if !isControlledBy(item, raw) {
+			continue
+		}
+
+		revision := WorkloadRevision{Name: item.GetName(), Created: item.GetCreationTimestamp().Time, source: item}
+		if gvr == replicaSetGVR {
+			revision.Revision, _ = strconv.ParseInt(item.GetAnnotations()[deploymentRevisionAnnotation], 10, 64)
+			revision.Images = templateImages(item)
+		} else {
+			// The revision's data holds the workload's spec.template as a patch
+			revision.Revision, _, _ = unstructured.NestedInt64(item.Object, "revision")
+			data, _, _ := unstructured.NestedMap(item.Object, "data")
+			revision.Images = templateImages(&unstructured.Unstructured{Object: data})
+		}
+		revisions = append(revisions, revision)
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision > revisions[j].Revision
+	})
+
+	// A Deployment records its current revision; for the others it is the newest, or the StatefulSet's update revision
+	updateRevision, _, _ := unstructured.NestedString(raw.Object, "status", "updateRevision")
+	current := raw.GetAnnotations()[deploymentRevisionAnnotation]
+	for i := range revisions {
+		switch {
+		case resource.GetKind() == "Deployment":
+			revisions[i].Current = strconv.FormatInt(revisions[i].Revision, 10) == current
+		case updateRevision != "":
+			revisions[i].Current = revisions[i].Name == updateRevision
+		default:
+			revisions[i].Current = i == 0
+		}
+	}
+
+	return revisions, nil
+}
+
+// isControlledBy reports whether obj's controller owner reference points at owner
+func isControlledBy(obj, owner *unstructured.Unstructured) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() && ref.Controller != nil && *ref.Controller {
+			return true
+		}
+	}
+	return false
+}
+
+// RollbackWorkload returns a workload's pod template to that of a previous revision, as kubectl rollout undo does
+func (c *Client) RollbackWorkload(ctx context.Context, resource TrackedObject, revision WorkloadRevision) error {
+	raw := resource.GetRaw()
+	if raw == nil || revision.source == nil {
+		return fmt.Errorf("%s can't be rolled back", describeKind(resource))
+	}
+
+	c.Logger.Info("Rolling back workload",
+		"kind", resource.GetKind(),
+		"name", resource.GetName(),
+		"namespace", resource.GetNamespace(),
+		"revision", revision.Revision)
+
+	if resource.GetKind() != "Deployment" {
+		// A ControllerRevision's data is a strategic merge patch that restores its pod template
+		data, found, _ := unstructured.NestedMap(revision.source.Object, "data")
+		if !found {
+			return fmt.Errorf("revision %d has no data to roll back to", revision.Revision)
+		}
+		patch, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("failed to encode patch: %w", err)
+		}
+		return c.applyPatch(ctx, resource, "roll back", types.StrategicMergePatchType, patch)
+	}
+
+	if paused, _, _ := unstructured.NestedBool(raw.Object, "spec", "paused"); paused {
+		return fmt.Errorf("the rollout of %s is paused; resume it before rolling back", describeKind(resource))
+	}
+
+	template, found, _ := unstructured.NestedMap(revision.source.Object, "spec", "template")
+	if !found {
+		return fmt.Errorf("ReplicaSet %s has no pod template", revision.Name)
+	}
+	unstructured.RemoveNestedField(template, "metadata", "labels", podTemplateHashLabel)
+
+	// Replace the whole template so fields added since that revision are dropped rather than merged
+	patch, err := json.Marshal([]map[string]interface{}{
+		{"op": "replace", "path": "/spec/template", "value": template},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode patch: %w", err)
+	}
+	return c.applyPatch(ctx, resource, "roll back", types.JSONPatchType, patch)
+}
+
+// describeKind names a resource for errors, e.g. "Deployment web"
+func describeKind(resource TrackedObject) string {
+	return resource.GetKind() + " " + resource.GetName()
+}
@@ -0,0 +1,522 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Severity classifies how healthy a resource's status is
+type Severity int
+
+const (
+	SeverityUnknown Severity = iota
+	SeverityOK
+	SeverityProgressing
+	SeverityWarning
+	SeverityError
+)
+
+// String returns the name of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityOK:
+		return "OK"
+	case SeverityProgressing:
+		return "Progressing"
+	case SeverityWarning:
+		return "Warning"
+	case SeverityError:
+		return "Error"
+	default:
+		return "Unknown"
+	}
+}
+
+// EvaluatedStatus is the status shown for a resource along with its severity
+type EvaluatedStatus struct {
+	Text     string
+	Severity Severity
+}
+
+// StatusEvaluator computes the status of a resource of a specific kind
+type StatusEvaluator func(obj *unstructured.Unstructured) EvaluatedStatus
+
+var (
+	statusEvaluatorsMu sync.RWMutex
+	statusEvaluators   = map[schema.GroupVersionKind]StatusEvaluator{
+		{Version: "v1", Kind: "Pod"}:                        evaluatePodStatus,
+		{Version: "v1", Kind: "Service"}:                    evaluateServiceStatus,
+		{Version: "v1", Kind: "Node"}:                       evaluateNodeStatus,
+		{Version: "v1", Kind: "PersistentVolumeClaim"}:      evaluatePVCStatus,
+		{Version: "v1", Kind: "Event"}:                      evaluateEventStatus,
+		{Group: "apps", Version: "v1", Kind: "Deployment"}:  evaluateDeploymentStatus,
+		{Group: "apps", Version: "v1", Kind: "StatefulSet"}: evaluateStatefulSetStatus,
+		{Group: "apps", Version: "v1", Kind: "DaemonSet"}:   evaluateDaemonSetStatus,
+		{Group: "batch", Version: "v1", Kind: "Job"}:        evaluateJobStatus,
+	}
+)
+
+// RegisterStatusEvaluator sets the evaluator used for resources of the given GVK, replacing any existing one
+func RegisterStatusEvaluator(gvk schema.GroupVersionKind, evaluator StatusEvaluator) {
+	statusEvaluatorsMu.Lock()
+	defer statusEvaluatorsMu.Unlock()
+	statusEvaluators[gvk] = evaluator
+}
+
+// EvaluateStatus computes the status of a resource using the evaluator registered for its GVK
+// Kinds without an evaluator fall back to status.phase or the well-known Ready/Available conditions
+func EvaluateStatus(obj *unstructured.Unstructured) EvaluatedStatus {
+	statusEvaluatorsMu.RLock()
+	evaluator, ok := statusEvaluators[obj.GroupVersionKind()]
+	statusEvaluatorsMu.RUnlock()
+
+	if ok {
+		return evaluator(obj)
+	}
+	return evaluateGenericStatus(obj)
+}
+
+// SeverityForStatus classifies a plain status string, for objects that don't go through an evaluator
+// such as Helm releases and ArgoCD applications
+func SeverityForStatus(status string) Severity {
+	switch strings.ToLower(status) {
+	case "running", "active", "available", "ready", "bound", "succeeded", "completed", "complete",
+		"deployed", "healthy", "synced", "established", "normal":
+		return SeverityOK
+	case "pending", "progressing", "terminating", "containercreating", "pending-install",
+		"pending-upgrade", "pending-rollback", "uninstalling":
+		return SeverityProgressing
+	case "warning", "missing", "outofsync", "unschedulable", "suspended":
+		return SeverityWarning
+	case "failed", "error", "crashloopbackoff", "imagepullbackoff", "errimagepull", "lost",
+		"degraded", "notready", "oomkilled":
+		return SeverityError
+	default:
+		return SeverityUnknown
+	}
+}
+
+// evaluateGenericStatus derives a status from status.phase or the Ready/Available conditions
+func evaluateGenericStatus(obj *unstructured.Unstructured) EvaluatedStatus {
+	if obj.GetDeletionTimestamp() != nil {
+		return EvaluatedStatus{"Terminating", SeverityProgressing}
+	}
+
+	if phase, found, _ := unstructured.NestedString(obj.Object, "status", "phase"); found && phase != "" {
+		return EvaluatedStatus{phase, SeverityForStatus(phase)}
+	}
+
+	for _, condType := range []string{"Ready", "Available"} {
+		if cond, found := findCondition(obj, condType); found {
+			if cond.status == "True" {
+				return EvaluatedStatus{condType, SeverityOK}
+			}
+			if cond.reason != "" {
+				return EvaluatedStatus{cond.reason, SeverityWarning}
+			}
+			return EvaluatedStatus{"Not" + condType, SeverityWarning}
+		}
+	}
+
+	// Otherwise report the most recent condition by its type rather than its bare True/False
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if len(conditions) > 0 {
+		if last, ok := conditions[len(conditions)-1].(map[string]interface{}); ok {
+			condType, _ := last["type"].(string)
+			condStatus, _ := last["status"].(string)
+			if condType != "" {
+				switch condStatus {
+				case "True":
+					return EvaluatedStatus{condType, SeverityOK}
+				case "False":
+					return EvaluatedStatus{"Not" + condType, SeverityWarning}
+				}
+			}
+		}
+	}
+
+	return EvaluatedStatus{"Unknown", SeverityUnknown}
+}
+
+// condition holds the fields of a status condition used by the evaluators
+type condition struct {
+	status string
+	reason string
+}
+
+// findCondition returns the status condition of the given type
+func findCondition(obj *unstructured.Unstructured, condType string) (condition, bool) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["type"] != condType {
+			continue
+		}
+		status, _ := cond["status"].(string)
+		reason, _ := cond["reason"].(string)
+		return condition{status: status, reason: reason}, true
+	}
+	return condition{}, false
+}
+
+// conditionIsTrue reports whether the condition of the given type is present and True
+func conditionIsTrue(obj *unstructured.Unstructured, condType string) bool {
+	cond, found := findCondition(obj, condType)
+	return found && cond.status == "True"
+}
+
+// podWaitingReasonsInProgress are container waiting reasons that are part of a normal start
+var podWaitingReasonsInProgress = map[string]bool{
+	"ContainerCreating": true,
+	"PodInitializing":   true,
+}
+
+// evaluatePodStatus follows the STATUS column of kubectl get pods: container problems such as
+// CrashLoopBackOff take precedence over the pod phase
+func evaluatePodStatus(obj *unstructured.Unstructured) EvaluatedStatus {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	reason, _, _ := unstructured.NestedString(obj.Object, "status", "reason")
+
+	if obj.GetDeletionTimestamp() != nil {
+		return EvaluatedStatus{"Terminating", SeverityProgressing}
+	}
+
+	switch phase {
+	case "Succeeded":
+		return EvaluatedStatus{"Completed", SeverityOK}
+	case "Failed":
+		// Evicted and similar pod-level failures carry a reason
+		if reason != "" {
+			return EvaluatedStatus{reason, SeverityError}
+		}
+		return EvaluatedStatus{"Failed", SeverityError}
+	}
+
+	initStatuses, _, _ := unstructured.NestedSlice(obj.Object, "status", "initContainerStatuses")
+	for i, s := range initStatuses {
+		state := parseContainerState(s)
+		switch {
+		case state.terminated && state.exitCode == 0:
+			continue
+		case state.running && state.ready:
+			// Sidecar init containers keep running once started
+			continue
+		case state.waitingReason != "" && !podWaitingReasonsInProgress[state.waitingReason]:
+			return EvaluatedStatus{"Init:" + state.waitingReason, SeverityError}
+		case state.terminated:
+			return EvaluatedStatus{"Init:Error", SeverityError}
+		default:
+			return EvaluatedStatus{fmt.Sprintf("Init:%d/%d", i, len(initStatuses)), SeverityProgressing}
+		}
+	}
+
+	statuses, _, _ := unstructured.NestedSlice(obj.Object, "status", "containerStatuses")
+	allReady := len(statuses) > 0
+	creating := false
+	terminatedReason := ""
+	for _, s := range statuses {
+		state := parseContainerState(s)
+		if !state.ready {
+			allReady = false
+		}
+		switch {
+		case podWaitingReasonsInProgress[state.waitingReason]:
+			creating = true
+		case state.waitingReason != "":
+			return EvaluatedStatus{state.waitingReason, SeverityError}
+		case state.terminated && state.exitCode != 0 && terminatedReason == "":
+			terminatedReason = state.terminatedReason
+			if terminatedReason == "" {
+				terminatedReason = "Error"
+			}
+		}
+	}
+	if terminatedReason != "" {
+		return EvaluatedStatus{terminatedReason, SeverityError}
+	}
+
+	switch phase {
+	case "Pending":
+		if cond, found := findCondition(obj, "PodScheduled"); found && cond.status == "False" && cond.reason != "" {
+			return EvaluatedStatus{cond.reason, SeverityWarning}
+		}
+		if creating {
+			return EvaluatedStatus{"ContainerCreating", SeverityProgressing}
+		}
+		return EvaluatedStatus{"Pending", SeverityProgressing}
+	case "Running":
+		if !allReady {
+			return EvaluatedStatus{"Running", SeverityProgressing}
+		}
+		return EvaluatedStatus{"Running", SeverityOK}
+	case "":
+		return EvaluatedStatus{"Unknown", SeverityUnknown}
+	default:
+		return EvaluatedStatus{phase, SeverityForStatus(phase)}
+	}
+}
+
+// containerState is the part of a container status the pod evaluator looks at
+type containerState struct {
+	ready            bool
+	running          bool
+	terminated       bool
+	exitCode         int64
+	terminatedReason string
+	waitingReason    string
+}
+
+// parseContainerState extracts the current state of an entry of status.containerStatuses
+func parseContainerState(s interface{}) containerState {
+	status, ok := s.(map[string]interface{})
+	if !ok {
+		return containerState{}
+	}
+
+	var state containerState
+	state.ready, _, _ = unstructured.NestedBool(status, "ready")
+	if _, found, _ := unstructured.NestedMap(status, "state", "running"); found {
+		state.running = true
+	}
+	if terminated, found, _ := unstructured.NestedMap(status, "state", "terminated"); found {
+		state.terminated = true
+		state.exitCode, _, _ = unstructured.NestedInt64(terminated, "exitCode")
+		state.terminatedReason, _, _ = unstructured.NestedString(terminated, "reason")
+	}
+	state.waitingReason, _, _ = unstructured.NestedString(status, "state", "waiting", "reason")
+	return state
+}
+
+// desiredReplicas returns spec.replicas, which defaults to 1 when unset
+func desiredReplicas(obj *unstructured.Unstructured) int64 {
+	replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if !found {
+		return 1
+	}
+	return replicas
+}
+
+// workloadCounts is the replica accounting shared by the workload evaluators
+type workloadCounts struct {
+	desired   int64
+	ready     int64
+	updated   int64
+	available int64
+	// rollingOut is set when the controller hasn't caught up with the spec or old replicas remain
+	rollingOut bool
+}
+
+// evaluateWorkloadCounts turns replica counts into a "Ready 2/3" style status
+func evaluateWorkloadCounts(counts workloadCounts) EvaluatedStatus {
+	ratio := fmt.Sprintf("%d/%d", counts.ready, counts.desired)
+
+	if counts.desired == 0 && counts.ready == 0 {
+		return EvaluatedStatus{"Scaled to 0", SeverityUnknown}
+	}
+	if counts.rollingOut || counts.updated < counts.desired ||
+		counts.ready < counts.desired || counts.available < counts.desired {
+		return EvaluatedStatus{"Progressing " + ratio, SeverityProgressing}
+	}
+	return EvaluatedStatus{"Ready " + ratio, SeverityOK}
+}
+
+// generationObserved reports whether the controller has processed the latest spec
+func generationObserved(obj *unstructured.Unstructured) bool {
+	observed, found, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	return !found || observed >= obj.GetGeneration()
+}
+
+// evaluateDeploymentStatus reports ready/desired replicas, flagging stalled and paused rollouts
+func evaluateDeploymentStatus(obj *unstructured.Unstructured) EvaluatedStatus {
+	if obj.GetDeletionTimestamp() != nil {
+		return EvaluatedStatus{"Terminating", SeverityProgressing}
+	}
+
+	counts := workloadCounts{desired: desiredReplicas(obj)}
+	counts.ready, _, _ = unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+	counts.updated, _, _ = unstructured.NestedInt64(obj.Object, "status", "updatedReplicas")
+	counts.available, _, _ = unstructured.NestedInt64(obj.Object, "status", "availableReplicas")
+	total, _, _ := unstructured.NestedInt64(obj.Object, "status", "replicas")
+	counts.rollingOut = !generationObserved(obj) || total > counts.updated
+	ratio := fmt.Sprintf("%d/%d", counts.ready, counts.desired)
+
+	if cond, found := findCondition(obj, "Progressing"); found && cond.reason == "ProgressDeadlineExceeded" {
+		return EvaluatedStatus{"Stalled " + ratio, SeverityError}
+	}
+	if conditionIsTrue(obj, "ReplicaFailure") {
+		return EvaluatedStatus{"ReplicaFailure", SeverityError}
+	}
+	if paused, _, _ := unstructured.NestedBool(obj.Object, "spec", "paused"); paused {
+		return EvaluatedStatus{"Paused " + ratio, SeverityWarning}
+	}
+
+	return evaluateWorkloadCounts(counts)
+}
+
+// evaluateStatefulSetStatus reports ready/desired replicas and whether a revision rollout is underway
+func evaluateStatefulSetStatus(obj *unstructured.Unstructured) EvaluatedStatus {
+	if obj.GetDeletionTimestamp() != nil {
+		return EvaluatedStatus{"Terminating", SeverityProgressing}
+	}
+
+	counts := workloadCounts{desired: desiredReplicas(obj)}
+	counts.ready, _, _ = unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+	counts.updated, _, _ = unstructured.NestedInt64(obj.Object, "status", "updatedReplicas")
+	counts.available, _, _ = unstructured.NestedInt64(obj.Object, "status", "availableReplicas")
+
+	// A partitioned rolling update intentionally leaves the ordinals below the partition on the old revision
+	partition, _, _ := unstructured.NestedInt64(obj.Object, "spec", "updateStrategy", "rollingUpdate", "partition")
+	counts.updated += min(partition, counts.desired)
+
+	currentRevision, _, _ := unstructured.NestedString(obj.Object, "status", "currentRevision")
+	updateRevision, _, _ := unstructured.NestedString(obj.Object, "status", "updateRevision")
+	counts.rollingOut = !generationObserved(obj) ||
+		(partition == 0 && updateRevision != "" && currentRevision != updateRevision)
+
+	return evaluateWorkloadCounts(counts)
+}
+
+// evaluateDaemonSetStatus reports ready/desired pods, flagging pods running on nodes they shouldn't
+func evaluateDaemonSetStatus(obj *unstructured.Unstructured) EvaluatedStatus {
+	if obj.GetDeletionTimestamp() != nil {
+		return EvaluatedStatus{"Terminating", SeverityProgressing}
+	}
+
+	var counts workloadCounts
+	counts.desired, _, _ = unstructured.NestedInt64(obj.Object, "status", "desiredNumberScheduled")
+	counts.ready, _, _ = unstructured.NestedInt64(obj.Object, "status", "numberReady")
+	counts.updated, _, _ = unstructured.NestedInt64(obj.Object, "status", "updatedNumberScheduled")
+	counts.available, _, _ = unstructured.NestedInt64(obj.Object, "status", "numberAvailable")
+	counts.rollingOut = !generationObserved(obj)
+
+	if misscheduled, _, _ := unstructured.NestedInt64(obj.Object, "status", "numberMisscheduled"); misscheduled > 0 {
+		return EvaluatedStatus{fmt.Sprintf("Misscheduled %d", misscheduled), SeverityWarning}
+	}
+
+	return evaluateWorkloadCounts(counts)
+}
+
+// evaluateJobStatus reports whether a Job completed, failed or is still running
+func evaluateJobStatus(obj *unstructured.Unstructured) EvaluatedStatus {
+	if obj.GetDeletionTimestamp() != nil {
+		return EvaluatedStatus{"Terminating", SeverityProgressing}
+	}
+
+	succeeded, _, _ := unstructured.NestedInt64(obj.Object, "status", "succeeded")
+	active, _, _ := unstructured.NestedInt64(obj.Object, "status", "active")
+	completions, found, _ := unstructured.NestedInt64(obj.Object, "spec", "completions")
+	if !found {
+		completions = 1
+	}
+
+	switch {
+	case conditionIsTrue(obj, "Failed"), conditionIsTrue(obj, "FailureTarget"):
+		return EvaluatedStatus{"Failed", SeverityError}
+	case conditionIsTrue(obj, "Complete"), conditionIsTrue(obj, "SuccessCriteriaMet"):
+		return EvaluatedStatus{"Complete", SeverityOK}
+	case conditionIsTrue(obj, "Suspended"):
+		return EvaluatedStatus{"Suspended", SeverityUnknown}
+	case active > 0:
+		return EvaluatedStatus{fmt.Sprintf("Running %d/%d", succeeded, completions), SeverityProgressing}
+	default:
+		return EvaluatedStatus{"Pending", SeverityProgressing}
+	}
+}
+
+// evaluatePVCStatus reports the claim's phase, noting in-progress volume expansion
+func evaluatePVCStatus(obj *unstructured.Unstructured) EvaluatedStatus {
+	if obj.GetDeletionTimestamp() != nil {
+		return EvaluatedStatus{"Terminating", SeverityProgressing}
+	}
+
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	switch phase {
+	case "Bound":
+		if conditionIsTrue(obj, "FileSystemResizePending") {
+			return EvaluatedStatus{"ResizePending", SeverityWarning}
+		}
+		if conditionIsTrue(obj, "Resizing") {
+			return EvaluatedStatus{"Resizing", SeverityProgressing}
+		}
+		return EvaluatedStatus{"Bound", SeverityOK}
+	case "Pending":
+		return EvaluatedStatus{"Pending", SeverityProgressing}
+	case "Lost":
+		return EvaluatedStatus{"Lost", SeverityError}
+	case "":
+		return EvaluatedStatus{"Unknown", SeverityUnknown}
+	default:
+		return EvaluatedStatus{phase, SeverityForStatus(phase)}
+	}
+}
+
+// nodePressureConditions are node conditions that signal trouble when True
+var nodePressureConditions = []string{"MemoryPressure", "DiskPressure", "PIDPressure", "NetworkUnavailable"}
+
+// evaluateNodeStatus reports readiness, cordoning and resource pressure, e.g. "Ready,Cordoned"
+func evaluateNodeStatus(obj *unstructured.Unstructured) EvaluatedStatus {
+	parts := []string{}
+	severity := SeverityOK
+
+	ready, found := findCondition(obj, "Ready")
+	switch {
+	case !found:
+		parts = append(parts, "Unknown")
+		severity = SeverityUnknown
+	case ready.status == "True":
+		parts = append(parts, "Ready")
+	default:
+		parts = append(parts, "NotReady")
+		severity = SeverityError
+	}
+
+	if unschedulable, _, _ := unstructured.NestedBool(obj.Object, "spec", "unschedulable"); unschedulable {
+		parts = append(parts, "Cordoned")
+		severity = max(severity, SeverityWarning)
+	}
+
+	for _, condType := range nodePressureConditions {
+		if conditionIsTrue(obj, condType) {
+			parts = append(parts, condType)
+			severity = max(severity, SeverityWarning)
+		}
+	}
+
+	return EvaluatedStatus{strings.Join(parts, ","), severity}
+}
+
+// evaluateServiceStatus reports the service type, and whether a load balancer is still being provisioned
+func evaluateServiceStatus(obj *unstructured.Unstructured) EvaluatedStatus {
+	if obj.GetDeletionTimestamp() != nil {
+		return EvaluatedStatus{"Terminating", SeverityProgressing}
+	}
+
+	serviceType, _, _ := unstructured.NestedString(obj.Object, "spec", "type")
+	clusterIP, _, _ := unstructured.NestedString(obj.Object, "spec", "clusterIP")
+
+	switch serviceType {
+	case "LoadBalancer":
+		ingress, _, _ := unstructured.NestedSlice(obj.Object, "status", "loadBalancer", "ingress")
+		if len(ingress) == 0 {
+			return EvaluatedStatus{"Pending", SeverityProgressing}
+		}
+		return EvaluatedStatus{"LoadBalancer", SeverityOK}
+	case "ExternalName", "NodePort":
+		return EvaluatedStatus{serviceType, SeverityOK}
+	default:
+		if clusterIP == "None" {
+			return EvaluatedStatus{"Headless", SeverityOK}
+		}
+		return EvaluatedStatus{"ClusterIP", SeverityOK}
+	}
+}
+
+// evaluateEventStatus uses the event type (Normal/Warning), since events have no status
+func evaluateEventStatus(obj *unstructured.Unstructured) EvaluatedStatus {
+	eventType, _, _ := unstructured.NestedString(obj.Object, "type")
+	return EvaluatedStatus{eventType, SeverityForStatus(eventType)}
+}
@@ -0,0 +1,205 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+)
+
+// resourceTemplates are starting points for new objects of common built-in kinds, keyed by group/kind
+// NAMESPACE is replaced with the namespace the object is created in
+var resourceTemplates = map[string]string{
+	"/Namespace": `apiVersion: v1
+kind: Namespace
+metadata:
+  name: example
+`,
+	"/ConfigMap": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: example
+  namespace: NAMESPACE
+data:
+  key: value
+`,
+	"/Secret": `apiVersion: v1
+kind: Secret
+metadata:
+  name: example
+  namespace: NAMESPACE
+type: Opaque
+stringData:
+  key: value
+`,
+	"/ServiceAccount": `apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: example
+  namespace: NAMESPACE
+`,
+	"/Pod": `apiVersion: v1
+kind: Pod
+metadata:
+  name: example
+  namespace: NAMESPACE
+  labels:
+    app: example
+spec:
+  containers:
+    - name: main
+      image: nginx:latest
+      ports:
+        - containerPort: 80
+`,
+	"/Service": `apiVersion: v1
+kind: Service
+metadata:
+  name: example
+  namespace: NAMESPACE
+spec:
+  selector:
+    app: example
+  ports:
+    - port: 80
+      targetPort: 80
+`,
+	"/PersistentVolumeClaim": `apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: example
+  namespace: NAMESPACE
+spec:
+  accessModes:
+    - ReadWriteOnce
+  resources:
+    requests:
+      storage: 1Gi
+`,
+	"apps/Deployment": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: example
+  namespace: NAMESPACE
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: example
+  template:
+    metadata:
+      labels:
+        app: example
+    spec:
+      containers:
+        - name: main
+          image: nginx:latest
+          ports:
+            - containerPort: 80
+`,
+	"apps/StatefulSet": `apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: example
+  namespace: NAMESPACE
+spec:
+  serviceName: example
+  replicas: 1
+  selector:
+    matchLabels:
+      app: example
+  template:
+    metadata:
+      labels:
+        app: example
+    spec:
+      containers:
+        - name: main
+          image: nginx:latest
+`,
+	"apps/DaemonSet": `apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: example
+  namespace: NAMESPACE
+spec:
+  selector:
+    matchLabels:
+      app: example
+  template:
+    metadata:
+      labels:
+        app: example
+    spec:
+      containers:
+        - name: main
+          image: nginx:latest
+`,
+	"batch/Job": `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: example
+  namespace: NAMESPACE
+spec:
+  backoffLimit: 2
+  template:
+    spec:
+      restartPolicy: Never
+      containers:
+        - name: main
+          image: busybox:latest
+          command: ["sh", "-c", "echo hello"]
+`,
+	"batch/CronJob": `apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: example
+  namespace: NAMESPACE
+spec:
+  schedule: "*/5 * * * *"
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          restartPolicy: OnFailure
+          containers:
+            - name: main
+              image: busybox:latest
+              command: ["sh", "-c", "echo hello"]
+`,
+	"networking.k8s.io/Ingress": `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: example
+  namespace: NAMESPACE
+spec:
+  rules:
+    - host: example.local
+      http:
+        paths:
+          - path: /
+            pathType: Prefix
+            backend:
+              service:
+                name: example
+                port:
+                  number: 80
+`,
+}
+
+// ResourceTemplate returns a YAML starting point for a new object of a kind
+// Kinds without a bundled template get a skeleton with just the identifying fields
+func ResourceTemplate(apiVersion, kind string, namespaced bool, namespace string) string {
+	group := ""
+	if i := strings.Index(apiVersion, "/"); i >= 0 {
+		group = apiVersion[:i]
+	}
+
+	template, ok := resourceTemplates[group+"/"+kind]
+	if !ok {
+		template = fmt.Sprintf("apiVersion: %s\nkind: %s\nmetadata:\n  name: example\n", apiVersion, kind)
+		if namespaced {
+			template += "  namespace: NAMESPACE\n"
+		}
+		template += "spec: {}\n"
+	}
+	return strings.ReplaceAll(template, "NAMESPACE", namespace)
+}
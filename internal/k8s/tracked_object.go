@@ -24,6 +24,7 @@ type CoreFields struct {
 	Name      string
 	Namespace string
 	Status    string
+	Severity  Severity
 	Age       time.Duration
 	Raw       *unstructured.Unstructured
 }
@@ -33,6 +34,7 @@ type TrackedObject interface {
 	GetName() string
 	GetNamespace() string
 	GetStatus() string
+	GetSeverity() Severity
 	GetAge() time.Duration
 	GetRaw() *unstructured.Unstructured
 	GetCategory() ObjectCategory
@@ -52,6 +54,7 @@ type K8sResource struct {
 func (k *K8sResource) GetName() string                    { return k.Name }
 func (k *K8sResource) GetNamespace() string               { return k.Namespace }
 func (k *K8sResource) GetStatus() string                  { return k.Status }
+func (k *K8sResource) GetSeverity() Severity              { return k.Severity }
 func (k *K8sResource) GetAge() time.Duration              { return k.Age }
 func (k *K8sResource) GetRaw() *unstructured.Unstructured { return k.Raw }
 func (k *K8sResource) GetCategory() ObjectCategory        { return ObjectCategoryK8sResource }
@@ -79,12 +82,14 @@ type HelmRelease struct {
 	HelmChart    string
 	HelmRevision int
 	HelmManifest string
+	HelmStorage  string                      // Helm storage driver the release was read from
 	GVR          schema.GroupVersionResource // Pseudo-GVR
 }
 
 func (h *HelmRelease) GetName() string                    { return h.Name }
 func (h *HelmRelease) GetNamespace() string               { return h.Namespace }
 func (h *HelmRelease) GetStatus() string                  { return h.Status }
+func (h *HelmRelease) GetSeverity() Severity              { return h.Severity }
 func (h *HelmRelease) GetAge() time.Duration              { return h.Age }
 func (h *HelmRelease) GetRaw() *unstructured.Unstructured { return h.Raw }
 func (h *HelmRelease) GetCategory() ObjectCategory        { return ObjectCategoryHelm }
@@ -97,6 +102,7 @@ func (h *HelmRelease) DefaultRowBinding() table.Row {
 		h.Status,
 		util.Truncate(h.HelmChart, 25),
 		fmt.Sprintf("%d", h.HelmRevision),
+		h.HelmStorage,
 	}
 }
 
@@ -117,6 +123,7 @@ type ArgoCDApp struct {
 func (a *ArgoCDApp) GetName() string                    { return a.Name }
 func (a *ArgoCDApp) GetNamespace() string               { return a.Namespace }
 func (a *ArgoCDApp) GetStatus() string                  { return a.Status }
+func (a *ArgoCDApp) GetSeverity() Severity              { return a.Severity }
 func (a *ArgoCDApp) GetAge() time.Duration              { return a.Age }
 func (a *ArgoCDApp) GetRaw() *unstructured.Unstructured { return a.Raw }
 func (a *ArgoCDApp) GetCategory() ObjectCategory        { return ObjectCategoryArgoCD }
@@ -177,13 +184,13 @@ func NewCustomTrackedType(gvr schema.GroupVersionResource, name string, namespac
 			columns = []table.Column{
 				{Title: "NAME", Width: 40},
 				{Title: "NAMESPACE", Width: 20},
-				{Title: "STATUS", Width: 15},
+				{Title: "STATUS", Width: 18},
 				{Title: "AGE", Width: 10},
 			}
 		} else {
 			columns = []table.Column{
 				{Title: "NAME", Width: 60},
-				{Title: "STATUS", Width: 15},
+				{Title: "STATUS", Width: 18},
 				{Title: "AGE", Width: 10},
 			}
 		}
@@ -0,0 +1,132 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// restartedAtAnnotation is the pod template annotation kubectl rollout restart sets
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// restartableKinds are the workloads a rollout restart applies to, as with kubectl rollout restart
+var restartableKinds = map[string]bool{
+	"Deployment":  true,
+	"StatefulSet": true,
+	"DaemonSet":   true,
+}
+
+// scalableKinds are the workloads that have a scale subresource
+var scalableKinds = map[string]bool{
+	"Deployment":  true,
+	"StatefulSet": true,
+	"ReplicaSet":  true,
+}
+
+// IsRestartable reports whether a resource supports a rollout restart
+func IsRestartable(resource TrackedObject) bool {
+	return resource.GetCategory() == ObjectCategoryK8sResource && restartableKinds[resource.GetKind()]
+}
+
+// IsPausable reports whether a resource's rollout can be paused; only Deployments support it
+func IsPausable(resource TrackedObject) bool {
+	return resource.GetCategory() == ObjectCategoryK8sResource && resource.GetKind() == "Deployment"
+}
+
+// IsScalable reports whether a resource can be scaled
+func IsScalable(resource TrackedObject) bool {
+	return resource.GetCategory() == ObjectCategoryK8sResource && scalableKinds[resource.GetKind()]
+}
+
+// patchResource applies a merge patch to a resource, or to one of its subresources
+func (c *Client) patchResource(ctx context.Context, resource TrackedObject, action string, patch map[string]interface{}, subresources ...string) error {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("failed to encode patch: %w", err)
+	}
+	return c.applyPatch(ctx, resource, action, types.MergePatchType, data, subresources...)
+}
+
+// applyPatch sends an encoded patch of the given type to a resource, or to one of its subresources
+func (c *Client) applyPatch(ctx context.Context, resource TrackedObject, action string, patchType types.PatchType, data []byte, subresources ...string) error {
+	resourceInterface, err := c.resourceInterfaceFor(resource)
+	if err != nil {
+		return err
+	}
+
+	if _, err := resourceInterface.Patch(ctx, resource.GetName(), patchType, data, metav1.PatchOptions{}, subresources...); err != nil {
+		return describeAPIError(action, err)
+	}
+	return nil
+}
+
+// RestartWorkload restarts a workload's pods by stamping its pod template, as kubectl rollout restart does
+func (c *Client) RestartWorkload(ctx context.Context, resource TrackedObject) error {
+	if !IsRestartable(resource) {
+		return fmt.Errorf("%s can't be restarted; only Deployments, StatefulSets and DaemonSets can", resource.GetKind())
+	}
+
+	c.Logger.Info("Restarting workload", "kind", resource.GetKind(), "name", resource.GetName(), "namespace", resource.GetNamespace())
+
+	return c.patchResource(ctx, resource, "restart", map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						restartedAtAnnotation: time.Now().Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	})
+}
+
+// ScaleWorkload sets a workload's replica count through its scale subresource
+func (c *Client) ScaleWorkload(ctx context.Context, resource TrackedObject, replicas int32) error {
+	if !IsScalable(resource) {
+		return fmt.Errorf("%s can't be scaled; only Deployments, StatefulSets and ReplicaSets can", resource.GetKind())
+	}
+
+	c.Logger.Info("Scaling workload", "kind", resource.GetKind(), "name", resource.GetName(), "namespace", resource.GetNamespace(), "replicas", replicas)
+
+	return c.patchResource(ctx, resource, "scale", map[string]interface{}{
+		"spec": map[string]interface{}{"replicas": replicas},
+	}, "scale")
+}
+
+// SetWorkloadPaused pauses or resumes a Deployment's rollout, as kubectl rollout pause and resume do
+func (c *Client) SetWorkloadPaused(ctx context.Context, resource TrackedObject, paused bool) error {
+	if !IsPausable(resource) {
+		return fmt.Errorf("%s can't be paused; only Deployments can", resource.GetKind())
+	}
+
+	c.Logger.Info("Setting workload paused", "kind", resource.GetKind(), "name", resource.GetName(), "namespace", resource.GetNamespace(), "paused", paused)
+
+	action := "pause"
+	if !paused {
+		action = "resume"
+	}
+	return c.patchResource(ctx, resource, action, map[string]interface{}{
+		"spec": map[string]interface{}{"paused": paused},
+	})
+}
+
+// PatchMetadata sets or removes labels and annotations; a nil value removes the key
+func (c *Client) PatchMetadata(ctx context.Context, resource TrackedObject, labels, annotations map[string]*string) error {
+	metadata := map[string]interface{}{}
+	if len(labels) > 0 {
+		metadata["labels"] = labels
+	}
+	if len(annotations) > 0 {
+		metadata["annotations"] = annotations
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	return c.patchResource(ctx, resource, "patch", map[string]interface{}{"metadata": metadata})
+}
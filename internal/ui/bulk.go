@@ -0,0 +1,523 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles-w-3/lobot/internal/k8s"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// bulkWorkers bounds how many bulk requests run against the API server at once
+	bulkWorkers = 8
+	// bulkItemTimeout bounds each request of a bulk action
+	bulkItemTimeout = 30 * time.Second
+	// maxListedBulkTargets caps how many targets a bulk confirmation lists
+	maxListedBulkTargets = 10
+	// markIndicator is shown in the mark column of marked rows
+	markIndicator = "✓"
+)
+
+// Bulk actions, as listed in the bulk action selector
+const (
+	bulkActionDelete   = "Delete"
+	bulkActionLabel    = "Label"
+	bulkActionAnnotate = "Annotate"
+	bulkActionRestart  = "Restart"
+	bulkActionScale    = "Scale"
+	bulkActionExport   = "Export YAML"
+)
+
+var bulkActions = []string{bulkActionDelete, bulkActionLabel, bulkActionAnnotate, bulkActionRestart, bulkActionScale, bulkActionExport}
+
+// markKey identifies a resource across refreshes; informer updates replace the objects themselves
+func markKey(resource k8s.TrackedObject) string {
+	if raw := resource.GetRaw(); raw != nil && raw.GetUID() != "" {
+		return string(raw.GetUID())
+	}
+	return resource.GetKind() + "/" + resource.GetNamespace() + "/" + resource.GetName()
+}
+
+// isMarked reports whether a resource is marked
+func (m *Model) isMarked(resource k8s.TrackedObject) bool {
+	return m.marked[markKey(resource)]
+}
+
+// markedResources returns the shown resources that are marked, in table order
+// Marked resources hidden by a filter are left out so actions only touch what is on screen
+func (m *Model) markedResources() []k8s.TrackedObject {
+	if len(m.marked) == 0 {
+		return nil
+	}
+	var marked []k8s.TrackedObject
+	for _, resource := range m.filteredResources {
+		if m.isMarked(resource) {
+			marked = append(marked, resource)
+		}
+	}
+	return marked
+}
+
+// showMarkColumn reports whether the table needs its mark column, i.e. whether any shown resource is marked
+func (m *Model) showMarkColumn() bool {
+	for _, resource := range m.filteredResources {
+		if m.isMarked(resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// setMarked marks or unmarks a resource
+func (m *Model) setMarked(resource k8s.TrackedObject, marked bool) {
+	if m.marked == nil {
+		m.marked = make(map[string]bool)
+	}
+	if marked {
+		m.marked[markKey(resource)] = true
+	} else {
+		delete(m.marked, markKey(resource))
+	}
+}
+
+// ToggleMark marks or unmarks the selected resource and moves to the next row
+func (m *Model) ToggleMark() {
+	resource := m.GetSelectedResource()
+	if resource == nil {
+		return
+	}
+	m.setMarked(resource, !m.isMarked(resource))
+	m.refreshMarks()
+	m.MoveDown()
+}
+
+// MarkAll marks every shown resource, or unmarks them all if they are already marked
+func (m *Model) MarkAll() {
+	allMarked := len(m.markedResources()) == len(m.filteredResources)
+	for _, resource := range m.filteredResources {
+		m.setMarked(resource, !allMarked)
+	}
+	m.refreshMarks()
+}
+
+// InvertMarks flips the mark of every shown resource
+func (m *Model) InvertMarks() {
+	for _, resource := range m.filteredResources {
+		m.setMarked(resource, !m.isMarked(resource))
+	}
+	m.refreshMarks()
+}
+
+// refreshMarks re-renders the rows after marks change, keeping the selection
+func (m *Model) refreshMarks() {
+	selected := m.GetSelectedResource()
+	m.UpdateResources()
+	m.reselectResource(selected)
+}
+
+// OpenBulkActionSelector opens the list of actions to apply to the marked resources
+func (m *Model) OpenBulkActionSelector() tea.Cmd {
+	targets := m.markedResources()
+	if len(targets) == 0 {
+		m.modal.ShowInfo("Nothing Marked", "Mark resources with space (A marks all shown, I inverts), then press B.")
+		return nil
+	}
+
+	actions := bulkActions
+	if m.writeMode() == k8s.WriteDenied {
+		// Exporting is the only bulk action that doesn't change the cluster
+		actions = []string{bulkActionExport}
+	}
+
+	m.bulkTargets = targets
+	m.selector = NewBulkActionSelector(actions, len(targets))
+	return m.selector.Init()
+}
+
+// ApplyBulkActionSelection asks for what the chosen bulk action needs before running it
+func (m *Model) ApplyBulkActionSelection(action string) tea.Cmd {
+	if len(m.bulkTargets) == 0 {
+		return nil
+	}
+	summary := describeBulkTargets(m.bulkTargets)
+
+	var prompt *PromptModel
+	switch action {
+	case bulkActionDelete:
+		m.modal.ShowConfirm(fmt.Sprintf("Delete %d Resources", len(m.bulkTargets)),
+			"Delete these resources, along with anything they own unless propagation is Orphan?\n"+summary,
+			ConfirmActionBulkDelete, []ConfirmOption{
+				{Key: "p", Label: "Propagation", Values: deletePropagationPolicies},
+				{Key: "g", Label: "Grace period", Values: deleteGracePeriods},
+			})
+		return nil
+
+	case bulkActionRestart:
+		m.modal.ShowConfirm(fmt.Sprintf("Restart %d Workloads", len(m.bulkTargets)),
+			"Roll out new pods for these workloads?\n"+summary, ConfirmActionBulkRestart, nil)
+		return nil
+
+	case bulkActionLabel, bulkActionAnnotate:
+		prompt = NewPrompt(PromptTypeBulkMetadata,
+			fmt.Sprintf("%s %d resources", action, len(m.bulkTargets)),
+			"key=value to set, key- to remove; separate several with spaces",
+			"",
+			func(value string) error {
+				_, err := parseMetadataChanges(value, action == bulkActionLabel)
+				return err
+			})
+
+	case bulkActionScale:
+		prompt = NewPrompt(PromptTypeBulkScale,
+			fmt.Sprintf("Scale %d workloads", len(m.bulkTargets)),
+			"Number of replicas",
+			"",
+			func(value string) error {
+				_, err := parseReplicas(value)
+				return err
+			})
+
+	case bulkActionExport:
+		trackedType := m.trackedTypes[m.currentType]
+		prompt = NewPrompt(PromptTypeBulkExport,
+			fmt.Sprintf("Export %d resources", len(m.bulkTargets)),
+			"File to write the resources to as multi-document YAML",
+			fmt.Sprintf("lobot-%s-%s.yaml", trackedType.GVR.Resource, time.Now().Format("20060102-150405")),
+			func(value string) error {
+				if strings.TrimSpace(value) == "" {
+					return fmt.Errorf("file name is empty")
+				}
+				return nil
+			})
+
+	default:
+		return nil
+	}
+
+	m.bulkAction = action
+	m.prompt = prompt
+	m.prompt.SetWidth(min(90, m.width-10))
+	return m.prompt.Init()
+}
+
+// describeBulkTargets lists the targets of a bulk action for a confirmation
+func describeBulkTargets(targets []k8s.TrackedObject) string {
+	var lines []string
+	for i, target := range targets {
+		if i == maxListedBulkTargets {
+			lines = append(lines, fmt.Sprintf("  …and %d more", len(targets)-maxListedBulkTargets))
+			break
+		}
+		lines = append(lines, "  "+describeResource(target))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseMetadataChanges parses "key=value" and "key-" terms into a metadata patch, where nil removes a key
+func parseMetadataChanges(value string, labels bool) (map[string]*string, error) {
+	terms := strings.Fields(value)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("expected key=value or key-")
+	}
+
+	changes := make(map[string]*string, len(terms))
+	for _, term := range terms {
+		if key, ok := strings.CutSuffix(term, "-"); ok && !strings.Contains(term, "=") {
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				return nil, fmt.Errorf("invalid key %q: %s", key, strings.Join(errs, "; "))
+			}
+			changes[key] = nil
+			continue
+		}
+
+		key, val, found := strings.Cut(term, "=")
+		if !found {
+			return nil, fmt.Errorf("expected key=value or key-, got %q", term)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if labels {
+			if errs := validation.IsValidLabelValue(val); len(errs) > 0 {
+				return nil, fmt.Errorf("invalid value for %q: %s", key, strings.Join(errs, "; "))
+			}
+		}
+		changes[key] = &val
+	}
+	return changes, nil
+}
+
+// parseReplicas parses a replica count
+func parseReplicas(value string) (int32, error) {
+	replicas, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+	if err != nil || replicas < 0 {
+		return 0, fmt.Errorf("expected a replica count of 0 or more")
+	}
+	return int32(replicas), nil
+}
+
+// ApplyBulkPrompt runs a bulk action with the value entered in its prompt
+func (m *Model) ApplyBulkPrompt(promptType PromptType, value string) tea.Cmd {
+	targets := m.bulkTargets
+	client := m.resourceService.GetClient()
+
+	switch promptType {
+	case PromptTypeBulkMetadata:
+		labels := m.bulkAction == bulkActionLabel
+		changes, err := parseMetadataChanges(value, labels)
+		if err != nil {
+			m.modal.ShowError("Invalid Input", err.Error())
+			return nil
+		}
+		return m.startBulkOperation(m.bulkAction, targets, func(ctx context.Context, resource k8s.TrackedObject) error {
+			if labels {
+				return client.PatchMetadata(ctx, resource, changes, nil)
+			}
+			return client.PatchMetadata(ctx, resource, nil, changes)
+		})
+
+	case PromptTypeBulkScale:
+		replicas, err := parseReplicas(value)
+		if err != nil {
+			m.modal.ShowError("Invalid Input", err.Error())
+			return nil
+		}
+		recorder := m.recorder()
+		return m.startBulkOperation(bulkActionScale, targets, func(ctx context.Context, resource k8s.TrackedObject) error {
+			_, err := recorder.run(ctx, bulkActionScale, resource, func(ctx context.Context) error {
+				return client.ScaleWorkload(ctx, resource, replicas)
+			})
+			return err
+		})
+
+	case PromptTypeBulkExport:
+		path := strings.TrimSpace(value)
+		written, err := exportResources(path, targets)
+		if err != nil {
+			m.modal.ShowError("Export Failed", err.Error())
+			return nil
+		}
+		m.modal.ShowInfo("Exported", fmt.Sprintf("Wrote %d resources to %s", written, path))
+	}
+	return nil
+}
+
+// applyBulkConfirmed runs a bulk action accepted in a confirmation modal
+func (m *Model) applyBulkConfirmed(msg ConfirmedMsg) tea.Cmd {
+	targets := m.bulkTargets
+	client := m.resourceService.GetClient()
+
+	switch msg.Action {
+	case ConfirmActionBulkDelete:
+		opts, err := parseDeleteOptions(msg.Options[0], msg.Options[1])
+		if err != nil {
+			m.modal.ShowError("Invalid Grace Period", err.Error())
+			return nil
+		}
+		recorder := m.recorder()
+		return m.startBulkOperation(bulkActionDelete, targets, func(ctx context.Context, resource k8s.TrackedObject) error {
+			_, err := recorder.run(ctx, bulkActionDelete, resource, func(ctx context.Context) error {
+				return client.DeleteResource(ctx, resource, opts)
+			})
+			return err
+		})
+
+	case ConfirmActionBulkRestart:
+		return m.startBulkOperation(bulkActionRestart, targets, client.RestartWorkload)
+	}
+	return nil
+}
+
+// exportResources writes resources to a file as multi-document YAML, without their managed fields
+// Helm releases have no manifest of their own and are skipped; it returns how many resources were written
+func exportResources(path string, resources []k8s.TrackedObject) (int, error) {
+	var docs []string
+	for _, resource := range resources {
+		raw := resource.GetRaw()
+		if raw == nil {
+			continue
+		}
+		obj := raw.DeepCopy()
+		obj.SetManagedFields(nil)
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode %s: %w", describeResource(resource), err)
+		}
+		docs = append(docs, string(data))
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Join(docs, "---\n")), 0o644); err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return len(docs), nil
+}
+
+// BulkItemState is the progress of one resource in a bulk operation
+type BulkItemState int
+
+const (
+	BulkItemPending BulkItemState = iota
+	BulkItemRunning
+	BulkItemSucceeded
+	BulkItemFailed
+)
+
+// String returns a human-readable state
+func (s BulkItemState) String() string {
+	switch s {
+	case BulkItemRunning:
+		return "Running"
+	case BulkItemSucceeded:
+		return "Done"
+	case BulkItemFailed:
+		return "Failed"
+	default:
+		return "Pending"
+	}
+}
+
+// BulkItem is one resource of a bulk operation
+type BulkItem struct {
+	Resource k8s.TrackedObject
+	State    BulkItemState
+	Err      error
+}
+
+// BulkOperation is an action applied to many resources by a bounded pool of workers
+type BulkOperation struct {
+	Action    string
+	Items     []BulkItem
+	StartedAt time.Time
+	// FinishedAt is set once every item has finished
+	FinishedAt time.Time
+	updates    chan BulkItemMsg
+}
+
+// BulkItemMsg reports progress of one item of a bulk operation
+type BulkItemMsg struct {
+	Operation *BulkOperation
+	Index     int
+	State     BulkItemState
+	Err       error
+}
+
+// BulkFinishedMsg is sent once every item of a bulk operation has finished
+type BulkFinishedMsg struct {
+	Operation *BulkOperation
+}
+
+// Counts returns how many items have succeeded and failed
+func (op *BulkOperation) Counts() (succeeded, failed int) {
+	for _, item := range op.Items {
+		switch item.State {
+		case BulkItemSucceeded:
+			succeeded++
+		case BulkItemFailed:
+			failed++
+		}
+	}
+	return succeeded, failed
+}
+
+// next waits for the operation's next progress update
+func (op *BulkOperation) next() tea.Cmd {
+	return func() tea.Msg {
+		update, ok := <-op.updates
+		if !ok {
+			return BulkFinishedMsg{Operation: op}
+		}
+		return update
+	}
+}
+
+// startBulkOperation runs an action on every target and opens the results panel to follow it
+func (m *Model) startBulkOperation(action string, targets []k8s.TrackedObject, run func(context.Context, k8s.TrackedObject) error) tea.Cmd {
+	return m.guardWrite(action, func(m *Model, base context.Context) tea.Cmd {
+		op := &BulkOperation{
+			Action:    action,
+			Items:     make([]BulkItem, len(targets)),
+			StartedAt: time.Now(),
+			// Each item reports that it started and that it finished
+			updates: make(chan BulkItemMsg, 2*len(targets)),
+		}
+		for i, target := range targets {
+			op.Items[i] = BulkItem{Resource: target}
+		}
+
+		jobs := make(chan int, len(targets))
+		for i := range targets {
+			jobs <- i
+		}
+		close(jobs)
+
+		var wg sync.WaitGroup
+		for range min(bulkWorkers, len(targets)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range jobs {
+					op.updates <- BulkItemMsg{Operation: op, Index: i, State: BulkItemRunning}
+
+					ctx, cancel := context.WithTimeout(base, bulkItemTimeout)
+					err := run(ctx, targets[i])
+					cancel()
+
+					state := BulkItemSucceeded
+					if err != nil {
+						state = BulkItemFailed
+					}
+					op.updates <- BulkItemMsg{Operation: op, Index: i, State: state, Err: err}
+				}
+			}()
+		}
+		go func() {
+			wg.Wait()
+			close(op.updates)
+		}()
+
+		m.logger.Info("Starting bulk operation", "action", action, "resources", len(targets))
+		m.bulkPanel = NewBulkPanelModel(op, m.width, m.height)
+		m.viewMode = ViewModeBulkResults
+		m.bulkTargets = nil
+		return op.next()
+	})
+}
+
+// ApplyBulkItemUpdate records an item's progress, unmarking resources the action succeeded on
+func (m *Model) ApplyBulkItemUpdate(msg BulkItemMsg) tea.Cmd {
+	item := &msg.Operation.Items[msg.Index]
+	item.State = msg.State
+	item.Err = msg.Err
+
+	if msg.State == BulkItemSucceeded {
+		m.setMarked(item.Resource, false)
+	}
+	return msg.Operation.next()
+}
+
+// FinishBulkOperation records that every item of an operation has finished
+func (m *Model) FinishBulkOperation(op *BulkOperation) {
+	op.FinishedAt = time.Now()
+	succeeded, failed := op.Counts()
+	m.logger.Info("Bulk operation finished", "action", op.Action, "succeeded", succeeded, "failed", failed)
+
+	// The results panel rebuilds the table when it is closed
+	if m.viewMode == ViewModeNormal {
+		m.refreshMarks()
+	}
+}
+
+// ExitBulkResultsMode closes the results panel; a running operation carries on in the background
+func (m *Model) ExitBulkResultsMode() {
+	m.viewMode = ViewModeNormal
+	m.bulkPanel = nil
+	m.UpdateResources()
+}
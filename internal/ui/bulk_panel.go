@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles-w-3/lobot/internal/util"
+)
+
+// BulkPanelKeyMap defines key bindings for the bulk results panel
+type BulkPanelKeyMap struct {
+	Up   key.Binding
+	Down key.Binding
+	Back key.Binding
+}
+
+// DefaultBulkPanelKeyMap returns the default key bindings for the bulk results panel
+func DefaultBulkPanelKeyMap() BulkPanelKeyMap {
+	return BulkPanelKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "move up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "move down"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc/q", "back to list"),
+		),
+	}
+}
+
+// ShortHelp returns a short list of key bindings
+func (k BulkPanelKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Back}
+}
+
+// FullHelp returns the full list of key bindings organized by category
+func (k BulkPanelKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down},
+		{k.Back},
+	}
+}
+
+// BulkPanelModel shows the per-resource progress and failures of a bulk operation
+type BulkPanelModel struct {
+	operation *BulkOperation
+	cursor    int
+	offset    int
+	width     int
+	height    int
+	keys      BulkPanelKeyMap
+	help      help.Model
+}
+
+// NewBulkPanelModel creates the bulk results panel for an operation
+func NewBulkPanelModel(operation *BulkOperation, width, height int) *BulkPanelModel {
+	return &BulkPanelModel{
+		operation: operation,
+		width:     width,
+		height:    height,
+		keys:      DefaultBulkPanelKeyMap(),
+		help:      configureHelp(),
+	}
+}
+
+// SetSize updates the panel dimensions
+func (p *BulkPanelModel) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// visibleRows is how many items fit in the panel body, below the header row and above the error details
+func (p *BulkPanelModel) visibleRows() int {
+	return max(1, p.height-10)
+}
+
+// Update handles key presses
+func (p *BulkPanelModel) Update(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, p.keys.Up):
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case key.Matches(msg, p.keys.Down):
+		if p.cursor < len(p.operation.Items)-1 {
+			p.cursor++
+		}
+	}
+
+	// Keep the cursor in view
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+p.visibleRows() {
+		p.offset = p.cursor - p.visibleRows() + 1
+	}
+	return nil
+}
+
+// View renders the panel
+func (p *BulkPanelModel) View() string {
+	op := p.operation
+	succeeded, failed := op.Counts()
+
+	title := titleStyle.Render(op.Action)
+	progress := fmt.Sprintf("%d/%d done", succeeded+failed, len(op.Items))
+	if failed > 0 {
+		progress += fmt.Sprintf(" • %d failed", failed)
+	}
+	elapsed := time.Since(op.StartedAt)
+	if !op.FinishedAt.IsZero() {
+		elapsed = op.FinishedAt.Sub(op.StartedAt)
+	}
+	progress += " • " + util.FormatAge(elapsed)
+	header := title + "  " + lipgloss.NewStyle().Foreground(colorMuted).Render(progress)
+
+	body := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorBorder).
+		Width(p.width - 2).
+		Height(p.height - 5).
+		Render(p.renderList())
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		body,
+		helpStyle.Render(p.help.ShortHelpView(p.keys.ShortHelp())),
+	)
+}
+
+// renderList renders the items as a table followed by the error of the selected item
+func (p *BulkPanelModel) renderList() string {
+	stateWidth := 8
+	resourceWidth := max(20, p.width-6-stateWidth-1)
+
+	header := padCell("STATE", stateWidth) + " " + padCell("RESOURCE", resourceWidth)
+	lines := []string{tableHeaderStyle.UnsetPadding().Render(header)}
+
+	items := p.operation.Items
+	end := min(len(items), p.offset+p.visibleRows())
+	for i := p.offset; i < end; i++ {
+		item := items[i]
+		stateCell := padCell(item.State.String(), stateWidth)
+		resourceCell := padCell(describeResource(item.Resource), resourceWidth)
+
+		if i == p.cursor {
+			lines = append(lines, portForwardSelectedStyle.Render(stateCell+" "+resourceCell))
+			continue
+		}
+		lines = append(lines, bulkItemStateStyle(item.State).Render(stateCell)+" "+resourceCell)
+	}
+
+	if p.cursor < len(items) {
+		if err := items[p.cursor].Err; err != nil {
+			lines = append(lines, "", logErrorStyle.Render("Error: "+err.Error()))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// bulkItemStateStyle returns the style for a bulk item state
+func bulkItemStateStyle(state BulkItemState) lipgloss.Style {
+	switch state {
+	case BulkItemSucceeded:
+		return podRunningStyle
+	case BulkItemRunning:
+		return podPendingStyle
+	case BulkItemFailed:
+		return podFailedStyle
+	default:
+		return portForwardStoppedStyle
+	}
+}
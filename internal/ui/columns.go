@@ -0,0 +1,380 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles-w-3/lobot/internal/config"
+	"github.com/miles-w-3/lobot/internal/k8s"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// tableCellPadding is the horizontal padding table.DefaultStyles adds around every cell
+	tableCellPadding = 2
+	// flexibleColumnWidth is the preferred width from which a column holds free text (names, images, messages)
+	// and takes part in fitting the table to the terminal; narrower columns keep their width
+	flexibleColumnWidth = 20
+	// minFlexibleColumnWidth is how far a flexible column may shrink on narrow terminals
+	minFlexibleColumnWidth = 10
+	// defaultCustomColumnWidth is the preferred width of custom columns that don't set one
+	defaultCustomColumnWidth = 20
+)
+
+// customColumn is a configured custom column with its parsed expression
+type customColumn struct {
+	config.CustomColumn
+	expr *k8s.ColumnExpression
+}
+
+// sortState is how the table of a resource type is sorted
+type sortState struct {
+	column     int // Index into the type's columns, including custom columns
+	descending bool
+}
+
+// fitColumns scales the flexible columns so the table fills the given width
+// Space is shared in proportion to the preferred widths, so NAME stays the widest column
+func fitColumns(columns []table.Column, width int) []table.Column {
+	fitted := make([]table.Column, len(columns))
+	copy(fitted, columns)
+
+	total, flexTotal := 0, 0
+	var flexible []int
+	for i, col := range columns {
+		total += col.Width + tableCellPadding
+		if col.Width >= flexibleColumnWidth {
+			flexTotal += col.Width
+			flexible = append(flexible, i)
+		}
+	}
+
+	delta := width - total
+	if delta == 0 || flexTotal == 0 || width <= 0 {
+		return fitted
+	}
+
+	remaining := delta
+	for n, i := range flexible {
+		share := delta * columns[i].Width / flexTotal
+		if n == len(flexible)-1 {
+			// The last flexible column absorbs the rounding
+			share = remaining
+		}
+		fitted[i].Width = max(minFlexibleColumnWidth, columns[i].Width+share)
+		remaining -= share
+	}
+	return fitted
+}
+
+// resourceConfigKey is the key custom columns are stored under, e.g. "deployments.apps"
+func resourceConfigKey(gvr schema.GroupVersionResource) string {
+	return gvr.GroupResource().String()
+}
+
+// customColumnsFor returns the parsed custom columns of a resource type
+// Columns whose expression no longer parses are skipped rather than failing the whole table
+func (m *Model) customColumnsFor(trackedType *k8s.TrackedType) []customColumn {
+	if m.config == nil {
+		return nil
+	}
+	if columns, ok := m.customColumns[trackedType.GVR]; ok {
+		return columns
+	}
+
+	var columns []customColumn
+	for _, col := range m.columnConfig(trackedType.GVR) {
+		expr, err := k8s.ParseColumnExpression(col.Expression)
+		if err != nil {
+			m.logger.Warn("Skipping invalid custom column", "column", col.Name, "error", err)
+			continue
+		}
+		columns = append(columns, customColumn{CustomColumn: col, expr: expr})
+	}
+
+	if m.customColumns == nil {
+		m.customColumns = make(map[schema.GroupVersionResource][]customColumn)
+	}
+	m.customColumns[trackedType.GVR] = columns
+	return columns
+}
+
+// tableColumns returns a type's columns followed by its custom columns, at their preferred widths
+func (m *Model) tableColumns(trackedType *k8s.TrackedType) []table.Column {
+	columns := append([]table.Column{}, trackedType.Columns...)
+	for _, col := range m.customColumnsFor(trackedType) {
+		width := col.Width
+		if width <= 0 {
+			width = defaultCustomColumnWidth
+		}
+		columns = append(columns, table.Column{Title: strings.ToUpper(col.Name), Width: width})
+	}
+	return columns
+}
+
+// bindRow binds a resource to the type's columns followed by its custom columns
+func (m *Model) bindRow(trackedType *k8s.TrackedType, resource k8s.TrackedObject) table.Row {
+	row := trackedType.RowBinder(resource)
+	for _, col := range m.customColumnsFor(trackedType) {
+		row = append(row, col.expr.Evaluate(resource))
+	}
+	return row
+}
+
+// resizeTableColumns fits the current type's columns, plus the status indicator column, to the table width
+func (m *Model) resizeTableColumns() {
+	if len(m.trackedTypes) == 0 {
+		return
+	}
+	trackedType := m.trackedTypes[m.currentType]
+
+	columns := m.tableColumns(trackedType)
+	if state, ok := m.sortStates[trackedType.GVR]; ok && state.column < len(columns) {
+		col := &columns[state.column]
+		marker := " ▲"
+		if state.descending {
+			marker = " ▼"
+		}
+		col.Title += marker
+		col.Width = max(col.Width, len([]rune(col.Title)))
+	}
+
+	// A narrow leading column carries the status indicator that renderResourceTable colors rows by
+	leading := []table.Column{{Title: "", Width: 1}}
+	// Once anything is marked, a second narrow column shows which rows are
+	if m.showMarkColumn() {
+		leading = append(leading, table.Column{Title: "", Width: 1})
+	}
+	available := m.table.Width() - len(leading)*(1+tableCellPadding)
+	m.table.SetColumns(append(leading, fitColumns(columns, available)...))
+}
+
+// sortResources orders resources and their rows by the type's sort column
+// The sort is stable over the informer's namespace/name order, so equal values keep a fixed order across refreshes
+func (m *Model) sortResources(trackedType *k8s.TrackedType, resources []k8s.TrackedObject, rows []table.Row) ([]k8s.TrackedObject, []table.Row) {
+	state, ok := m.sortStates[trackedType.GVR]
+	if !ok || len(rows) == 0 || state.column >= len(rows[0]) {
+		return resources, rows
+	}
+
+	order := make([]int, len(rows))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		c := compareCells(rows[order[a]][state.column], rows[order[b]][state.column])
+		if state.descending {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	sortedResources := make([]k8s.TrackedObject, len(order))
+	sortedRows := make([]table.Row, len(order))
+	for i, j := range order {
+		sortedResources[i] = resources[j]
+		sortedRows[i] = rows[j]
+	}
+	return sortedResources, sortedRows
+}
+
+// CycleSortColumn sorts by the next column, returning to the default order after the last one
+func (m *Model) CycleSortColumn() {
+	if len(m.trackedTypes) == 0 {
+		return
+	}
+	trackedType := m.trackedTypes[m.currentType]
+
+	if m.sortStates == nil {
+		m.sortStates = make(map[schema.GroupVersionResource]sortState)
+	}
+
+	next := 0
+	if state, ok := m.sortStates[trackedType.GVR]; ok {
+		next = state.column + 1
+	}
+	if next >= len(m.tableColumns(trackedType)) {
+		delete(m.sortStates, trackedType.GVR)
+	} else {
+		m.sortStates[trackedType.GVR] = sortState{column: next}
+	}
+
+	selected := m.GetSelectedResource()
+	m.UpdateResources()
+	m.reselectResource(selected)
+}
+
+// ReverseSort flips the direction of the current sort
+func (m *Model) ReverseSort() {
+	if len(m.trackedTypes) == 0 {
+		return
+	}
+	trackedType := m.trackedTypes[m.currentType]
+
+	state, ok := m.sortStates[trackedType.GVR]
+	if !ok {
+		return
+	}
+	state.descending = !state.descending
+	m.sortStates[trackedType.GVR] = state
+
+	selected := m.GetSelectedResource()
+	m.UpdateResources()
+	m.reselectResource(selected)
+}
+
+var (
+	// ageCellPattern matches the ages produced by util.FormatAge
+	ageCellPattern = regexp.MustCompile(`^(\d+)([smhd])$`)
+	// leadingNumberPattern matches cells that start with a count, such as "3/3" or "2 (5m ago)"
+	leadingNumberPattern = regexp.MustCompile(`^(-?\d+(?:\.\d+)?)(?:$|[ /%,(])`)
+)
+
+// ageUnits converts util.FormatAge units to durations
+var ageUnits = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+}
+
+// compareCells compares two cells of the same column
+// Ages, quantities (1Gi, 500m) and counts compare numerically when both cells parse the same way;
+// anything else compares as text
+func compareCells(a, b string) int {
+	if x, ok := parseAgeCell(a); ok {
+		if y, ok := parseAgeCell(b); ok {
+			return cmp.Compare(x, y)
+		}
+	}
+	if x, err := resource.ParseQuantity(a); err == nil {
+		if y, err := resource.ParseQuantity(b); err == nil {
+			return x.Cmp(y)
+		}
+	}
+	if x, ok := parseLeadingNumber(a); ok {
+		if y, ok := parseLeadingNumber(b); ok {
+			return cmp.Compare(x, y)
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// parseAgeCell parses an age such as "5m" or "12d"
+func parseAgeCell(cell string) (time.Duration, bool) {
+	match := ageCellPattern.FindStringSubmatch(cell)
+	if match == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(n) * ageUnits[match[2]], true
+}
+
+// parseLeadingNumber parses the number a cell starts with
+func parseLeadingNumber(cell string) (float64, bool) {
+	match := leadingNumberPattern.FindStringSubmatch(cell)
+	if match == nil {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(match[1], 64)
+	return n, err == nil
+}
+
+// PromptCustomColumn asks for a custom column to add to, or remove from, the current resource type
+func (m *Model) PromptCustomColumn() tea.Cmd {
+	if m.config == nil || len(m.trackedTypes) == 0 {
+		return nil
+	}
+	trackedType := m.trackedTypes[m.currentType]
+
+	m.prompt = NewPrompt(PromptTypeCustomColumn,
+		fmt.Sprintf("Custom column for %s", trackedType.DisplayName),
+		"NAME=JSONPATH (e.g. VERSION={.metadata.labels.app\\.kubernetes\\.io/version}), NAME={{ template }}, or -NAME to remove",
+		"",
+		func(value string) error {
+			_, _, err := parseCustomColumnInput(value)
+			return err
+		})
+	m.prompt.SetWidth(min(90, m.width-10))
+	return m.prompt.Init()
+}
+
+// parseCustomColumnInput parses NAME=EXPRESSION, or -NAME for removal (which returns a nil column)
+func parseCustomColumnInput(value string) (name string, column *config.CustomColumn, err error) {
+	value = strings.TrimSpace(value)
+	if remove, ok := strings.CutPrefix(value, "-"); ok && !strings.Contains(remove, "=") {
+		if remove = strings.TrimSpace(remove); remove == "" {
+			return "", nil, fmt.Errorf("column name is empty")
+		}
+		return remove, nil, nil
+	}
+
+	name, expr, found := strings.Cut(value, "=")
+	name = strings.TrimSpace(name)
+	if !found || name == "" {
+		return "", nil, fmt.Errorf("expected NAME=EXPRESSION or -NAME")
+	}
+	if _, err := k8s.ParseColumnExpression(expr); err != nil {
+		return "", nil, err
+	}
+	return name, &config.CustomColumn{Name: name, Expression: strings.TrimSpace(expr)}, nil
+}
+
+// ApplyCustomColumnPrompt adds, replaces or removes a custom column and saves the config
+// The columns of a recalled view are changed for the session only
+func (m *Model) ApplyCustomColumnPrompt(value string) {
+	name, column, err := parseCustomColumnInput(value)
+	if err != nil {
+		m.modal.ShowError("Invalid Column", err.Error())
+		return
+	}
+
+	trackedType := m.trackedTypes[m.currentType]
+	key := resourceConfigKey(trackedType.GVR)
+
+	var columns []config.CustomColumn
+	replaced := false
+	for _, existing := range m.columnConfig(trackedType.GVR) {
+		if !strings.EqualFold(existing.Name, name) {
+			columns = append(columns, existing)
+			continue
+		}
+		if column != nil {
+			columns = append(columns, *column)
+			replaced = true
+		}
+	}
+	if column != nil && !replaced {
+		columns = append(columns, *column)
+	}
+
+	// While a view is recalled its columns are changed for the session; save the view to keep them
+	recalled := m.recalledView != nil && m.recalledView.gvr == trackedType.GVR
+	if recalled {
+		m.recalledView.columns = columns
+	} else {
+		m.config.SetCustomColumns(key, columns)
+	}
+	delete(m.customColumns, trackedType.GVR)
+	// The sort column may have shifted or disappeared
+	delete(m.sortStates, trackedType.GVR)
+	m.UpdateResources()
+
+	if recalled {
+		return
+	}
+	if err := m.config.Save(); err != nil {
+		m.modal.ShowError("Failed to Save Config", err.Error())
+	}
+}
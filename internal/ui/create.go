@@ -0,0 +1,230 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles-w-3/lobot/internal/k8s"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// createSourceFile is the create source that reads a manifest from a file
+	createSourceFile = "Apply a YAML file"
+
+	// createDryRunTimeout bounds parsing and dry-running a manifest
+	createDryRunTimeout = 60 * time.Second
+	// createApplyTimeout bounds applying one document, including waiting for a CRD it depends on
+	createApplyTimeout = 60 * time.Second
+)
+
+// CreatePreviewMsg is sent when a manifest has been dry-run and is ready to be applied
+type CreatePreviewMsg struct {
+	Source    string
+	Namespace string
+	Docs      []*k8s.ManifestDocument
+	Err       error
+}
+
+// CreateDocumentMsg is sent when a document of the manifest being created has been applied
+type CreateDocumentMsg struct {
+	Panel    *CreatePanelModel
+	Position int
+	State    k8s.ManifestState
+	Err      error
+}
+
+// createTemplateSource is the create source that opens a template for the current resource type
+func (m *Model) createTemplateSource() string {
+	return fmt.Sprintf("New %s from a template", m.CurrentResourceType().DisplayName)
+}
+
+// createNamespace is the namespace new objects go in when they don't set one:
+// the namespace filter when it names a single namespace, otherwise "default"
+func (m *Model) createNamespace() string {
+	pattern := m.namespaceFilter.GetPattern()
+	if pattern != "" && len(validation.IsDNS1123Label(pattern)) == 0 {
+		return pattern
+	}
+	return "default"
+}
+
+// OpenCreateSelector asks whether to start from a template or a file
+func (m *Model) OpenCreateSelector() tea.Cmd {
+	m.selector = NewCreateSourceSelector([]string{m.createTemplateSource(), createSourceFile})
+	return m.selector.Init()
+}
+
+// ApplyCreateSourceSelection starts creating from the chosen source
+func (m *Model) ApplyCreateSourceSelection(source string) tea.Cmd {
+	if source == createSourceFile {
+		m.prompt = NewPrompt(PromptTypeCreateFile,
+			"Apply a YAML file",
+			"Path to a manifest; several documents separated by --- are created together",
+			"",
+			func(value string) error {
+				path := expandHome(strings.TrimSpace(value))
+				if path == "" {
+					return fmt.Errorf("file name is empty")
+				}
+				if info, err := os.Stat(path); err != nil {
+					return err
+				} else if info.IsDir() {
+					return fmt.Errorf("%s is a directory", path)
+				}
+				return nil
+			})
+		m.prompt.SetWidth(min(90, m.width-10))
+		return m.prompt.Init()
+	}
+	return m.createFromTemplate()
+}
+
+// ApplyCreateFilePrompt reads and dry-runs the manifest file that was entered
+func (m *Model) ApplyCreateFilePrompt(value string) tea.Cmd {
+	path := expandHome(strings.TrimSpace(value))
+	client := m.resourceService.GetClient()
+	namespace := m.createNamespace()
+	return func() tea.Msg {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return CreatePreviewMsg{Err: fmt.Errorf("failed to read %s: %w", path, err)}
+		}
+		return previewManifests(client, filepath.Base(path), namespace, data)
+	}
+}
+
+// createFromTemplate opens the editor on a template for the current resource type
+// Quitting the editor without changing the template cancels
+func (m *Model) createFromTemplate() tea.Cmd {
+	trackedType := m.CurrentResourceType()
+	namespace := m.createNamespace()
+	client := m.resourceService.GetClient()
+
+	editResult, err := client.PrepareCreateFile(trackedType.GVR, namespace)
+	if err != nil {
+		return func() tea.Msg {
+			return CreatePreviewMsg{Err: fmt.Errorf("failed to prepare template: %w", err)}
+		}
+	}
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vim"
+	}
+
+	return tea.ExecProcess(exec.Command(editor, editResult.TmpFilePath), func(err error) tea.Msg {
+		defer os.Remove(editResult.TmpFilePath)
+
+		if err != nil {
+			return EditorFinishedMsg{Err: fmt.Errorf("editor exited with error: %w", err)}
+		}
+
+		data, err := os.ReadFile(editResult.TmpFilePath)
+		if err != nil {
+			return CreatePreviewMsg{Err: fmt.Errorf("failed to read edited file: %w", err)}
+		}
+		if bytes.Equal(data, editResult.OriginalContent) {
+			return EditorFinishedMsg{Cancelled: true}
+		}
+		return previewManifests(client, "template", namespace, data)
+	})
+}
+
+// previewManifests parses a manifest, orders its documents for creation and dry-runs them
+func previewManifests(client *k8s.Client, source, namespace string, data []byte) tea.Msg {
+	docs, err := k8s.ParseManifests(data)
+	if err != nil {
+		return CreatePreviewMsg{Err: err}
+	}
+	k8s.SortManifests(docs)
+
+	ctx, cancel := context.WithTimeout(context.Background(), createDryRunTimeout)
+	defer cancel()
+	client.DryRunManifests(ctx, docs, namespace)
+
+	return CreatePreviewMsg{Source: source, Namespace: namespace, Docs: docs}
+}
+
+// ShowCreatePreview opens the create panel with the dry-run results of a manifest
+func (m *Model) ShowCreatePreview(msg CreatePreviewMsg) {
+	if msg.Err != nil {
+		m.modal.ShowError("Create Failed", msg.Err.Error())
+		return
+	}
+	m.createPanel = NewCreatePanelModel(msg.Source, msg.Namespace, msg.Docs, m.width, m.height)
+	m.viewMode = ViewModeCreate
+}
+
+// ApplyCreate starts applying the previewed documents, one at a time in order
+func (m *Model) ApplyCreate() tea.Cmd {
+	panel := m.createPanel
+	if panel == nil || panel.applying {
+		return nil
+	}
+	return m.guardWrite("Create", func(m *Model, base context.Context) tea.Cmd {
+		panel.applying = true
+		panel.writeCtx = base
+		panel.keys.Apply.SetEnabled(false)
+		return m.applyNextDocument(panel)
+	})
+}
+
+// applyNextDocument applies the next document of the panel that passed its dry run, or finishes
+func (m *Model) applyNextDocument(panel *CreatePanelModel) tea.Cmd {
+	for panel.next < len(panel.docs) {
+		doc := panel.docs[panel.next]
+		if doc.State != k8s.ManifestReady && doc.State != k8s.ManifestDeferred {
+			panel.next++
+			continue
+		}
+
+		position := panel.next
+		client := m.resourceService.GetClient()
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(panel.writeCtx, createApplyTimeout)
+			defer cancel()
+
+			state, err := client.ApplyManifest(ctx, doc, panel.namespace)
+			return CreateDocumentMsg{Panel: panel, Position: position, State: state, Err: err}
+		}
+	}
+
+	m.UpdateResources()
+	return nil
+}
+
+// ApplyCreateDocument records how applying a document went and moves on to the next
+func (m *Model) ApplyCreateDocument(msg CreateDocumentMsg) tea.Cmd {
+	panel := msg.Panel
+	doc := panel.docs[msg.Position]
+	doc.State, doc.Err = msg.State, msg.Err
+	panel.next = msg.Position + 1
+	return m.applyNextDocument(panel)
+}
+
+// ExitCreateMode closes the create panel
+// Documents still being applied continue in the background
+func (m *Model) ExitCreateMode() {
+	m.createPanel = nil
+	m.viewMode = ViewModeNormal
+}
+
+// expandHome expands a leading ~ in a path to the home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
@@ -0,0 +1,237 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles-w-3/lobot/internal/k8s"
+)
+
+// CreatePanelKeyMap defines key bindings for the create panel
+type CreatePanelKeyMap struct {
+	Up    key.Binding
+	Down  key.Binding
+	Apply key.Binding
+	Back  key.Binding
+}
+
+// DefaultCreatePanelKeyMap returns the default key bindings for the create panel
+func DefaultCreatePanelKeyMap() CreatePanelKeyMap {
+	return CreatePanelKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "move up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "move down"),
+		),
+		Apply: key.NewBinding(
+			key.WithKeys("y", "enter"),
+			key.WithHelp("y/enter", "apply"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc/q", "back to list"),
+		),
+	}
+}
+
+// ShortHelp returns a short list of key bindings
+func (k CreatePanelKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Apply, k.Back}
+}
+
+// FullHelp returns the full list of key bindings organized by category
+func (k CreatePanelKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down},
+		{k.Apply, k.Back},
+	}
+}
+
+// CreatePanelModel shows the documents of a manifest being created: their dry-run results, then how applying each went
+type CreatePanelModel struct {
+	source    string
+	namespace string // Namespace for documents that don't set one
+	docs      []*k8s.ManifestDocument
+	next      int  // Position of the next document to apply
+	applying  bool // Set once applying has started
+	// writeCtx is the context documents are applied with, which carries the confirmation a protected context needs
+	writeCtx context.Context
+	cursor   int
+	offset   int
+	width    int
+	height   int
+	keys     CreatePanelKeyMap
+	help     help.Model
+}
+
+// NewCreatePanelModel creates the create panel for dry-run documents
+func NewCreatePanelModel(source, namespace string, docs []*k8s.ManifestDocument, width, height int) *CreatePanelModel {
+	p := &CreatePanelModel{
+		source:    source,
+		namespace: namespace,
+		docs:      docs,
+		width:     width,
+		height:    height,
+		keys:      DefaultCreatePanelKeyMap(),
+		help:      configureHelp(),
+	}
+	p.keys.Apply.SetEnabled(p.applicable() > 0)
+	return p
+}
+
+// SetSize updates the panel dimensions
+func (p *CreatePanelModel) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// applicable counts the documents that would be applied
+func (p *CreatePanelModel) applicable() int {
+	count := 0
+	for _, doc := range p.docs {
+		if doc.State == k8s.ManifestReady || doc.State == k8s.ManifestDeferred {
+			count++
+		}
+	}
+	return count
+}
+
+// counts tallies the documents by state
+func (p *CreatePanelModel) counts() map[k8s.ManifestState]int {
+	counts := make(map[k8s.ManifestState]int)
+	for _, doc := range p.docs {
+		counts[doc.State]++
+	}
+	return counts
+}
+
+// Done reports whether every applicable document has been applied
+func (p *CreatePanelModel) Done() bool {
+	return p.applying && p.next >= len(p.docs)
+}
+
+// visibleRows is how many documents fit in the panel body, below the header row and above the error details
+func (p *CreatePanelModel) visibleRows() int {
+	return max(1, p.height-10)
+}
+
+// Update handles key presses
+func (p *CreatePanelModel) Update(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, p.keys.Up):
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case key.Matches(msg, p.keys.Down):
+		if p.cursor < len(p.docs)-1 {
+			p.cursor++
+		}
+	}
+
+	// Keep the cursor in view
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+p.visibleRows() {
+		p.offset = p.cursor - p.visibleRows() + 1
+	}
+	return nil
+}
+
+// View renders the panel
+func (p *CreatePanelModel) View() string {
+	counts := p.counts()
+
+	title := titleStyle.Render("Create from " + p.source)
+	var details []string
+	if p.applying {
+		done := counts[k8s.ManifestCreated] + counts[k8s.ManifestConfigured] + counts[k8s.ManifestFailed]
+		details = append(details, fmt.Sprintf("%d/%d applied", done, done+counts[k8s.ManifestReady]+counts[k8s.ManifestDeferred]))
+		if counts[k8s.ManifestFailed] > 0 {
+			details = append(details, fmt.Sprintf("%d failed", counts[k8s.ManifestFailed]))
+		}
+	} else {
+		details = append(details, fmt.Sprintf("%d documents", len(p.docs)))
+		if counts[k8s.ManifestInvalid] > 0 {
+			details = append(details, fmt.Sprintf("%d invalid, skipped when applying", counts[k8s.ManifestInvalid]))
+		}
+		details = append(details, fmt.Sprintf("%d ready", counts[k8s.ManifestReady]))
+		if counts[k8s.ManifestDeferred] > 0 {
+			details = append(details, fmt.Sprintf("%d deferred", counts[k8s.ManifestDeferred]))
+		}
+	}
+	details = append(details, "default namespace "+p.namespace)
+	header := title + "  " + lipgloss.NewStyle().Foreground(colorMuted).Render(strings.Join(details, " • "))
+
+	body := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorBorder).
+		Width(p.width - 2).
+		Height(p.height - 5).
+		Render(p.renderList())
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		body,
+		helpStyle.Render(p.help.ShortHelpView(p.keys.ShortHelp())),
+	)
+}
+
+// renderList renders the documents as a table in apply order, followed by the details of the selected one
+func (p *CreatePanelModel) renderList() string {
+	indexWidth := 4
+	stateWidth := 10
+	resourceWidth := max(20, p.width-6-indexWidth-stateWidth-2)
+
+	header := padCell("#", indexWidth) + " " + padCell("STATE", stateWidth) + " " + padCell("RESOURCE", resourceWidth)
+	lines := []string{tableHeaderStyle.UnsetPadding().Render(header)}
+
+	end := min(len(p.docs), p.offset+p.visibleRows())
+	for i := p.offset; i < end; i++ {
+		doc := p.docs[i]
+		indexCell := padCell(fmt.Sprint(doc.Index), indexWidth)
+		stateCell := padCell(doc.State.String(), stateWidth)
+		resourceCell := padCell(doc.Describe(), resourceWidth)
+
+		if i == p.cursor {
+			lines = append(lines, portForwardSelectedStyle.Render(indexCell+" "+stateCell+" "+resourceCell))
+			continue
+		}
+		lines = append(lines, indexCell+" "+manifestStateStyle(doc.State).Render(stateCell)+" "+resourceCell)
+	}
+
+	if p.cursor < len(p.docs) {
+		doc := p.docs[p.cursor]
+		switch {
+		case doc.Err != nil:
+			lines = append(lines, "", logErrorStyle.Render("Error: "+doc.Err.Error()))
+		case doc.State == k8s.ManifestDeferred:
+			lines = append(lines, "", diffHunkStyle.Render("Checked when applied: "+doc.Note))
+		case doc.State == k8s.ManifestReady && doc.Exists:
+			lines = append(lines, "", diffHunkStyle.Render("Already exists; applying updates it"))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// manifestStateStyle returns the style for a manifest document state
+func manifestStateStyle(state k8s.ManifestState) lipgloss.Style {
+	switch state {
+	case k8s.ManifestCreated, k8s.ManifestConfigured:
+		return podRunningStyle
+	case k8s.ManifestReady, k8s.ManifestDeferred:
+		return podPendingStyle
+	case k8s.ManifestInvalid, k8s.ManifestFailed:
+		return podFailedStyle
+	default:
+		return portForwardStoppedStyle
+	}
+}
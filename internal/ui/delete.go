@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles-w-3/lobot/internal/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// maxListedDependents caps how many cascading dependents the delete confirmation lists
+	maxListedDependents = 12
+	// deleteTimeout bounds a delete or finalizer removal request
+	deleteTimeout = 30 * time.Second
+	// defaultGracePeriod is the grace period choice that keeps the resource's own setting
+	defaultGracePeriod = "default"
+)
+
+// deletePropagationPolicies are the propagation choices, starting with the API server's default
+var deletePropagationPolicies = []string{
+	string(metav1.DeletePropagationBackground),
+	string(metav1.DeletePropagationForeground),
+	string(metav1.DeletePropagationOrphan),
+}
+
+// deleteGracePeriods are the grace period choices; 0s deletes immediately
+var deleteGracePeriods = []string{defaultGracePeriod, "0s", "5s", "30s", "1m", "5m"}
+
+// ResourceActionFinishedMsg is sent when an action on a resource completes
+type ResourceActionFinishedMsg struct {
+	Action   string // Describes the action for error titles, e.g. "Delete"
+	Resource k8s.TrackedObject
+	Err      error
+	// TrackRollout follows the rollout the action started in the status bar
+	TrackRollout bool
+}
+
+// describeResource names a resource for confirmations, e.g. "Deployment default/web"
+func describeResource(resource k8s.TrackedObject) string {
+	if namespace := resource.GetNamespace(); namespace != "" {
+		return fmt.Sprintf("%s %s/%s", resource.GetKind(), namespace, resource.GetName())
+	}
+	return fmt.Sprintf("%s %s", resource.GetKind(), resource.GetName())
+}
+
+// ConfirmDeleteSelected asks for confirmation before deleting the selected resource
+// The confirmation lists what the owner graph says will be deleted along with it
+func (m *Model) ConfirmDeleteSelected() {
+	resource := m.GetSelectedResource()
+	if resource == nil {
+		return
+	}
+	if resource.GetRaw() == nil || resource.GetCategory() == k8s.ObjectCategoryHelm {
+		m.modal.ShowError("Cannot Delete", "Helm releases are managed by Helm and can't be deleted as Kubernetes resources.")
+		return
+	}
+
+	var lines []string
+	lines = append(lines, fmt.Sprintf("Delete %s?", describeResource(resource)))
+
+	if resource.GetCategory() == k8s.ObjectCategoryK8sResource {
+		graph := m.graphBuilder.BuildGraph(resource)
+		dependents := graph.GetDescendants(graph.Root)
+		if len(dependents) == 0 {
+			lines = append(lines, "Nothing else is owned by it.")
+		} else {
+			lines = append(lines, fmt.Sprintf("Background and Foreground propagation also delete %d dependent(s); Orphan leaves them:", len(dependents)))
+			for i, node := range dependents {
+				if i == maxListedDependents {
+					lines = append(lines, fmt.Sprintf("  …and %d more", len(dependents)-maxListedDependents))
+					break
+				}
+				lines = append(lines, "  "+describeResource(node.Resource))
+			}
+		}
+	}
+
+	if raw := resource.GetRaw(); raw.GetDeletionTimestamp() != nil {
+		lines = append(lines, "It is already terminating. If it is stuck, press X in the list to remove its finalizers.")
+	}
+
+	m.deleteTarget = resource
+	m.modal.ShowConfirm("Delete "+resource.GetKind(), strings.Join(lines, "\n"), ConfirmActionDelete, []ConfirmOption{
+		{Key: "p", Label: "Propagation", Values: deletePropagationPolicies},
+		{Key: "g", Label: "Grace period", Values: deleteGracePeriods},
+	})
+}
+
+// ConfirmRemoveFinalizers asks for confirmation before clearing the selected resource's finalizers
+func (m *Model) ConfirmRemoveFinalizers() {
+	resource := m.GetSelectedResource()
+	if resource == nil || resource.GetRaw() == nil {
+		return
+	}
+
+	raw := resource.GetRaw()
+	finalizers := raw.GetFinalizers()
+	if len(finalizers) == 0 {
+		m.modal.ShowInfo("No Finalizers", fmt.Sprintf("%s has no finalizers.", describeResource(resource)))
+		return
+	}
+
+	lines := []string{
+		fmt.Sprintf("Remove the finalizers of %s?", describeResource(resource)),
+		"Finalizers: " + strings.Join(finalizers, ", "),
+		"The controllers behind them won't get to clean up, which can leave orphaned external resources.",
+	}
+	if raw.GetDeletionTimestamp() == nil {
+		lines = append(lines, "It isn't being deleted, so this only skips that cleanup when it is deleted later.")
+	}
+
+	m.deleteTarget = resource
+	m.modal.ShowConfirm("Remove Finalizers", strings.Join(lines, "\n"), ConfirmActionRemoveFinalizers, nil)
+}
+
+// parseDeleteOptions converts the delete confirmation choices into delete options
+func parseDeleteOptions(propagation, gracePeriod string) (k8s.DeleteOptions, error) {
+	opts := k8s.DeleteOptions{Propagation: metav1.DeletionPropagation(propagation)}
+	if gracePeriod != defaultGracePeriod {
+		d, err := time.ParseDuration(gracePeriod)
+		if err != nil {
+			return opts, err
+		}
+		seconds := int64(d.Seconds())
+		opts.GracePeriodSeconds = &seconds
+	}
+	return opts, nil
+}
+
+// deleteResource deletes a resource with the options chosen in the confirmation
+func (m *Model) deleteResource(resource k8s.TrackedObject, propagation, gracePeriod string) tea.Cmd {
+	opts, err := parseDeleteOptions(propagation, gracePeriod)
+	if err != nil {
+		m.modal.ShowError("Invalid Grace Period", err.Error())
+		return nil
+	}
+
+	client := m.resourceService.GetClient()
+	recorder := m.recorder()
+	return m.guardWrite("Delete", func(_ *Model, base context.Context) tea.Cmd {
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(base, deleteTimeout)
+			defer cancel()
+			_, err := recorder.run(ctx, "Delete", resource, func(ctx context.Context) error {
+				return client.DeleteResource(ctx, resource, opts)
+			})
+			return ResourceActionFinishedMsg{Action: "Delete", Resource: resource, Err: err}
+		}
+	})
+}
+
+// removeFinalizers clears a resource's finalizers
+func (m *Model) removeFinalizers(resource k8s.TrackedObject) tea.Cmd {
+	client := m.resourceService.GetClient()
+	return m.guardWrite("Remove Finalizers", func(_ *Model, base context.Context) tea.Cmd {
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(base, deleteTimeout)
+			defer cancel()
+			return ResourceActionFinishedMsg{Action: "Remove Finalizers", Resource: resource, Err: client.RemoveFinalizers(ctx, resource)}
+		}
+	})
+}
+
+// ApplyConfirmed runs the action a confirmation modal was accepted for
+func (m *Model) ApplyConfirmed(msg ConfirmedMsg) tea.Cmd {
+	switch msg.Action {
+	case ConfirmActionBulkDelete, ConfirmActionBulkRestart:
+		return m.applyBulkConfirmed(msg)
+	case ConfirmActionRestart:
+		return m.applyWorkloadConfirmed(msg)
+	case ConfirmActionRevert:
+		return m.revertConfirmed()
+	}
+
+	target := m.deleteTarget
+	m.deleteTarget = nil
+	if target == nil {
+		return nil
+	}
+
+	switch msg.Action {
+	case ConfirmActionDelete:
+		return m.deleteResource(target, msg.Options[0], msg.Options[1])
+	case ConfirmActionRemoveFinalizers:
+		return m.removeFinalizers(target)
+	}
+	return nil
+}
+
+// showActionError reports a failed resource action, titled by the kind of API error
+func (m *Model) showActionError(msg ResourceActionFinishedMsg) {
+	errStr := msg.Err.Error()
+
+	title := msg.Action + " Failed"
+	switch {
+	case errors.Is(msg.Err, k8s.ErrWriteProtected):
+		title = "Write Protected"
+	case strings.HasPrefix(errStr, "not found:"):
+		title = "Resource Not Found"
+	case strings.HasPrefix(errStr, "forbidden:"):
+		title = "Permission Denied"
+	case strings.HasPrefix(errStr, "conflict:"):
+		title = "Conflict Detected"
+	}
+
+	m.modal.ShowError(title, fmt.Sprintf("%s: %s", describeResource(msg.Resource), errStr))
+}
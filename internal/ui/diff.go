@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles-w-3/lobot/internal/util"
+)
+
+// diffContextLines is how many unchanged lines are kept around each change
+const diffContextLines = 3
+
+var (
+	diffInsertStyle = lipgloss.NewStyle().
+			Foreground(colorSuccess)
+
+	diffDeleteStyle = lipgloss.NewStyle().
+			Foreground(colorDanger)
+
+	diffContextStyle = lipgloss.NewStyle().
+				Foreground(colorMuted)
+
+	diffHunkStyle = lipgloss.NewStyle().
+			Foreground(colorAccent)
+)
+
+// renderDiff colors a line diff, collapsing long runs of unchanged lines
+func renderDiff(diff []util.DiffLine) string {
+	// Keep unchanged lines that are within diffContextLines of a change
+	keep := make([]bool, len(diff))
+	for i, line := range diff {
+		if line.Op == util.DiffEqual {
+			continue
+		}
+		for j := max(0, i-diffContextLines); j <= min(len(diff)-1, i+diffContextLines); j++ {
+			keep[j] = true
+		}
+	}
+
+	var lines []string
+	skipped := 0
+	flushSkipped := func() {
+		if skipped > 0 {
+			lines = append(lines, diffHunkStyle.Render(fmt.Sprintf("@@ %d unchanged lines @@", skipped)))
+			skipped = 0
+		}
+	}
+
+	for i, line := range diff {
+		if !keep[i] {
+			skipped++
+			continue
+		}
+		flushSkipped()
+
+		switch line.Op {
+		case util.DiffInsert:
+			lines = append(lines, diffInsertStyle.Render("+ "+line.Text))
+		case util.DiffDelete:
+			lines = append(lines, diffDeleteStyle.Render("- "+line.Text))
+		default:
+			lines = append(lines, diffContextStyle.Render("  "+line.Text))
+		}
+	}
+	flushSkipped()
+
+	return strings.Join(lines, "\n")
+}
+
+// diffStats counts the inserted and deleted lines of a diff
+func diffStats(diff []util.DiffLine) (inserted, deleted int) {
+	for _, line := range diff {
+		switch line.Op {
+		case util.DiffInsert:
+			inserted++
+		case util.DiffDelete:
+			deleted++
+		}
+	}
+	return inserted, deleted
+}
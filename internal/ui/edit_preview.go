@@ -0,0 +1,241 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles-w-3/lobot/internal/k8s"
+	"github.com/miles-w-3/lobot/internal/util"
+)
+
+// editApplyTimeout bounds applying, merging and re-previewing an edit
+const editApplyTimeout = 30 * time.Second
+
+// EditPreviewMsg is sent when an edit has been dry-run and is ready to be confirmed
+type EditPreviewMsg struct {
+	Preview *k8s.EditPreview
+}
+
+// EditPreviewKeyMap defines key bindings for the edit preview
+type EditPreviewKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Apply  key.Binding
+	Merge  key.Binding
+	Force  key.Binding
+	Cancel key.Binding
+}
+
+// DefaultEditPreviewKeyMap returns the default key bindings for the edit preview
+func DefaultEditPreviewKeyMap() EditPreviewKeyMap {
+	return EditPreviewKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "scroll up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "scroll down"),
+		),
+		Apply: key.NewBinding(
+			key.WithKeys("y", "enter"),
+			key.WithHelp("y/enter", "apply"),
+		),
+		Merge: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "merge onto live"),
+		),
+		Force: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "force apply"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc/q", "discard"),
+		),
+	}
+}
+
+// ShortHelp returns a short list of key bindings
+func (k EditPreviewKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Apply, k.Merge, k.Force, k.Cancel}
+}
+
+// FullHelp returns the full list of key bindings organized by category
+func (k EditPreviewKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down},
+		{k.Apply, k.Merge, k.Force},
+		{k.Cancel},
+	}
+}
+
+// EditPreviewModel shows what a server-side apply of an edit would change, before it is applied
+type EditPreviewModel struct {
+	preview  *k8s.EditPreview
+	diff     []util.DiffLine
+	viewport viewport.Model
+	width    int
+	height   int
+	keys     EditPreviewKeyMap
+	help     help.Model
+}
+
+// NewEditPreviewModel creates the edit preview for a dry-run edit
+// Without a dry-run result (on a conflict) the saved manifest is compared to the live object instead
+func NewEditPreviewModel(preview *k8s.EditPreview, width, height int) *EditPreviewModel {
+	result := preview.DryRun
+	if result == nil {
+		result = preview.Edited
+	}
+
+	p := &EditPreviewModel{
+		preview: preview,
+		diff:    util.DiffText(k8s.ManifestYAML(preview.Live), k8s.ManifestYAML(result)),
+		keys:    DefaultEditPreviewKeyMap(),
+		help:    configureHelp(),
+	}
+
+	// Merging and forcing only make sense once the dry run has conflicted
+	p.keys.Merge.SetEnabled(preview.Conflict != nil)
+	p.keys.Force.SetEnabled(preview.Conflict != nil)
+
+	p.viewport = viewport.New(0, 0)
+	p.SetSize(width, height)
+	p.viewport.SetContent(renderDiff(p.diff))
+	return p
+}
+
+// SetSize updates the preview dimensions
+func (p *EditPreviewModel) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+	p.viewport.Width = width - 4
+	p.viewport.Height = max(1, height-8)
+}
+
+// Update scrolls the diff
+func (p *EditPreviewModel) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	p.viewport, cmd = p.viewport.Update(msg)
+	return cmd
+}
+
+// View renders the preview
+func (p *EditPreviewModel) View() string {
+	title := titleStyle.Render("Apply " + describeResource(p.preview.Resource))
+	inserted, deleted := diffStats(p.diff)
+	method := "server-side apply"
+	if p.preview.Update() {
+		method = "update"
+	}
+	details := lipgloss.NewStyle().Foreground(colorMuted).
+		Render(fmt.Sprintf("+%d -%d lines • %s as %q", inserted, deleted, method, k8s.FieldManager))
+	header := title + "  " + details
+
+	var notice string
+	switch {
+	case p.preview.Conflict != nil && p.preview.Update():
+		notice = logErrorStyle.Render("Conflict: " + p.preview.Conflict.Error() + "\n" +
+			"m replays your changes onto the live object; f overwrites the changes made on the cluster.")
+	case p.preview.Conflict != nil:
+		notice = logErrorStyle.Render("Conflict: " + p.preview.Conflict.Error() + "\n" +
+			"m replays your changes onto the live object, leaving the conflicting fields to their owners; " +
+			"f takes ownership of them.")
+	case p.preview.Merged && len(p.preview.Skipped) > 0:
+		notice = diffHunkStyle.Render("Your changes were merged onto the live object, leaving these fields to their owners: " +
+			strings.Join(p.preview.Skipped, ", ") + ". Showing the server's dry-run result.")
+	case p.preview.Merged:
+		notice = diffHunkStyle.Render("Your changes were merged onto the live object. Showing the server's dry-run result.")
+	default:
+		notice = diffHunkStyle.Render("Showing the server's dry-run result against the live object.")
+	}
+	if p.preview.Update() {
+		notice += "\n" + podPendingStyle.Render("Removes fields other field managers own, so it is sent as an update of the whole object: "+
+			strings.Join(p.preview.Removed, ", "))
+	}
+
+	body := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorBorder).
+		Width(p.width - 2).
+		Render(p.viewport.View())
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		lipgloss.NewStyle().Width(p.width).Render(notice),
+		body,
+		helpStyle.Render(p.help.ShortHelpView(p.keys.ShortHelp())),
+	)
+}
+
+// ShowEditPreview opens the preview of an edit, unless the server would change nothing
+func (m *Model) ShowEditPreview(preview *k8s.EditPreview) {
+	if preview.Conflict == nil && preview.DryRun != nil &&
+		!util.DiffChanged(util.DiffText(k8s.ManifestYAML(preview.Live), k8s.ManifestYAML(preview.DryRun))) {
+		m.modal.ShowInfo("No Changes", fmt.Sprintf("Applying the edit wouldn't change %s.", describeResource(preview.Resource)))
+		return
+	}
+
+	if m.viewMode != ViewModeEditPreview {
+		m.editReturnMode = m.viewMode
+	}
+	m.editPreview = NewEditPreviewModel(preview, m.width, m.height)
+	m.viewMode = ViewModeEditPreview
+}
+
+// ExitEditPreview closes the edit preview and returns to where the edit started
+func (m *Model) ExitEditPreview() {
+	m.editPreview = nil
+	m.viewMode = m.editReturnMode
+	if m.viewMode == ViewModeEditPreview {
+		m.viewMode = ViewModeNormal
+	}
+}
+
+// applyEdit applies the previewed edit
+// If something changed on the cluster since the preview, it is previewed again rather than failing
+func (m *Model) applyEdit(force bool) tea.Cmd {
+	preview := m.editPreview.preview
+	client := m.resourceService.GetClient()
+	recorder := m.recorder()
+	return m.guardWrite("Edit", func(_ *Model, base context.Context) tea.Cmd {
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(base, editApplyTimeout)
+			defer cancel()
+
+			backupPath, err := recorder.run(ctx, historyActionEdit, preview.Resource, func(ctx context.Context) error {
+				return client.ApplyEdit(ctx, preview, force)
+			})
+			if err != nil && !force && strings.HasPrefix(err.Error(), "conflict:") {
+				if refreshed, previewErr := client.PreviewEdit(ctx, preview.Resource, preview.Original, preview.Edited); previewErr == nil {
+					return EditPreviewMsg{Preview: refreshed}
+				}
+			}
+			return EditorFinishedMsg{Err: err, BackupPath: backupPath}
+		}
+	})
+}
+
+// mergeEdit replays the edit onto the live object and previews the result
+func (m *Model) mergeEdit() tea.Cmd {
+	preview := m.editPreview.preview
+	client := m.resourceService.GetClient()
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), editApplyTimeout)
+		defer cancel()
+
+		merged, err := client.MergeEdit(ctx, preview)
+		if err != nil {
+			return EditorFinishedMsg{Err: err}
+		}
+		return EditPreviewMsg{Preview: merged}
+	}
+}
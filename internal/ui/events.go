@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles-w-3/lobot/internal/k8s"
+	"github.com/miles-w-3/lobot/internal/util"
+)
+
+const (
+	// manifestEventLimit caps how many of the most recent events are shown below a manifest
+	manifestEventLimit = 25
+	// detailsEventLimit caps how many of the most recent events are shown in visualizer details
+	detailsEventLimit = 8
+)
+
+// EventLookup returns the events about a resource, oldest first
+type EventLookup func(k8s.TrackedObject) []k8s.EventInfo
+
+var eventReasonStyle = lipgloss.NewStyle().Bold(true)
+
+// renderEventTimeline renders the most recent events as a timeline, newest last
+// Each event is a header line (age, type, reason, count) followed by its message wrapped to width
+func renderEventTimeline(events []k8s.EventInfo, limit, width int) string {
+	if len(events) == 0 {
+		return helpStyle.Render("No events")
+	}
+
+	var b strings.Builder
+	if len(events) > limit {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("(%d older events not shown)", len(events)-limit)))
+		b.WriteString("\n")
+		events = events[len(events)-limit:]
+	}
+
+	messageStyle := lipgloss.NewStyle().
+		Foreground(colorMuted).
+		PaddingLeft(2).
+		Width(max(10, width))
+
+	for _, event := range events {
+		age := "?"
+		if !event.LastSeen.IsZero() {
+			age = util.FormatAge(time.Since(event.LastSeen))
+		}
+
+		header := fmt.Sprintf("%-4s %s %s", age,
+			GetStatusStyle(event.Type).Render(event.Type),
+			eventReasonStyle.Render(event.Reason))
+		if event.Count > 1 {
+			header += helpStyle.Render(fmt.Sprintf(" (x%d)", event.Count))
+		}
+
+		b.WriteString(header)
+		b.WriteString("\n")
+		if event.Message != "" {
+			b.WriteString(messageStyle.Render(strings.TrimSpace(event.Message)))
+			b.WriteString("\n")
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// renderManifestEvents renders the events section shown below a manifest
+func (m *Model) renderManifestEvents(resource k8s.TrackedObject) string {
+	if resource.GetCategory() == k8s.ObjectCategoryHelm {
+		return ""
+	}
+
+	events := m.resourceService.GetEventsForObject(resource)
+	header := titleStyle.Render(fmt.Sprintf("Events (%d)", len(events)))
+	return "\n" + header + "\n" + renderEventTimeline(events, manifestEventLimit, m.width-12)
+}
+
+// refreshManifestEvents re-renders the manifest's events section when new events arrive
+func (m *Model) refreshManifestEvents() {
+	if m.viewMode != ViewModeManifest || m.manifestResource == nil {
+		return
+	}
+
+	events := m.renderManifestEvents(m.manifestResource)
+	if events == m.manifestEvents {
+		return
+	}
+	m.manifestEvents = events
+	m.manifestViewport.SetContent(m.manifestContent + m.manifestEvents)
+}
+
+// renderDetailsEvents renders the events section of a visualizer details panel
+func renderDetailsEvents(lookup EventLookup, resource k8s.TrackedObject, width int) string {
+	if lookup == nil || resource.GetRaw() == nil || resource.GetCategory() == k8s.ObjectCategoryHelm {
+		return ""
+	}
+
+	events := lookup(resource)
+	return "\nEvents:\n" + renderEventTimeline(events, detailsEventLimit, width-2) + "\n"
+}
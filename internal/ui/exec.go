@@ -0,0 +1,254 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles-w-3/lobot/internal/k8s"
+	"github.com/muesli/cancelreader"
+	"golang.org/x/term"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// shellEnvVar names the environment variable that sets the preferred exec shell
+const shellEnvVar = "LOBOT_SHELL"
+
+// ExecFinishedMsg is sent when an interactive exec session ends
+type ExecFinishedMsg struct {
+	Err error
+}
+
+// execSession runs an interactive shell in a container while the TUI is suspended
+// It implements tea.ExecCommand so it can be run with tea.Exec
+type execSession struct {
+	ctx       context.Context
+	service   *k8s.ResourceService
+	namespace string
+	pod       string
+	container string
+	shells    []string
+
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// SetStdin sets the session input
+func (s *execSession) SetStdin(r io.Reader) { s.stdin = r }
+
+// SetStdout sets the session output
+func (s *execSession) SetStdout(w io.Writer) { s.stdout = w }
+
+// SetStderr sets the session error output
+func (s *execSession) SetStderr(w io.Writer) { s.stderr = w }
+
+// Run puts the local terminal in raw mode and attaches it to a shell in the container
+func (s *execSession) Run() error {
+	fmt.Fprintf(s.stdout, "Connecting to %s/%s (%s)...\r\n", s.namespace, s.pod, s.container)
+
+	var stdin io.Reader = s.stdin
+	var sizeQueue *terminalSizeQueue
+
+	if f, ok := s.stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		state, err := term.MakeRaw(int(f.Fd()))
+		if err != nil {
+			return fmt.Errorf("failed to put terminal in raw mode: %w", err)
+		}
+		defer term.Restore(int(f.Fd()), state)
+	}
+
+	if out, ok := s.stdout.(*os.File); ok && term.IsTerminal(int(out.Fd())) {
+		sizeQueue = newTerminalSizeQueue(int(out.Fd()))
+		defer sizeQueue.Stop()
+	}
+
+	// The exec stream copies stdin in the background; make that read cancellable so it
+	// doesn't swallow the first keypress after control returns to the TUI
+	if reader, err := cancelreader.NewReader(s.stdin); err == nil {
+		defer reader.Close()
+		defer reader.Cancel()
+		stdin = reader
+	}
+
+	opts := k8s.ExecOptions{
+		Namespace: s.namespace,
+		Pod:       s.pod,
+		Container: s.container,
+		Stdin:     stdin,
+		Stdout:    s.stdout,
+		Stderr:    s.stderr,
+		TTY:       true,
+	}
+	if sizeQueue != nil {
+		opts.SizeQueue = sizeQueue
+	}
+
+	return s.service.ExecShell(s.ctx, s.shells, opts)
+}
+
+// execShells returns the shells to try, starting with the one configured via LOBOT_SHELL
+func execShells() []string {
+	configured := os.Getenv(shellEnvVar)
+	if configured == "" {
+		return k8s.DefaultShells
+	}
+
+	shells := []string{configured}
+	for _, shell := range k8s.DefaultShells {
+		if shell != configured {
+			shells = append(shells, shell)
+		}
+	}
+	return shells
+}
+
+// terminalSizeQueue reports the local terminal size to the remote TTY, initially and on every resize
+// It implements remotecommand.TerminalSizeQueue
+type terminalSizeQueue struct {
+	fd       int
+	sizes    chan remotecommand.TerminalSize
+	done     chan struct{}
+	stopOnce sync.Once
+	mu       sync.Mutex
+	last     remotecommand.TerminalSize
+}
+
+// newTerminalSizeQueue creates a size queue for the terminal and starts watching for resizes
+func newTerminalSizeQueue(fd int) *terminalSizeQueue {
+	q := &terminalSizeQueue{
+		fd:    fd,
+		sizes: make(chan remotecommand.TerminalSize, 1),
+		done:  make(chan struct{}),
+	}
+	q.push()
+	go watchTerminalResize(q.done, q.push)
+	return q
+}
+
+// Next blocks until the terminal size changes, returning nil once the queue is stopped
+func (q *terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-q.sizes:
+		return &size
+	case <-q.done:
+		return nil
+	}
+}
+
+// Stop ends resize propagation
+func (q *terminalSizeQueue) Stop() {
+	q.stopOnce.Do(func() {
+		close(q.done)
+	})
+}
+
+// push queues the current terminal size if it changed, replacing any size not yet consumed
+func (q *terminalSizeQueue) push() {
+	width, height, err := term.GetSize(q.fd)
+	if err != nil {
+		return
+	}
+	size := remotecommand.TerminalSize{Width: uint16(width), Height: uint16(height)}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if size == q.last {
+		return
+	}
+	q.last = size
+
+	select {
+	case <-q.sizes:
+	default:
+	}
+	q.sizes <- size
+}
+
+// isShellExit reports whether an exec error is just the remote shell exiting with a non-zero code
+func isShellExit(err error) bool {
+	var exitErr utilexec.ExitError
+	return errors.As(err, &exitErr) && exitErr.Exited()
+}
+
+// ExecIntoSelectedPod opens a shell in the selected pod, asking for a container when there is more than one
+func (m *Model) ExecIntoSelectedPod() tea.Cmd {
+	resource := m.GetSelectedResource()
+	if resource == nil || resource.GetKind() != "Pod" || resource.GetRaw() == nil {
+		return nil
+	}
+
+	// The displayed status may name a failing container, so check the phase itself
+	if phase, _, _ := unstructured.NestedString(resource.GetRaw().Object, "status", "phase"); phase != "Running" {
+		m.modal.ShowWarning("Pod Not Running", fmt.Sprintf("Cannot exec into %s: pod is %s.", resource.GetName(), resource.GetStatus()))
+		return nil
+	}
+
+	// Init containers have normally completed, so only regular and ephemeral containers are offered
+	var containers []k8s.ContainerInfo
+	for _, c := range k8s.GetPodContainers(resource) {
+		if !c.Init {
+			containers = append(containers, c)
+		}
+	}
+
+	switch len(containers) {
+	case 0:
+		return nil
+	case 1:
+		return m.execIntoPod(resource, containers[0].Name)
+	}
+
+	m.execPod = resource
+	choices := make([]string, 0, len(containers))
+	for _, c := range containers {
+		choices = append(choices, c.DisplayName())
+	}
+	m.selector = NewExecContainerSelector(choices)
+	return m.selector.Init()
+}
+
+// ApplyExecContainerSelection starts a shell in the container picked for the pending exec
+func (m *Model) ApplyExecContainerSelection(displayName string) tea.Cmd {
+	pod := m.execPod
+	m.execPod = nil
+	if pod == nil {
+		return nil
+	}
+
+	for _, c := range k8s.GetPodContainers(pod) {
+		if c.DisplayName() == displayName {
+			return m.execIntoPod(pod, c.Name)
+		}
+	}
+	return nil
+}
+
+// execIntoPod suspends the TUI and runs an interactive shell in the container
+func (m *Model) execIntoPod(pod k8s.TrackedObject, container string) tea.Cmd {
+	return m.guardWrite("Exec", func(_ *Model, base context.Context) tea.Cmd {
+		session := &execSession{
+			ctx:       base,
+			service:   m.resourceService,
+			namespace: pod.GetNamespace(),
+			pod:       pod.GetName(),
+			container: container,
+			shells:    execShells(),
+		}
+
+		return tea.Exec(session, func(err error) tea.Msg {
+			if err != nil && isShellExit(err) {
+				// The user's shell exiting non-zero is not an error worth reporting
+				err = nil
+			}
+			return ExecFinishedMsg{Err: err}
+		})
+	})
+}
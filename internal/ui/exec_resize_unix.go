@@ -0,0 +1,25 @@
+//go:build !windows
+
+package ui
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchTerminalResize calls onResize whenever the terminal is resized until done is closed
+func watchTerminalResize(done <-chan struct{}, onResize func()) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGWINCH)
+	defer signal.Stop(sigs)
+
+	for {
+		select {
+		case <-sigs:
+			onResize()
+		case <-done:
+			return
+		}
+	}
+}
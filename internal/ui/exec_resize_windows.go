@@ -0,0 +1,21 @@
+//go:build windows
+
+package ui
+
+import "time"
+
+// watchTerminalResize polls for terminal size changes until done is closed
+// Windows has no SIGWINCH, so the size is checked periodically instead
+func watchTerminalResize(done <-chan struct{}, onResize func()) {
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			onResize()
+		case <-done:
+			return
+		}
+	}
+}
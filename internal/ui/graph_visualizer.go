@@ -36,10 +36,12 @@ type GraphVisualizerModel struct {
 	rootResource    k8s.TrackedObject
 	keys            GraphVisualizerKeyMap
 	help            help.Model
+	events          EventLookup
+	detailsNode     *graph.Node // Node shown in the details panel
 }
 
 // NewGraphVisualizerModel creates a new graph visualizer
-func NewGraphVisualizerModel(resourceGraph *graph.ResourceGraph, width, height int) *GraphVisualizerModel {
+func NewGraphVisualizerModel(resourceGraph *graph.ResourceGraph, events EventLookup, width, height int) *GraphVisualizerModel {
 	initStart := time.Now()
 
 	detailsWidth := 35
@@ -85,6 +87,7 @@ func NewGraphVisualizerModel(resourceGraph *graph.ResourceGraph, width, height i
 		rootResource:    resourceGraph.Root.Resource,
 		keys:            DefaultGraphVisualizerKeyMap(),
 		help:            help.New(),
+		events:          events,
 	}
 
 	contentStart := time.Now()
@@ -235,23 +238,9 @@ func (m *GraphVisualizerModel) updateViewportContent() {
 func (m *GraphVisualizerModel) renderNodeBox(node *graph.Node, selected bool) string {
 	res := node.Resource
 
-	// Determine border color from status
-	var borderColor lipgloss.Color
-	status := strings.ToLower(res.GetStatus())
-	if strings.Contains(status, "running") ||
-		strings.Contains(status, "ready") ||
-		strings.Contains(status, "active") ||
-		strings.Contains(status, "deployed") {
-		borderColor = ColorSuccess
-	} else if strings.Contains(status, "pending") ||
-		strings.Contains(status, "creating") {
-		borderColor = ColorWarning
-	} else if strings.Contains(status, "failed") ||
-		strings.Contains(status, "error") {
-		borderColor = ColorDanger
-	} else {
-		borderColor = ColorMuted
-	}
+	// Border color follows the evaluated status
+	statusStyle := GetSeverityStyle(res.GetSeverity())
+	borderColor := statusStyle.GetForeground()
 
 	// Box style
 	boxStyle := lipgloss.NewStyle().
@@ -278,8 +267,6 @@ func (m *GraphVisualizerModel) renderNodeBox(node *graph.Node, selected bool) st
 		name = name[:nodeWidth-7] + "..."
 	}
 
-	statusStyle := lipgloss.NewStyle().Foreground(borderColor)
-
 	content := lipgloss.JoinVertical(
 		lipgloss.Center,
 		kindStyle.Render(res.GetKind()),
@@ -306,7 +293,7 @@ func (m *GraphVisualizerModel) updateDetailsPanel(node *graph.Node) {
 	if res.GetNamespace() != "" {
 		details.WriteString(fmt.Sprintf("Namespace: %s\n", res.GetNamespace()))
 	}
-	details.WriteString(fmt.Sprintf("Status: %s\n", res.GetStatus()))
+	details.WriteString(fmt.Sprintf("Status: %s\n", GetSeverityStyle(res.GetSeverity()).Render(res.GetStatus())))
 
 	// Helm-specific details
 	if helmRes, ok := res.(*k8s.HelmRelease); ok && helmRes.HelmRevision > 0 {
@@ -336,6 +323,10 @@ func (m *GraphVisualizerModel) updateDetailsPanel(node *graph.Node) {
 		}
 	}
 
+	// Show recent events about the resource
+	details.WriteString(renderDetailsEvents(m.events, res, m.detailsViewport.Width))
+
+	m.detailsNode = node
 	m.detailsViewport.SetContent(details.String())
 }
 
@@ -470,6 +461,14 @@ func (m *GraphVisualizerModel) navigateRight() {
 	}
 }
 
+// SelectedNode returns the currently selected graph node, or nil if the graph is empty
+func (m *GraphVisualizerModel) SelectedNode() *graph.Node {
+	if m.selectedIndex < 0 || m.selectedIndex >= len(m.flattenedNodes) {
+		return nil
+	}
+	return m.flattenedNodes[m.selectedIndex]
+}
+
 func (m *GraphVisualizerModel) ensureSelectedVisible() {
 	if m.selectedIndex < 0 || m.selectedIndex >= len(m.flattenedNodes) {
 		return
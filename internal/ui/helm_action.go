@@ -0,0 +1,251 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles-w-3/lobot/internal/k8s"
+)
+
+const (
+	// helmPlanTimeout bounds comparing a release's resources with the cluster and dry-running them
+	helmPlanTimeout = 60 * time.Second
+	// helmStepTimeout bounds one step of a rollback or uninstall
+	helmStepTimeout = 60 * time.Second
+	// helmWaitTimeout bounds waiting for rollouts to finish and deleted resources to be gone
+	helmWaitTimeout = 5 * time.Minute
+	// helmWaitInterval is how often the steps waiting for the cluster are checked
+	helmWaitInterval = 2 * time.Second
+)
+
+// HelmActionPlanMsg is sent when a rollback or uninstall has been previewed
+type HelmActionPlanMsg struct {
+	Plan    *k8s.HelmActionPlan
+	Client  *k8s.Client
+	History *HelmHistoryPanelModel // History the preview was asked for from; nil for the resource list
+	Release string                 // Namespace and name of the release
+	Err     error
+}
+
+// HelmStepMsg is sent when a step of a rollback or uninstall has run
+type HelmStepMsg struct {
+	Panel   *HelmActionPanelModel
+	Index   int
+	State   k8s.HelmStepState
+	Message string
+	Err     error
+}
+
+// HelmWaitMsg reports on the steps of a rollback or uninstall waiting for the cluster
+type HelmWaitMsg struct {
+	Panel  *HelmActionPanelModel
+	Checks []HelmStepMsg
+}
+
+// PlanHelmRollback previews rolling the release of the open history back to the selected revision
+func (m *Model) PlanHelmRollback() tea.Cmd {
+	target := m.helmHistory.Selected()
+	if target == nil {
+		return nil
+	}
+	history := m.helmHistory
+	revisions := history.revisions
+	release := helmReleaseKey(history.release)
+	client := m.resourceService.GetClient()
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), helmPlanTimeout)
+		defer cancel()
+
+		plan, err := client.PlanHelmRollback(ctx, revisions, target)
+		return HelmActionPlanMsg{Plan: plan, Client: client, History: history, Release: release, Err: err}
+	}
+}
+
+// PlanHelmUninstall previews uninstalling a Helm release
+func (m *Model) PlanHelmUninstall(release *k8s.HelmRelease) tea.Cmd {
+	revisions := m.resourceService.GetHelmReleaseHistory(release)
+	if len(revisions) == 0 {
+		m.modal.ShowInfo("No History", "No revisions were found for "+release.GetNamespace()+"/"+release.GetName()+".")
+		return nil
+	}
+	var history *HelmHistoryPanelModel
+	if m.viewMode == ViewModeHelmHistory {
+		history = m.helmHistory
+	}
+	key := helmReleaseKey(release)
+	client := m.resourceService.GetClient()
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), helmPlanTimeout)
+		defer cancel()
+
+		plan, err := client.PlanHelmUninstall(ctx, revisions)
+		return HelmActionPlanMsg{Plan: plan, Client: client, History: history, Release: key, Err: err}
+	}
+}
+
+// helmReleaseKey identifies a release by its namespace and name
+func helmReleaseKey(release *k8s.HelmRelease) string {
+	return release.GetNamespace() + "/" + release.GetName()
+}
+
+// helmActionPlanCurrent reports whether the view a preview was asked for from is still open
+// Planning takes a while, and a preview for a history or context left since would act on what is no longer shown
+func (m *Model) helmActionPlanCurrent(msg HelmActionPlanMsg) bool {
+	if msg.Client != m.resourceService.GetClient() {
+		return false
+	}
+	if msg.History != nil {
+		return m.viewMode == ViewModeHelmHistory && m.helmHistory == msg.History
+	}
+	release, ok := m.GetSelectedResource().(*k8s.HelmRelease)
+	return m.viewMode == ViewModeNormal && ok && helmReleaseKey(release) == msg.Release
+}
+
+// ShowHelmActionPlan opens the preview of a rollback or uninstall
+// A preview arriving after its history, release or context was left is dropped
+func (m *Model) ShowHelmActionPlan(msg HelmActionPlanMsg) {
+	if !m.helmActionPlanCurrent(msg) {
+		m.logger.Debug("Dropping Helm action preview for a view no longer open", "release", msg.Release)
+		return
+	}
+	if msg.Err != nil {
+		m.modal.ShowError("Preview Failed", msg.Err.Error())
+		return
+	}
+	m.helmAction = NewHelmActionPanelModel(msg.Plan, msg.Client, m.width, m.height)
+	m.viewMode = ViewModeHelmAction
+}
+
+// ApplyHelmAction starts running the previewed steps, one at a time in order
+func (m *Model) ApplyHelmAction() tea.Cmd {
+	panel := m.helmAction
+	if panel == nil || panel.applying {
+		return nil
+	}
+	return m.guardWrite(panel.plan.Action.String(), func(m *Model, base context.Context) tea.Cmd {
+		panel.applying = true
+		panel.writeCtx = base
+		panel.startedAt = time.Now()
+		panel.keys.Apply.SetEnabled(false)
+
+		plan := panel.plan
+		m.logger.Info("Starting Helm action", "action", plan.Action, "release", plan.Name, "namespace", plan.Namespace)
+		return m.runNextHelmStep(panel)
+	})
+}
+
+// runNextHelmStep runs the next pending step of the panel, or starts waiting on the cluster once every step has run
+// Release records wait for the resources to settle first, so a rollout that fails or times out is recorded
+// The panel's own client is used, so switching contexts meanwhile doesn't send the remaining steps elsewhere
+func (m *Model) runNextHelmStep(panel *HelmActionPanelModel) tea.Cmd {
+	steps := panel.plan.Steps
+	for panel.next < len(steps) {
+		step := steps[panel.next]
+		if step.State != k8s.HelmStepPending {
+			panel.next++
+			continue
+		}
+		if _, _, waiting := panel.plan.Progress(); step.IsRecord() && waiting > 0 {
+			panel.waitStarted = time.Now()
+			return m.checkHelmSteps(panel)
+		}
+
+		index := panel.next
+		step.State = k8s.HelmStepRunning
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(panel.writeCtx, helmStepTimeout)
+			defer cancel()
+
+			state, message, err := panel.client.RunHelmStep(ctx, panel.plan, step)
+			return HelmStepMsg{Panel: panel, Index: index, State: state, Message: message, Err: err}
+		}
+	}
+
+	panel.waitStarted = time.Now()
+	return m.checkHelmSteps(panel)
+}
+
+// ApplyHelmStep records how a step went and moves on to the next
+func (m *Model) ApplyHelmStep(msg HelmStepMsg) tea.Cmd {
+	panel := msg.Panel
+	step := panel.plan.Steps[msg.Index]
+	step.State, step.Message, step.Err = msg.State, msg.Message, msg.Err
+	panel.next = msg.Index + 1
+	return m.runNextHelmStep(panel)
+}
+
+// checkHelmSteps checks on the steps waiting for the cluster after a short wait
+// When none are left, the steps held back for them are run, or the action finishes
+// Steps still waiting when helmWaitTimeout runs out fail
+func (m *Model) checkHelmSteps(panel *HelmActionPanelModel) tea.Cmd {
+	var waiting []int
+	for i, step := range panel.plan.Steps {
+		if step.State == k8s.HelmStepWaiting {
+			waiting = append(waiting, i)
+		}
+	}
+	if len(waiting) == 0 {
+		return m.continueHelmAction(panel)
+	}
+
+	if time.Since(panel.waitStarted) > helmWaitTimeout {
+		for _, i := range waiting {
+			step := panel.plan.Steps[i]
+			step.State = k8s.HelmStepFailed
+			step.Err = fmt.Errorf("timed out after %s: %s", helmWaitTimeout, step.Message)
+		}
+		return m.continueHelmAction(panel)
+	}
+
+	return tea.Tick(helmWaitInterval, func(time.Time) tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), helmStepTimeout)
+		defer cancel()
+
+		checks := make([]HelmStepMsg, 0, len(waiting))
+		for _, i := range waiting {
+			state, message, err := panel.client.CheckHelmStep(ctx, panel.plan.Steps[i])
+			checks = append(checks, HelmStepMsg{Panel: panel, Index: i, State: state, Message: message, Err: err})
+		}
+		return HelmWaitMsg{Panel: panel, Checks: checks}
+	})
+}
+
+// ApplyHelmWait records the progress of the steps waiting for the cluster and keeps checking
+func (m *Model) ApplyHelmWait(msg HelmWaitMsg) tea.Cmd {
+	for _, check := range msg.Checks {
+		step := msg.Panel.plan.Steps[check.Index]
+		step.State, step.Message, step.Err = check.State, check.Message, check.Err
+	}
+	return m.checkHelmSteps(msg.Panel)
+}
+
+// continueHelmAction runs the steps left once nothing is waiting for the cluster, or finishes the action
+func (m *Model) continueHelmAction(panel *HelmActionPanelModel) tea.Cmd {
+	if panel.next < len(panel.plan.Steps) {
+		return m.runNextHelmStep(panel)
+	}
+	m.finishHelmAction(panel)
+	return nil
+}
+
+// finishHelmAction records that every step of a rollback or uninstall has finished
+func (m *Model) finishHelmAction(panel *HelmActionPanelModel) {
+	panel.finishedAt = time.Now()
+	plan := panel.plan
+	_, failed, _ := plan.Progress()
+	m.logger.Info("Helm action finished", "action", plan.Action, "release", plan.Name, "namespace", plan.Namespace, "failed", failed)
+	m.UpdateResources()
+}
+
+// ExitHelmActionMode closes the rollback or uninstall panel, going back to the release history if it is open
+// Steps still running continue in the background
+func (m *Model) ExitHelmActionMode() {
+	m.helmAction = nil
+	if m.helmHistory != nil {
+		m.viewMode = ViewModeHelmHistory
+		return
+	}
+	m.viewMode = ViewModeNormal
+}
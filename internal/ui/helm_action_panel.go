@@ -0,0 +1,317 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles-w-3/lobot/internal/k8s"
+	"github.com/miles-w-3/lobot/internal/util"
+)
+
+// HelmActionKeyMap defines key bindings for the Helm rollback and uninstall panel
+type HelmActionKeyMap struct {
+	Up    key.Binding
+	Down  key.Binding
+	Apply key.Binding
+	Diff  key.Binding
+	Back  key.Binding
+}
+
+// DefaultHelmActionKeyMap returns the default key bindings for the Helm rollback and uninstall panel
+func DefaultHelmActionKeyMap() HelmActionKeyMap {
+	return HelmActionKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "move up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "move down"),
+		),
+		Apply: key.NewBinding(
+			key.WithKeys("y", "enter"),
+			key.WithHelp("y/enter", "apply"),
+		),
+		Diff: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "view diff"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc/q", "back"),
+		),
+	}
+}
+
+// ShortHelp returns a short list of key bindings
+func (k HelmActionKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Apply, k.Diff, k.Back}
+}
+
+// FullHelp returns the full list of key bindings organized by category
+func (k HelmActionKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down},
+		{k.Apply, k.Diff, k.Back},
+	}
+}
+
+// HelmActionPanelModel previews the steps of a Helm rollback or uninstall, then follows each to completion
+type HelmActionPanelModel struct {
+	plan   *k8s.HelmActionPlan
+	client *k8s.Client // Client of the context the plan was made in
+	next   int         // Position of the next step to run
+	// writeCtx is the context steps are run with, which carries the confirmation a protected context needs
+	writeCtx    context.Context
+	applying    bool // Set once running the steps has started
+	startedAt   time.Time
+	waitStarted time.Time // When the resources had been sent and waiting on the cluster began
+	finishedAt  time.Time
+	cursor      int
+	offset      int
+	width       int
+	height      int
+	keys        HelmActionKeyMap
+	help        help.Model
+	showingDiff bool
+	viewport    viewport.Model
+}
+
+// NewHelmActionPanelModel creates the panel for a previewed rollback or uninstall
+func NewHelmActionPanelModel(plan *k8s.HelmActionPlan, client *k8s.Client, width, height int) *HelmActionPanelModel {
+	p := &HelmActionPanelModel{
+		plan:     plan,
+		client:   client,
+		keys:     DefaultHelmActionKeyMap(),
+		help:     configureHelp(),
+		viewport: viewport.New(0, 0),
+	}
+	if plan.Action == k8s.HelmActionRollback {
+		p.keys.Apply.SetHelp("y/enter", "roll back")
+	} else {
+		p.keys.Apply.SetHelp("y/enter", "uninstall")
+	}
+	p.viewport.SetContent(renderHelmDiff(plan.Diff))
+	p.SetSize(width, height)
+	return p
+}
+
+// SetSize updates the panel dimensions
+func (p *HelmActionPanelModel) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+	p.viewport.Width = width - 4
+	p.viewport.Height = max(1, height-6)
+}
+
+// ShowingDiff reports whether the diff is shown in place of the steps
+func (p *HelmActionPanelModel) ShowingDiff() bool {
+	return p.showingDiff
+}
+
+// ToggleDiff switches between the steps and how the live resources will change
+func (p *HelmActionPanelModel) ToggleDiff() {
+	p.showingDiff = !p.showingDiff
+	p.viewport.GotoTop()
+	p.keys.Apply.SetEnabled(!p.showingDiff && !p.applying)
+	if p.showingDiff {
+		p.keys.Diff.SetHelp("d", "view steps")
+	} else {
+		p.keys.Diff.SetHelp("d", "view diff")
+	}
+}
+
+// visibleRows is how many steps fit in the panel body, below the header row and above the step details
+func (p *HelmActionPanelModel) visibleRows() int {
+	return max(1, p.height-11)
+}
+
+// Update moves the cursor, or scrolls the diff
+func (p *HelmActionPanelModel) Update(msg tea.KeyMsg) tea.Cmd {
+	if p.showingDiff {
+		var cmd tea.Cmd
+		p.viewport, cmd = p.viewport.Update(msg)
+		return cmd
+	}
+
+	switch {
+	case key.Matches(msg, p.keys.Up):
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case key.Matches(msg, p.keys.Down):
+		if p.cursor < len(p.plan.Steps)-1 {
+			p.cursor++
+		}
+	}
+
+	// Keep the cursor in view
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+p.visibleRows() {
+		p.offset = p.cursor - p.visibleRows() + 1
+	}
+	return nil
+}
+
+// View renders the panel
+func (p *HelmActionPanelModel) View() string {
+	plan := p.plan
+	release := plan.Namespace + "/" + plan.Name
+
+	title := "Uninstall " + release
+	if plan.Action == k8s.HelmActionRollback {
+		title = fmt.Sprintf("Roll back %s to revision %d", release, plan.Target.Revision)
+	}
+	header := titleStyle.Render(title) + "  " + lipgloss.NewStyle().Foreground(colorMuted).Render(p.details())
+
+	content := p.renderSteps()
+	if p.showingDiff {
+		content = p.viewport.View()
+	}
+	body := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorBorder).
+		Width(p.width - 2).
+		Height(p.height - 5).
+		Render(content)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		body,
+		helpStyle.Render(p.help.ShortHelpView(p.keys.ShortHelp())),
+	)
+}
+
+// details summarizes the plan before it runs, and its progress after
+func (p *HelmActionPanelModel) details() string {
+	plan := p.plan
+	finished, failed, waiting := plan.Progress()
+
+	var details []string
+	if p.applying {
+		details = append(details, fmt.Sprintf("%d/%d steps finished", finished, len(plan.Steps)))
+		if waiting > 0 {
+			details = append(details, fmt.Sprintf("%d waiting", waiting))
+		}
+		if failed > 0 {
+			details = append(details, fmt.Sprintf("%d failed", failed))
+		}
+		elapsed := time.Since(p.startedAt)
+		if !p.finishedAt.IsZero() {
+			elapsed = p.finishedAt.Sub(p.startedAt)
+			details = append(details, "finished in "+util.FormatAge(elapsed))
+		} else {
+			details = append(details, util.FormatAge(elapsed))
+		}
+		return strings.Join(details, " • ")
+	}
+
+	counts := make(map[k8s.HelmStepAction]int)
+	invalid, records := 0, 0
+	for _, step := range plan.Steps {
+		switch {
+		case step.IsRecord():
+			records++
+		case step.State == k8s.HelmStepFailed:
+			invalid++
+		case step.State == k8s.HelmStepPending || step.Action == k8s.HelmStepKeep || step.Action == k8s.HelmStepUnchanged:
+			counts[step.Action]++
+		}
+	}
+	for _, action := range []k8s.HelmStepAction{k8s.HelmStepCreate, k8s.HelmStepUpdate, k8s.HelmStepDelete} {
+		if counts[action] > 0 {
+			details = append(details, fmt.Sprintf("%d to %s", counts[action], action))
+		}
+	}
+	if len(details) == 0 {
+		details = append(details, "no resources change")
+	}
+	if counts[k8s.HelmStepKeep] > 0 {
+		details = append(details, fmt.Sprintf("%d kept", counts[k8s.HelmStepKeep]))
+	}
+	if counts[k8s.HelmStepUnchanged] > 0 {
+		details = append(details, fmt.Sprintf("%d unchanged", counts[k8s.HelmStepUnchanged]))
+	}
+	if invalid > 0 {
+		details = append(details, fmt.Sprintf("%d can't be applied", invalid))
+	}
+	details = append(details, fmt.Sprintf("%d release records", records))
+	return strings.Join(details, " • ")
+}
+
+// renderSteps renders the steps as a table in the order they run, followed by the details of the selected one
+func (p *HelmActionPanelModel) renderSteps() string {
+	actionWidth := 9
+	stateWidth := 8
+	resourceWidth := max(20, p.width-6-actionWidth-stateWidth-2)
+
+	header := padCell("ACTION", actionWidth) + " " + padCell("STATE", stateWidth) + " " + padCell("RESOURCE", resourceWidth)
+	lines := []string{tableHeaderStyle.UnsetPadding().Render(header)}
+
+	steps := p.plan.Steps
+	end := min(len(steps), p.offset+p.visibleRows())
+	for i := p.offset; i < end; i++ {
+		step := steps[i]
+		actionCell := padCell(step.Action.String(), actionWidth)
+		stateCell := padCell(step.State.String(), stateWidth)
+		resourceCell := padCell(step.Describe(), resourceWidth)
+
+		if i == p.cursor {
+			lines = append(lines, portForwardSelectedStyle.Render(actionCell+" "+stateCell+" "+resourceCell))
+			continue
+		}
+		lines = append(lines, helmStepActionStyle(step.Action).Render(actionCell)+" "+
+			helmStepStateStyle(step.State).Render(stateCell)+" "+resourceCell)
+	}
+
+	if p.cursor < len(steps) {
+		step := steps[p.cursor]
+		switch {
+		case step.Err != nil:
+			lines = append(lines, "", logErrorStyle.Render("Error: "+step.Err.Error()))
+		case step.Message != "":
+			lines = append(lines, "", diffHunkStyle.Render(step.Message))
+		}
+	}
+	if hooks := p.plan.SkippedHooks; len(hooks) > 0 {
+		lines = append(lines, "", podPendingStyle.Render("Hooks lobot won't run: "+strings.Join(hooks, ", ")))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// helmStepActionStyle returns the style for what a step does
+func helmStepActionStyle(action k8s.HelmStepAction) lipgloss.Style {
+	switch action {
+	case k8s.HelmStepCreate:
+		return diffInsertStyle
+	case k8s.HelmStepDelete:
+		return diffDeleteStyle
+	case k8s.HelmStepUpdate:
+		return diffHunkStyle
+	default:
+		return diffContextStyle
+	}
+}
+
+// helmStepStateStyle returns the style for a step state
+func helmStepStateStyle(state k8s.HelmStepState) lipgloss.Style {
+	switch state {
+	case k8s.HelmStepDone:
+		return podRunningStyle
+	case k8s.HelmStepRunning, k8s.HelmStepWaiting:
+		return podPendingStyle
+	case k8s.HelmStepFailed:
+		return podFailedStyle
+	default:
+		return portForwardStoppedStyle
+	}
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miles-w-3/lobot/internal/k8s"
+	"sigs.k8s.io/yaml"
+)
+
+// helmDetailTab is one tab of a Helm revision's details
+type helmDetailTab struct {
+	Title   string
+	Content string
+}
+
+// helmDetailTabs returns the tabs showing what a Helm revision was released with
+func helmDetailTabs(revision *k8s.HelmReleaseRevision) []helmDetailTab {
+	return []helmDetailTab{
+		{Title: "Manifest", Content: orPlaceholder(strings.TrimSpace(revision.Manifest), "The revision rendered no resources.")},
+		{Title: "Values", Content: renderHelmValues(revision.Values, "No values were supplied; the chart defaults were used.")},
+		{Title: "Chart Defaults", Content: renderHelmValues(revision.ChartValues, "The chart has no default values.")},
+		{Title: "Chart", Content: renderHelmChart(revision)},
+		{Title: "Notes", Content: orPlaceholder(strings.TrimSpace(revision.Notes), "The chart has no notes.")},
+		{Title: fmt.Sprintf("Hooks (%d)", len(revision.Hooks)), Content: renderHelmHooks(revision)},
+	}
+}
+
+// renderHelmValues renders values as YAML, or a placeholder when there are none
+func renderHelmValues(values map[string]interface{}, placeholder string) string {
+	if len(values) == 0 {
+		return diffContextStyle.Render(placeholder)
+	}
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return logErrorStyle.Render("Failed to encode values: " + err.Error())
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
+
+// renderHelmChart renders the metadata of a revision's chart and its dependencies
+func renderHelmChart(revision *k8s.HelmReleaseRevision) string {
+	lines := []string{
+		"Chart:        " + orDash(revision.Chart),
+		"Version:      " + orDash(revision.ChartVersion),
+		"App version:  " + orDash(revision.AppVersion),
+		"Description:  " + orDash(revision.ChartDescription),
+	}
+
+	lines = append(lines, "", diffHunkStyle.Render(fmt.Sprintf("Dependencies (%d)", len(revision.Dependencies))))
+	for _, dependency := range revision.Dependencies {
+		line := fmt.Sprintf("  %s %s", dependency.Name, orDash(dependency.Version))
+		if dependency.Alias != "" {
+			line += " as " + dependency.Alias
+		}
+		if dependency.Repository != "" {
+			line += " from " + dependency.Repository
+		}
+		if dependency.Condition != "" {
+			line += fmt.Sprintf(" (if %s)", dependency.Condition)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderHelmHooks renders each hook of a revision with when it runs, how it last ran, and its manifest
+func renderHelmHooks(revision *k8s.HelmReleaseRevision) string {
+	if len(revision.Hooks) == 0 {
+		return diffContextStyle.Render("The chart has no hooks.")
+	}
+
+	var sections []string
+	for _, hook := range revision.Hooks {
+		lines := []string{
+			diffHunkStyle.Bold(true).Render(fmt.Sprintf("%s %s", hook.Kind, hook.Name)),
+			"Events:           " + orDash(strings.Join(hook.Events, ", ")),
+			fmt.Sprintf("Weight:           %d", hook.Weight),
+			"Delete policies:  " + orDash(strings.Join(hook.DeletePolicies, ", ")),
+		}
+
+		lastRun := "never"
+		if hook.LastRun.Phase != "" {
+			lastRun = hook.LastRun.Phase
+			if !hook.LastRun.StartedAt.IsZero() {
+				lastRun += " at " + hook.LastRun.StartedAt.Local().Format("2006-01-02 15:04:05")
+			}
+		}
+		lines = append(lines, "Last run:         "+lastRun)
+		if hook.Path != "" {
+			lines = append(lines, "Template:         "+hook.Path)
+		}
+		lines = append(lines, "", diffContextStyle.Render(strings.TrimSpace(hook.Manifest)))
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// orPlaceholder returns text, or a muted placeholder when it is empty
+func orPlaceholder(text, placeholder string) string {
+	if text == "" {
+		return diffContextStyle.Render(placeholder)
+	}
+	return text
+}
+
+// orDash returns text, or "-" when it is empty
+func orDash(text string) string {
+	if text == "" {
+		return "-"
+	}
+	return text
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles-w-3/lobot/internal/k8s"
+)
+
+// helmLiveDiffTimeout bounds reading a release's live objects to compare with a revision
+const helmLiveDiffTimeout = 60 * time.Second
+
+// HelmDiffMsg is sent when a revision has been compared with the live cluster
+type HelmDiffMsg struct {
+	Panel *HelmHistoryPanelModel
+	Diff  *k8s.HelmDiff
+	Err   error
+}
+
+// OpenHelmHistory opens the revision history of a Helm release
+func (m *Model) OpenHelmHistory(release *k8s.HelmRelease) {
+	revisions := m.resourceService.GetHelmReleaseHistory(release)
+	if len(revisions) == 0 {
+		m.modal.ShowInfo("No History", "No revisions were found for "+release.GetNamespace()+"/"+release.GetName()+".")
+		return
+	}
+	m.helmHistory = NewHelmHistoryPanelModel(release, revisions, m.width, m.height)
+	m.helmHistory.SetWritable(m.writeMode() != k8s.WriteDenied)
+	m.viewMode = ViewModeHelmHistory
+}
+
+// refreshHelmHistory updates the open Helm release history with the revisions decoded from the latest release Secrets
+func (m *Model) refreshHelmHistory() {
+	if m.helmHistory == nil {
+		return
+	}
+	m.helmHistory.SetRevisions(m.resourceService.GetHelmReleaseHistory(m.helmHistory.release))
+}
+
+// ExitHelmHistoryMode closes the Helm release history
+func (m *Model) ExitHelmHistoryMode() {
+	m.helmHistory = nil
+	m.viewMode = ViewModeNormal
+}
+
+// DiffHelmRevisions shows how the release changed between the marked revision, or the previous one, and the selected one
+func (m *Model) DiffHelmRevisions() {
+	from, to := m.helmHistory.DiffTargets()
+	if from == nil {
+		m.modal.ShowInfo("Nothing to Compare", "Mark a revision with space to compare it with the selected one; "+
+			"without a mark, the selected revision is compared with the one before it.")
+		return
+	}
+
+	diff, err := k8s.DiffHelmRevisions(from, to)
+	if err != nil {
+		m.modal.ShowError("Diff Failed", err.Error())
+		return
+	}
+	m.helmHistory.ShowDiff(diff)
+}
+
+// DiffHelmRevisionLive compares the selected revision with the release's objects live in the cluster
+func (m *Model) DiffHelmRevisionLive() tea.Cmd {
+	panel := m.helmHistory
+	revision := panel.Selected()
+	if revision == nil {
+		return nil
+	}
+	client := m.resourceService.GetClient()
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), helmLiveDiffTimeout)
+		defer cancel()
+
+		diff, err := client.DiffHelmRevisionLive(ctx, revision)
+		return HelmDiffMsg{Panel: panel, Diff: diff, Err: err}
+	}
+}
+
+// ShowHelmDiff shows a revision's comparison with the live cluster, if its history is still open
+func (m *Model) ShowHelmDiff(msg HelmDiffMsg) {
+	if msg.Panel != m.helmHistory {
+		return
+	}
+	if msg.Err != nil {
+		m.modal.ShowError("Diff Failed", fmt.Sprintf("%s: %s", msg.Panel.releaseName(), msg.Err.Error()))
+		return
+	}
+	m.helmHistory.ShowDiff(msg.Diff)
+}
@@ -0,0 +1,488 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles-w-3/lobot/internal/k8s"
+	"github.com/miles-w-3/lobot/internal/util"
+)
+
+// HelmHistoryKeyMap defines key bindings for the Helm release history
+type HelmHistoryKeyMap struct {
+	Up        key.Binding
+	Down      key.Binding
+	View      key.Binding
+	Mark      key.Binding
+	Diff      key.Binding
+	DiffLive  key.Binding
+	Rollback  key.Binding
+	Uninstall key.Binding
+	NextTab   key.Binding
+	PrevTab   key.Binding
+	Back      key.Binding
+}
+
+// DefaultHelmHistoryKeyMap returns the default key bindings for the Helm release history
+func DefaultHelmHistoryKeyMap() HelmHistoryKeyMap {
+	return HelmHistoryKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "move up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "move down"),
+		),
+		View: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "view details"),
+		),
+		Mark: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "mark to diff against"),
+		),
+		Diff: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "diff with marked/previous"),
+		),
+		DiffLive: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "diff against live"),
+		),
+		Rollback: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "roll back to revision"),
+		),
+		Uninstall: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "uninstall release"),
+		),
+		NextTab: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next tab"),
+			key.WithDisabled(),
+		),
+		PrevTab: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "previous tab"),
+			key.WithDisabled(),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc/q", "back"),
+		),
+	}
+}
+
+// ShortHelp returns a short list of key bindings
+func (k HelmHistoryKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.View, k.Mark, k.Diff, k.DiffLive, k.Rollback, k.Uninstall, k.NextTab, k.PrevTab, k.Back}
+}
+
+// FullHelp returns the full list of key bindings organized by category
+func (k HelmHistoryKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Mark},
+		{k.View, k.Diff, k.DiffLive},
+		{k.Rollback, k.Uninstall},
+		{k.NextTab, k.PrevTab, k.Back},
+	}
+}
+
+// HelmHistoryPanelModel lists every revision of a Helm release, newest first,
+// and shows the manifest of one or how the release changed between revisions
+type HelmHistoryPanelModel struct {
+	release   *k8s.HelmRelease
+	revisions []*k8s.HelmReleaseRevision
+	cursor    int
+	offset    int
+	width     int
+	height    int
+	keys      HelmHistoryKeyMap
+	help      help.Model
+	marked    int  // Revision marked to diff against, or 0
+	writable  bool // Whether the release can be rolled back and uninstalled
+
+	// Page shown in place of the list: a revision's details or a diff
+	page        string // Page title, or "" while listing revisions
+	pageDetails string
+	tabs        []helmDetailTab // Tabs of the page, if it has more than one
+	tab         int
+	viewport    viewport.Model
+}
+
+// NewHelmHistoryPanelModel creates the history of a Helm release
+func NewHelmHistoryPanelModel(release *k8s.HelmRelease, revisions []*k8s.HelmReleaseRevision, width, height int) *HelmHistoryPanelModel {
+	p := &HelmHistoryPanelModel{
+		release:   release,
+		revisions: revisions,
+		keys:      DefaultHelmHistoryKeyMap(),
+		help:      configureHelp(),
+		viewport:  viewport.New(0, 0),
+	}
+	p.SetSize(width, height)
+	return p
+}
+
+// SetSize updates the panel dimensions
+func (p *HelmHistoryPanelModel) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+	p.viewport.Width = width - 4
+	p.viewport.Height = max(1, p.bodyHeight()-1)
+}
+
+// bodyHeight is the height of the bordered panel body, which loses a line to the tab bar when the page has tabs
+func (p *HelmHistoryPanelModel) bodyHeight() int {
+	if len(p.tabs) > 0 {
+		return p.height - 6
+	}
+	return p.height - 5
+}
+
+// SetRevisions replaces the listed revisions, keeping the cursor on the same revision
+func (p *HelmHistoryPanelModel) SetRevisions(revisions []*k8s.HelmReleaseRevision) {
+	selected := p.Selected()
+	p.revisions = revisions
+	p.cursor = min(p.cursor, max(0, len(revisions)-1))
+	if selected != nil {
+		for i, revision := range revisions {
+			if revision.Revision == selected.Revision {
+				p.cursor = i
+				break
+			}
+		}
+	}
+	p.offset = min(p.offset, p.cursor)
+}
+
+// Selected returns the revision under the cursor
+func (p *HelmHistoryPanelModel) Selected() *k8s.HelmReleaseRevision {
+	if p.cursor < len(p.revisions) {
+		return p.revisions[p.cursor]
+	}
+	return nil
+}
+
+// Viewing reports whether a page is shown in place of the list
+func (p *HelmHistoryPanelModel) Viewing() bool {
+	return p.page != ""
+}
+
+// showPage shows content in place of the list
+func (p *HelmHistoryPanelModel) showPage(title, details, content string) {
+	p.page = title
+	p.pageDetails = details
+	p.viewport.SetContent(content)
+	p.viewport.GotoTop()
+	p.setListKeysEnabled(false)
+}
+
+// showTabs shows a page of tabs in place of the list, starting with the first
+func (p *HelmHistoryPanelModel) showTabs(title, details string, tabs []helmDetailTab) {
+	p.tabs = tabs
+	p.tab = 0
+	p.SetSize(p.width, p.height)
+	p.keys.NextTab.SetEnabled(true)
+	p.keys.PrevTab.SetEnabled(true)
+	p.showPage(title, details, "")
+	p.SwitchTab(0)
+}
+
+// SwitchTab moves to another tab of the page, by an offset that wraps around
+func (p *HelmHistoryPanelModel) SwitchTab(offset int) {
+	if len(p.tabs) == 0 {
+		return
+	}
+	p.tab = ((p.tab+offset)%len(p.tabs) + len(p.tabs)) % len(p.tabs)
+	p.viewport.SetContent(p.tabs[p.tab].Content)
+	p.viewport.GotoTop()
+}
+
+// ClosePage goes back to the list of revisions
+func (p *HelmHistoryPanelModel) ClosePage() {
+	p.page = ""
+	p.tabs = nil
+	p.SetSize(p.width, p.height)
+	p.keys.NextTab.SetEnabled(false)
+	p.keys.PrevTab.SetEnabled(false)
+	p.setListKeysEnabled(true)
+}
+
+// setListKeysEnabled enables the keys that act on the list of revisions
+func (p *HelmHistoryPanelModel) setListKeysEnabled(enabled bool) {
+	for _, binding := range []*key.Binding{&p.keys.View, &p.keys.Mark, &p.keys.Diff, &p.keys.DiffLive} {
+		binding.SetEnabled(enabled)
+	}
+	p.keys.Rollback.SetEnabled(enabled && p.writable)
+	p.keys.Uninstall.SetEnabled(enabled && p.writable)
+}
+
+// SetWritable offers rolling back and uninstalling the release, unless the cluster may not be changed
+func (p *HelmHistoryPanelModel) SetWritable(writable bool) {
+	p.writable = writable
+	p.setListKeysEnabled(!p.Viewing())
+}
+
+// ViewSelected shows the details of the revision under the cursor, in tabs
+func (p *HelmHistoryPanelModel) ViewSelected() {
+	revision := p.Selected()
+	if revision == nil {
+		return
+	}
+	p.showTabs(fmt.Sprintf("%s revision %d", p.releaseName(), revision.Revision),
+		revision.Status+" • "+formatHelmChart(revision),
+		helmDetailTabs(revision))
+}
+
+// ToggleMark marks the revision under the cursor to diff against, or clears the mark
+func (p *HelmHistoryPanelModel) ToggleMark() {
+	revision := p.Selected()
+	switch {
+	case revision == nil:
+	case p.marked == revision.Revision:
+		p.marked = 0
+	default:
+		p.marked = revision.Revision
+	}
+}
+
+// DiffTargets returns the revisions to diff, older first: the marked revision, or the one before the
+// revision under the cursor, and the revision under the cursor; both are nil if there is nothing to compare with
+func (p *HelmHistoryPanelModel) DiffTargets() (from, to *k8s.HelmReleaseRevision) {
+	to = p.Selected()
+	if to == nil {
+		return nil, nil
+	}
+	for i, revision := range p.revisions {
+		if p.marked != 0 && revision.Revision == p.marked && revision != to {
+			from = revision
+			break
+		}
+		// Revisions are listed newest first, so the previous revision is the next one down
+		if p.marked == 0 && revision == to && i+1 < len(p.revisions) {
+			from = p.revisions[i+1]
+			break
+		}
+	}
+	if from == nil {
+		return nil, nil
+	}
+	if from.Revision > to.Revision {
+		from, to = to, from
+	}
+	return from, to
+}
+
+// ShowDiff shows how the release's resources differ between two manifests
+func (p *HelmHistoryPanelModel) ShowDiff(diff *k8s.HelmDiff) {
+	added, removed, changed, failed := diff.Counts()
+	details := fmt.Sprintf("%d added, %d removed, %d changed", added, removed, changed)
+	if failed > 0 {
+		details += fmt.Sprintf(", %d couldn't be compared", failed)
+	}
+	p.showPage(fmt.Sprintf("%s: %s → %s", p.releaseName(), diff.From, diff.To), details, renderHelmDiff(diff))
+}
+
+// releaseName returns the namespace and name of the release
+func (p *HelmHistoryPanelModel) releaseName() string {
+	return p.release.Namespace + "/" + p.release.Name
+}
+
+// visibleRows is how many revisions fit in the panel body, below the header row and above the description
+func (p *HelmHistoryPanelModel) visibleRows() int {
+	return max(1, p.height-10)
+}
+
+// Update moves the cursor, or scrolls the page being shown
+func (p *HelmHistoryPanelModel) Update(msg tea.Msg) tea.Cmd {
+	if p.page != "" {
+		var cmd tea.Cmd
+		p.viewport, cmd = p.viewport.Update(msg)
+		return cmd
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+	switch {
+	case key.Matches(keyMsg, p.keys.Up):
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case key.Matches(keyMsg, p.keys.Down):
+		if p.cursor < len(p.revisions)-1 {
+			p.cursor++
+		}
+	}
+
+	// Keep the cursor in view
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+p.visibleRows() {
+		p.offset = p.cursor - p.visibleRows() + 1
+	}
+	return nil
+}
+
+// View renders the panel
+func (p *HelmHistoryPanelModel) View() string {
+	var title, details, content string
+	if p.page != "" {
+		title = titleStyle.Render(p.page)
+		details = fmt.Sprintf("%s • %d%%", p.pageDetails, int(p.viewport.ScrollPercent()*100))
+		content = p.viewport.View()
+		if len(p.tabs) > 0 {
+			title = lipgloss.JoinVertical(lipgloss.Left, title+"  "+lipgloss.NewStyle().Foreground(colorMuted).Render(details), p.renderTabBar())
+			details = ""
+		}
+	} else {
+		title = titleStyle.Render("Helm History " + p.releaseName())
+		details = fmt.Sprintf("%d revisions • stored with the %s driver", len(p.revisions), p.release.HelmStorage)
+		if p.marked != 0 {
+			details += fmt.Sprintf(" • d diffs against revision %d", p.marked)
+		}
+		content = p.renderList()
+	}
+	header := title
+	if details != "" {
+		header += "  " + lipgloss.NewStyle().Foreground(colorMuted).Render(details)
+	}
+
+	body := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorBorder).
+		Width(p.width - 2).
+		Height(p.bodyHeight()).
+		Render(content)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		body,
+		helpStyle.Render(p.help.ShortHelpView(p.keys.ShortHelp())),
+	)
+}
+
+// renderTabBar renders the tabs of the page, highlighting the current one
+func (p *HelmHistoryPanelModel) renderTabBar() string {
+	activeTabStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#000000")).
+		Background(colorAccent).
+		Padding(0, 2)
+
+	inactiveTabStyle := lipgloss.NewStyle().
+		Foreground(colorMuted).
+		Padding(0, 2)
+
+	tabs := make([]string, 0, 2*len(p.tabs))
+	for i, tab := range p.tabs {
+		if i == p.tab {
+			tabs = append(tabs, activeTabStyle.Render(tab.Title))
+		} else {
+			tabs = append(tabs, inactiveTabStyle.Render(tab.Title))
+		}
+		tabs = append(tabs, " ")
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Center, tabs...)
+}
+
+// renderList renders the revisions as a table followed by the selected one's description
+func (p *HelmHistoryPanelModel) renderList() string {
+	if len(p.revisions) == 0 {
+		return diffHunkStyle.Render("The release no longer has any revisions.")
+	}
+
+	revisionWidth := 5
+	statusWidth := 16
+	chartWidth := 30
+	appVersionWidth := 14
+	deployedWidth := 19
+	descriptionWidth := max(20, p.width-6-revisionWidth-statusWidth-chartWidth-appVersionWidth-deployedWidth-5)
+
+	header := padCell("REV", revisionWidth) + " " + padCell("STATUS", statusWidth) + " " +
+		padCell("CHART", chartWidth) + " " + padCell("APP VERSION", appVersionWidth) + " " +
+		padCell("DEPLOYED", deployedWidth) + " " + padCell("DESCRIPTION", descriptionWidth)
+	lines := []string{tableHeaderStyle.UnsetPadding().Render(header)}
+
+	end := min(len(p.revisions), p.offset+p.visibleRows())
+	for i := p.offset; i < end; i++ {
+		revision := p.revisions[i]
+		deployed := ""
+		if !revision.Deployed.IsZero() {
+			deployed = revision.Deployed.Local().Format("2006-01-02 15:04:05")
+		}
+		number := fmt.Sprintf("%d", revision.Revision)
+		if revision.Revision == p.marked {
+			number += "*"
+		}
+		row := padCell(number, revisionWidth) + " " +
+			padCell(revision.Status, statusWidth) + " " +
+			padCell(formatHelmChart(revision), chartWidth) + " " +
+			padCell(revision.AppVersion, appVersionWidth) + " " +
+			padCell(deployed, deployedWidth) + " " +
+			padCell(revision.Description, descriptionWidth)
+
+		if i == p.cursor {
+			lines = append(lines, portForwardSelectedStyle.Render(row))
+		} else {
+			lines = append(lines, row)
+		}
+	}
+
+	if revision := p.Selected(); revision != nil && revision.Description != "" {
+		lines = append(lines, "", diffHunkStyle.Render(revision.Description))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// formatHelmChart formats a revision's chart as name-version, the way Helm lists it
+func formatHelmChart(revision *k8s.HelmReleaseRevision) string {
+	if revision.Chart == "" {
+		return "unknown"
+	}
+	return revision.Chart + "-" + revision.ChartVersion
+}
+
+// renderHelmDiff renders a colorized diff of each resource that differs, with the unchanged ones summarized at the end
+func renderHelmDiff(diff *k8s.HelmDiff) string {
+	var sections []string
+	var unchanged []string
+	for _, resource := range diff.Resources {
+		switch {
+		case resource.Err != nil:
+			sections = append(sections, logErrorStyle.Render("! "+resource.Describe()+": "+resource.Err.Error()))
+		case resource.Change == k8s.HelmResourceUnchanged:
+			unchanged = append(unchanged, resource.Describe())
+		default:
+			lines := util.DiffText(resource.Old, resource.New)
+			inserted, deleted := diffStats(lines)
+			heading := fmt.Sprintf("%s (%s, +%d -%d)", resource.Describe(), resource.Change, inserted, deleted)
+			switch resource.Change {
+			case k8s.HelmResourceAdded:
+				heading = diffInsertStyle.Bold(true).Render("+ " + heading)
+			case k8s.HelmResourceRemoved:
+				heading = diffDeleteStyle.Bold(true).Render("- " + heading)
+			default:
+				heading = diffHunkStyle.Bold(true).Render("~ " + heading)
+			}
+			sections = append(sections, heading+"\n"+renderDiff(lines))
+		}
+	}
+
+	if len(sections) == 0 {
+		sections = append(sections, diffHunkStyle.Render(fmt.Sprintf("No differences between %s and %s.", diff.From, diff.To)))
+	}
+	if len(unchanged) > 0 {
+		sections = append(sections, diffContextStyle.Render(fmt.Sprintf("%d unchanged: %s", len(unchanged), strings.Join(unchanged, ", "))))
+	}
+	return strings.Join(sections, "\n\n")
+}
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles-w-3/lobot/internal/k8s"
+)
+
+const (
+	// historyListLimit is how many recent changes the history browser lists
+	historyListLimit = 200
+	// revertTimeout bounds reverting a change
+	revertTimeout = 30 * time.Second
+
+	historyActionEdit   = "Edit"
+	historyActionRevert = "Revert"
+)
+
+// HistoryLoadedMsg is sent when the recorded changes have been read for the history browser
+type HistoryLoadedMsg struct {
+	Entries []*k8s.HistoryEntry
+	Err     error
+}
+
+// HistoryRevertedMsg is sent when a change has been reverted
+type HistoryRevertedMsg struct {
+	Entry *k8s.HistoryEntry
+	Err   error
+}
+
+// changeRecorder backs resources up before lobot changes them, so the changes can be reverted from the history
+type changeRecorder struct {
+	history *k8s.History
+	client  *k8s.Client
+	logger  *slog.Logger
+}
+
+// recorder returns a change recorder for the current context
+func (m *Model) recorder() changeRecorder {
+	return changeRecorder{history: m.history, client: m.resourceService.GetClient(), logger: m.logger}
+}
+
+// run makes a change to a resource after backing it up; the backup is kept, and its path returned, only if the change succeeds
+// Failing to back the resource up is logged but doesn't stop the change
+func (r changeRecorder) run(ctx context.Context, action string, resource k8s.TrackedObject, change func(context.Context) error) (string, error) {
+	snapshot, err := r.client.Snapshot(ctx, resource)
+	if err != nil {
+		r.logger.Warn("Failed to back up resource before change", "action", action, "resource", describeResource(resource), "error", err)
+	}
+
+	if err := change(ctx); err != nil {
+		return "", err
+	}
+	if snapshot == nil {
+		return "", nil
+	}
+	return r.record(snapshot, action), nil
+}
+
+// record stores a snapshot in the history, logging rather than failing if it can't
+func (r changeRecorder) record(snapshot *k8s.HistoryEntry, action string) string {
+	path, err := r.history.Record(snapshot, action)
+	if err != nil {
+		r.logger.Warn("Failed to record change in history", "action", action, "error", err)
+		return ""
+	}
+	r.logger.Info("Recorded change in history", "action", action, "backup", path)
+	return path
+}
+
+// OpenHistory reads the recorded changes and opens the history browser
+func (m *Model) OpenHistory() tea.Cmd {
+	history := m.history
+	return func() tea.Msg {
+		entries, err := history.List(historyListLimit)
+		return HistoryLoadedMsg{Entries: entries, Err: err}
+	}
+}
+
+// ShowHistory opens the history browser with the recorded changes, or refreshes it if it is open
+func (m *Model) ShowHistory(msg HistoryLoadedMsg) {
+	if msg.Err != nil {
+		m.modal.ShowError("History Unavailable", msg.Err.Error())
+		return
+	}
+
+	if m.historyPanel != nil {
+		m.historyPanel.SetEntries(msg.Entries)
+		return
+	}
+	if len(msg.Entries) == 0 {
+		m.modal.ShowInfo("No History", "Edits, scales and deletes made in lobot are backed up to "+m.history.Dir()+
+			" so they can be reverted here. Nothing has been recorded yet.")
+		return
+	}
+	m.historyPanel = NewHistoryPanelModel(msg.Entries, m.resourceService.GetCurrentContext(), m.width, m.height)
+	m.historyPanel.keys.Revert.SetEnabled(m.writeMode() != k8s.WriteDenied)
+	m.viewMode = ViewModeHistory
+}
+
+// ExitHistoryMode closes the history browser
+func (m *Model) ExitHistoryMode() {
+	m.historyPanel = nil
+	m.viewMode = ViewModeNormal
+}
+
+// ConfirmRevertSelected asks to confirm reverting the change selected in the history browser
+func (m *Model) ConfirmRevertSelected() {
+	entry := m.historyPanel.Selected()
+	if entry == nil {
+		return
+	}
+	if current := m.resourceService.GetCurrentContext(); entry.Context != current {
+		m.modal.ShowInfo("Different Context",
+			fmt.Sprintf("This change was made in context %s, but %s is active. Switch context to revert it.", entry.Context, current))
+		return
+	}
+
+	m.revertTarget = entry
+	m.modal.ShowConfirm("Revert "+entry.Action,
+		fmt.Sprintf("Restore %s to how it was before the %s at %s?\n\n"+
+			"The backup replaces the object's current state, including changes made since. "+
+			"A deleted object is created again.",
+			entry.Describe(), entry.Action, entry.Recorded.Local().Format("2006-01-02 15:04:05")),
+		ConfirmActionRevert, nil)
+}
+
+// revertConfirmed reverts the change the confirmation was shown for, backing the current state up first
+func (m *Model) revertConfirmed() tea.Cmd {
+	entry := m.revertTarget
+	m.revertTarget = nil
+	if entry == nil {
+		return nil
+	}
+
+	recorder := m.recorder()
+	return m.guardWrite("Revert", func(_ *Model, base context.Context) tea.Cmd {
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(base, revertTimeout)
+			defer cancel()
+
+			replaced, err := recorder.client.RevertToBackup(ctx, entry)
+			if err == nil && replaced != nil {
+				recorder.record(replaced, historyActionRevert)
+			}
+			return HistoryRevertedMsg{Entry: entry, Err: err}
+		}
+	})
+}
+
+// ApplyHistoryReverted reports a revert and refreshes the history, which now holds the state the revert replaced
+func (m *Model) ApplyHistoryReverted(msg HistoryRevertedMsg) tea.Cmd {
+	if msg.Err != nil {
+		m.modal.ShowError("Revert Failed", fmt.Sprintf("%s: %s", msg.Entry.Describe(), msg.Err.Error()))
+		return nil
+	}
+	m.UpdateResources()
+	m.modal.ShowInfo("Reverted", fmt.Sprintf("Restored %s to how it was before the %s.", msg.Entry.Describe(), msg.Entry.Action))
+	return m.OpenHistory()
+}
@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles-w-3/lobot/internal/k8s"
+)
+
+// HistoryPanelKeyMap defines key bindings for the history browser
+type HistoryPanelKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Revert key.Binding
+	Back   key.Binding
+}
+
+// DefaultHistoryPanelKeyMap returns the default key bindings for the history browser
+func DefaultHistoryPanelKeyMap() HistoryPanelKeyMap {
+	return HistoryPanelKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "move up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "move down"),
+		),
+		Revert: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "revert change"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc/q", "back to list"),
+		),
+	}
+}
+
+// ShortHelp returns a short list of key bindings
+func (k HistoryPanelKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Revert, k.Back}
+}
+
+// FullHelp returns the full list of key bindings organized by category
+func (k HistoryPanelKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down},
+		{k.Revert, k.Back},
+	}
+}
+
+// HistoryPanelModel lists the changes made in lobot, newest first, so they can be reverted
+type HistoryPanelModel struct {
+	entries []*k8s.HistoryEntry
+	context string // Active context; changes made in other contexts are dimmed
+	cursor  int
+	offset  int
+	width   int
+	height  int
+	keys    HistoryPanelKeyMap
+	help    help.Model
+}
+
+// NewHistoryPanelModel creates the history browser
+func NewHistoryPanelModel(entries []*k8s.HistoryEntry, context string, width, height int) *HistoryPanelModel {
+	return &HistoryPanelModel{
+		entries: entries,
+		context: context,
+		width:   width,
+		height:  height,
+		keys:    DefaultHistoryPanelKeyMap(),
+		help:    configureHelp(),
+	}
+}
+
+// SetSize updates the panel dimensions
+func (p *HistoryPanelModel) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// SetEntries replaces the listed changes, keeping the cursor in range
+func (p *HistoryPanelModel) SetEntries(entries []*k8s.HistoryEntry) {
+	p.entries = entries
+	p.cursor = min(p.cursor, max(0, len(entries)-1))
+	p.offset = min(p.offset, p.cursor)
+}
+
+// Selected returns the change under the cursor
+func (p *HistoryPanelModel) Selected() *k8s.HistoryEntry {
+	if p.cursor < len(p.entries) {
+		return p.entries[p.cursor]
+	}
+	return nil
+}
+
+// visibleRows is how many changes fit in the panel body, below the header row and above the backup details
+func (p *HistoryPanelModel) visibleRows() int {
+	return max(1, p.height-10)
+}
+
+// Update handles key presses
+func (p *HistoryPanelModel) Update(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, p.keys.Up):
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case key.Matches(msg, p.keys.Down):
+		if p.cursor < len(p.entries)-1 {
+			p.cursor++
+		}
+	}
+
+	// Keep the cursor in view
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+p.visibleRows() {
+		p.offset = p.cursor - p.visibleRows() + 1
+	}
+	return nil
+}
+
+// View renders the panel
+func (p *HistoryPanelModel) View() string {
+	title := titleStyle.Render("History")
+	details := fmt.Sprintf("%d recent changes • r restores the object as it was before the change", len(p.entries))
+	header := title + "  " + lipgloss.NewStyle().Foreground(colorMuted).Render(details)
+
+	body := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorBorder).
+		Width(p.width - 2).
+		Height(p.height - 5).
+		Render(p.renderList())
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		body,
+		helpStyle.Render(p.help.ShortHelpView(p.keys.ShortHelp())),
+	)
+}
+
+// renderList renders the changes as a table followed by where the selected one's backup is stored
+func (p *HistoryPanelModel) renderList() string {
+	timeWidth := 19
+	actionWidth := 12
+	contextWidth := 20
+	resourceWidth := max(20, p.width-6-timeWidth-actionWidth-contextWidth-3)
+
+	header := padCell("TIME", timeWidth) + " " + padCell("ACTION", actionWidth) + " " +
+		padCell("CONTEXT", contextWidth) + " " + padCell("RESOURCE", resourceWidth)
+	lines := []string{tableHeaderStyle.UnsetPadding().Render(header)}
+
+	end := min(len(p.entries), p.offset+p.visibleRows())
+	for i := p.offset; i < end; i++ {
+		entry := p.entries[i]
+		row := padCell(entry.Recorded.Local().Format("2006-01-02 15:04:05"), timeWidth) + " " +
+			padCell(entry.Action, actionWidth) + " " +
+			padCell(entry.Context, contextWidth) + " " +
+			padCell(entry.Describe(), resourceWidth)
+
+		switch {
+		case i == p.cursor:
+			lines = append(lines, portForwardSelectedStyle.Render(row))
+		case entry.Context != p.context:
+			lines = append(lines, portForwardStoppedStyle.Render(row))
+		default:
+			lines = append(lines, row)
+		}
+	}
+
+	if entry := p.Selected(); entry != nil {
+		lines = append(lines, "", diffHunkStyle.Render("Backup: "+entry.Path))
+	}
+
+	return strings.Join(lines, "\n")
+}
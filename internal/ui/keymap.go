@@ -53,10 +53,42 @@ type NormalModeKeyMap struct {
 	// Actions
 	Enter     key.Binding
 	Edit      key.Binding
+	EditFull  key.Binding
 	Visualize key.Binding
+	Logs      key.Binding
+	Exec      key.Binding
 	Filter    key.Binding
 	Refresh   key.Binding
 
+	// Deletion
+	Delete           key.Binding
+	RemoveFinalizers key.Binding
+
+	// Port-forwarding
+	PortForward  key.Binding
+	PortForwards key.Binding
+
+	// Table layout
+	Sort         key.Binding
+	ReverseSort  key.Binding
+	CustomColumn key.Binding
+
+	// Workloads
+	WorkloadActions key.Binding
+	Create          key.Binding
+	History         key.Binding
+
+	// Multi-select
+	Mark        key.Binding
+	MarkAll     key.Binding
+	InvertMarks key.Binding
+	BulkAction  key.Binding
+
+	// Saved views
+	Views           key.Binding
+	SaveView        key.Binding
+	SaveContextView key.Binding
+
 	ToggleShowFavoriteTypes key.Binding
 
 	// Selectors
@@ -119,10 +151,22 @@ func DefaultNormalModeKeyMap() NormalModeKeyMap {
 			key.WithKeys("E"),
 			key.WithHelp("E", "edit resource"),
 		),
+		EditFull: key.NewBinding(
+			key.WithKeys("ctrl+e"),
+			key.WithHelp("ctrl+e", "edit full object"),
+		),
 		Visualize: key.NewBinding(
 			key.WithKeys("V"),
 			key.WithHelp("V", "visualize"),
 		),
+		Logs: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "view logs"),
+		),
+		Exec: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "shell into pod"),
+		),
 		Filter: key.NewBinding(
 			key.WithKeys("/"),
 			key.WithHelp("/", "filter by name"),
@@ -132,6 +176,86 @@ func DefaultNormalModeKeyMap() NormalModeKeyMap {
 			key.WithHelp("R", "refresh resources"),
 		),
 
+		// Port-forwarding
+		PortForward: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "port-forward"),
+		),
+		PortForwards: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "port-forwards"),
+		),
+
+		// Deletion
+		Delete: key.NewBinding(
+			key.WithKeys("ctrl+d"),
+			key.WithHelp("ctrl+d", "delete"),
+		),
+		RemoveFinalizers: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "remove finalizers"),
+		),
+
+		// Table layout
+		Sort: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "sort by next column"),
+		),
+		ReverseSort: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "reverse sort"),
+		),
+		CustomColumn: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "add/remove column"),
+		),
+
+		// Workloads
+		WorkloadActions: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "workload actions"),
+		),
+		Create: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "create from template/file"),
+		),
+		History: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "change history"),
+		),
+
+		// Multi-select
+		Mark: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "mark/unmark"),
+		),
+		MarkAll: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "mark all shown"),
+		),
+		InvertMarks: key.NewBinding(
+			key.WithKeys("I"),
+			key.WithHelp("I", "invert marks"),
+		),
+		BulkAction: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "bulk action on marked"),
+		),
+
+		// Saved views
+		Views: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "saved views"),
+		),
+		SaveView: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "save view"),
+		),
+		SaveContextView: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "save view for context"),
+		),
+
 		// Selectors
 		NamespaceSelector: key.NewBinding(
 			key.WithKeys("ctrl+n"),
@@ -175,7 +299,12 @@ func (k NormalModeKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.PageUp, k.PageDown, k.Home, k.End},
 		{k.NextType, k.PrevType},
-		{k.Enter, k.Edit, k.Visualize, k.Filter, k.Refresh},
+		{k.Enter, k.Edit, k.EditFull, k.Visualize, k.Logs, k.Exec, k.Filter, k.Refresh},
+		{k.PortForward, k.PortForwards},
+		{k.Create, k.Delete, k.RemoveFinalizers, k.WorkloadActions, k.History},
+		{k.Mark, k.MarkAll, k.InvertMarks, k.BulkAction},
+		{k.Sort, k.ReverseSort, k.CustomColumn},
+		{k.Views, k.SaveView, k.SaveContextView},
 		{k.NamespaceSelector, k.ResourceTypeSelector, k.ContextSelector, k.UtilizationDashboard},
 		{k.Quit},
 	}
@@ -192,8 +321,9 @@ type ManifestModeKeyMap struct {
 	End      key.Binding
 
 	// Actions
-	Edit key.Binding
-	Copy key.Binding
+	Edit     key.Binding
+	EditFull key.Binding
+	Copy     key.Binding
 
 	// Exit
 	Back key.Binding
@@ -233,6 +363,10 @@ func DefaultManifestModeKeyMap() ManifestModeKeyMap {
 			key.WithKeys("e"),
 			key.WithHelp("e", "edit resource"),
 		),
+		EditFull: key.NewBinding(
+			key.WithKeys("ctrl+e"),
+			key.WithHelp("ctrl+e", "edit full object"),
+		),
 		Copy: key.NewBinding(
 			key.WithKeys("c"),
 			key.WithHelp("c", "copy to clipboard"),
@@ -255,7 +389,7 @@ func (k ManifestModeKeyMap) ShortHelp() []key.Binding {
 func (k ManifestModeKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.PageUp, k.PageDown, k.Home, k.End},
-		{k.Edit, k.Copy},
+		{k.Edit, k.EditFull, k.Copy},
 		{k.Back},
 	}
 }
@@ -268,6 +402,7 @@ type VisualizerModeKeyMap struct {
 
 	// Actions
 	ToggleDetails key.Binding
+	Logs          key.Binding
 
 	// Exit
 	Back key.Binding
@@ -291,6 +426,10 @@ func DefaultVisualizerModeKeyMap() VisualizerModeKeyMap {
 			key.WithKeys("d"),
 			key.WithHelp("d", "toggle details"),
 		),
+		Logs: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "view logs"),
+		),
 
 		// Exit
 		Back: key.NewBinding(
@@ -309,7 +448,7 @@ func (k VisualizerModeKeyMap) ShortHelp() []key.Binding {
 func (k VisualizerModeKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.FocusLeft, k.FocusRight},
-		{k.ToggleDetails},
+		{k.ToggleDetails, k.Logs},
 		{k.Back},
 	}
 }
@@ -393,7 +532,7 @@ func (k TreeVisualizerKeyMap) FullHelp() [][]key.Binding {
 		{k.Up, k.Down, k.PageUp, k.PageDown, k.Home, k.End},
 		{k.Toggle, k.ExpandAll, k.CollapseAll},
 		{k.FocusLeft, k.FocusRight},
-		{k.ToggleDetails},
+		{k.ToggleDetails, k.Logs},
 		{k.Back},
 	}
 }
@@ -485,14 +624,16 @@ func (k GraphVisualizerKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right, k.Home, k.End},
 		{k.PanUp, k.PanDown, k.PanLeft, k.PanRight},
+		{k.Logs},
 		{k.Back},
 	}
 }
 
 // FilterModeKeyMap defines key bindings for filter input mode
 type FilterModeKeyMap struct {
-	Accept key.Binding
-	Cancel key.Binding
+	Accept    key.Binding
+	Cancel    key.Binding
+	NextField key.Binding
 }
 
 // DefaultFilterModeKeyMap returns the default key bindings for filter mode
@@ -500,23 +641,27 @@ func DefaultFilterModeKeyMap() FilterModeKeyMap {
 	return FilterModeKeyMap{
 		Accept: key.NewBinding(
 			key.WithKeys("enter"),
-			key.WithHelp("enter", "apply filter"),
+			key.WithHelp("enter", "apply filters"),
 		),
 		Cancel: key.NewBinding(
 			key.WithKeys("esc"),
 			key.WithHelp("esc", "cancel"),
 		),
+		NextField: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "complete/next filter"),
+		),
 	}
 }
 
 // ShortHelp returns a short list of key bindings
 func (k FilterModeKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Accept, k.Cancel}
+	return []key.Binding{k.Accept, k.NextField, k.Cancel}
 }
 
 // FullHelp returns the full list of key bindings organized by category
 func (k FilterModeKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Accept, k.Cancel},
+		{k.Accept, k.NextField, k.Cancel},
 	}
 }
@@ -0,0 +1,74 @@
+package ui
+
+import "github.com/miles-w-3/lobot/internal/k8s"
+
+// logEntry is a single line held in the log buffer
+type logEntry struct {
+	target k8s.LogTarget
+	text   string
+	kind   logEntryKind
+}
+
+// logEntryKind distinguishes container output from viewer-generated notices
+type logEntryKind int
+
+const (
+	logEntryOutput logEntryKind = iota
+	logEntryNotice              // Informational line (e.g. stream ended)
+	logEntryError               // Stream error
+)
+
+// logBuffer is a fixed-capacity ring buffer of log lines
+// Once full, appending a line evicts the oldest one so memory stays bounded
+type logBuffer struct {
+	entries []logEntry
+	start   int // Index of the oldest entry
+	count   int
+}
+
+// newLogBuffer creates a ring buffer holding at most capacity lines
+func newLogBuffer(capacity int) *logBuffer {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &logBuffer{
+		entries: make([]logEntry, capacity),
+	}
+}
+
+// Append adds an entry, evicting the oldest entry if the buffer is full
+// Returns true if an entry was evicted
+func (b *logBuffer) Append(entry logEntry) bool {
+	capacity := len(b.entries)
+	if b.count < capacity {
+		b.entries[(b.start+b.count)%capacity] = entry
+		b.count++
+		return false
+	}
+
+	b.entries[b.start] = entry
+	b.start = (b.start + 1) % capacity
+	return true
+}
+
+// Len returns the number of entries in the buffer
+func (b *logBuffer) Len() int {
+	return b.count
+}
+
+// Cap returns the maximum number of entries the buffer holds
+func (b *logBuffer) Cap() int {
+	return len(b.entries)
+}
+
+// At returns the i-th entry, where 0 is the oldest
+func (b *logBuffer) At(i int) logEntry {
+	return b.entries[(b.start+i)%len(b.entries)]
+}
+
+// Clear removes all entries
+func (b *logBuffer) Clear() {
+	b.entries = make([]logEntry, len(b.entries))
+	b.start = 0
+	b.count = 0
+}
@@ -0,0 +1,871 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/miles-w-3/lobot/internal/k8s"
+)
+
+const (
+	// logBufferLines bounds how many lines are kept in memory per log view
+	logBufferLines = 5000
+	// logTailLines is how many existing lines are requested when a stream starts
+	logTailLines = 500
+	// logBatchSize caps how many lines are drained from a stream per update
+	logBatchSize = 200
+)
+
+// LogLinesMsg delivers a batch of lines received from a log streamer
+type LogLinesMsg struct {
+	Streamer *k8s.LogStreamer
+	Lines    []k8s.LogLine
+}
+
+// LogViewerKeyMap defines key bindings for the log viewer
+type LogViewerKeyMap struct {
+	Up        key.Binding
+	Down      key.Binding
+	PageUp    key.Binding
+	PageDown  key.Binding
+	Home      key.Binding
+	End       key.Binding
+	Follow    key.Binding
+	Search    key.Binding
+	NextMatch key.Binding
+	PrevMatch key.Binding
+	Container key.Binding
+	Previous  key.Binding
+	Back      key.Binding
+}
+
+// DefaultLogViewerKeyMap returns the default key bindings for the log viewer
+func DefaultLogViewerKeyMap() LogViewerKeyMap {
+	return LogViewerKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "scroll up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "scroll down"),
+		),
+		PageUp: key.NewBinding(
+			key.WithKeys("pgup"),
+			key.WithHelp("pgup", "page up"),
+		),
+		PageDown: key.NewBinding(
+			key.WithKeys("pgdown"),
+			key.WithHelp("pgdown", "page down"),
+		),
+		Home: key.NewBinding(
+			key.WithKeys("home", "g"),
+			key.WithHelp("home/g", "go to top"),
+		),
+		End: key.NewBinding(
+			key.WithKeys("end", "G"),
+			key.WithHelp("end/G", "go to bottom"),
+		),
+		Follow: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "toggle follow"),
+		),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search"),
+		),
+		NextMatch: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next match"),
+		),
+		PrevMatch: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "previous match"),
+		),
+		Container: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "select container"),
+		),
+		Previous: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "toggle previous"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc/q", "back to list"),
+		),
+	}
+}
+
+// ShortHelp returns a short list of key bindings
+func (k LogViewerKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Follow, k.Search, k.NextMatch, k.Container, k.Previous, k.Back}
+}
+
+// FullHelp returns the full list of key bindings organized by category
+func (k LogViewerKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.PageUp, k.PageDown, k.Home, k.End},
+		{k.Search, k.NextMatch, k.PrevMatch},
+		{k.Follow, k.Container, k.Previous},
+		{k.Back},
+	}
+}
+
+var (
+	logNoticeStyle = lipgloss.NewStyle().
+			Foreground(colorMuted).
+			Italic(true)
+
+	logErrorStyle = lipgloss.NewStyle().
+			Foreground(colorDanger)
+
+	logMatchStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#000000")).
+			Background(colorWarning)
+
+	logCurrentMatchStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#000000")).
+				Background(colorAccent).
+				Bold(true)
+)
+
+// allContainersChoice is the container picker entry that selects every regular container
+const allContainersChoice = "<all>"
+
+// logPod tracks a pod whose containers are attached to the log streamer
+type logPod struct {
+	pod     k8s.TrackedObject
+	targets []k8s.LogTarget
+	color   lipgloss.Color
+}
+
+// LogViewerModel streams and displays container logs for one or more pods
+type LogViewerModel struct {
+	service     *k8s.ResourceService
+	streamer    *k8s.LogStreamer
+	title       string
+	resolvePods func() []k8s.TrackedObject // Returns the pods whose logs are shown
+	aggregate   bool                       // Lines from multiple pods are interleaved with per-pod prefixes
+	pods        map[string]*logPod         // Attached pods keyed by namespace/name
+	failed      map[string]string          // Target key -> pod resourceVersion when its stream failed
+	nextColor   int
+	container   string // Selected container; empty selects all regular containers
+	previous    bool   // Show logs of the previous container instance
+	follow      bool   // Keep the view pinned to the newest line
+	buffer      *logBuffer
+	rendered    []string // Rendered buffer lines, kept in step with the buffer
+
+	viewport      viewport.Model
+	searchInput   textinput.Model
+	searching     bool
+	searchTerm    string
+	matches       []int // Buffer indices of lines containing the search term
+	matchIndex    int
+	renderedMatch int // Buffer index of the line rendered as the current match, or -1
+
+	width  int
+	height int
+	keys   LogViewerKeyMap
+	help   help.Model
+}
+
+// NewLogViewerModel creates a log viewer for a single pod
+// The first regular container is selected by default
+func NewLogViewerModel(service *k8s.ResourceService, pod k8s.TrackedObject, width, height int) *LogViewerModel {
+	title := pod.GetNamespace() + "/" + pod.GetName()
+	lv := newLogViewerModel(service, title, func() []k8s.TrackedObject {
+		return []k8s.TrackedObject{pod}
+	}, width, height)
+
+	containers := k8s.GetPodContainers(pod)
+	for _, c := range containers {
+		if !c.Init && !c.Ephemeral {
+			lv.container = c.Name
+			break
+		}
+	}
+	if lv.container == "" && len(containers) > 0 {
+		lv.container = containers[0].Name
+	}
+
+	return lv
+}
+
+// NewAggregatedLogViewerModel creates a log viewer that tails every pod returned by resolvePods
+// The pod set is resolved again on each Reconcile so pods are attached and detached as they come and go
+func NewAggregatedLogViewerModel(service *k8s.ResourceService, title string, resolvePods func() []k8s.TrackedObject, width, height int) *LogViewerModel {
+	lv := newLogViewerModel(service, title, resolvePods, width, height)
+	lv.aggregate = true
+	return lv
+}
+
+// newLogViewerModel creates the shared log viewer state
+func newLogViewerModel(service *k8s.ResourceService, title string, resolvePods func() []k8s.TrackedObject, width, height int) *LogViewerModel {
+	searchInput := textinput.New()
+	searchInput.Placeholder = "Search logs..."
+	searchInput.CharLimit = 100
+
+	vp := viewport.New(0, 0)
+	vp.SetHorizontalStep(8)
+
+	lv := &LogViewerModel{
+		service:       service,
+		title:         title,
+		resolvePods:   resolvePods,
+		pods:          make(map[string]*logPod),
+		failed:        make(map[string]string),
+		follow:        true,
+		buffer:        newLogBuffer(logBufferLines),
+		viewport:      vp,
+		searchInput:   searchInput,
+		renderedMatch: -1,
+		keys:          DefaultLogViewerKeyMap(),
+		help:          configureHelp(),
+	}
+	lv.SetSize(width, height)
+	return lv
+}
+
+// Start begins streaming logs for the selected containers of all resolved pods
+func (lv *LogViewerModel) Start() tea.Cmd {
+	if lv.streamer != nil {
+		lv.streamer.Close()
+	}
+	lv.buffer.Clear()
+	lv.matches = nil
+	lv.matchIndex = 0
+	lv.pods = make(map[string]*logPod)
+	lv.failed = make(map[string]string)
+
+	lv.streamer = lv.service.NewLogStreamer()
+	for _, pod := range lv.resolvePods() {
+		lv.attach(pod, false)
+	}
+
+	lv.refreshContent()
+	return waitForLogLines(lv.streamer)
+}
+
+// Close stops all log streams
+func (lv *LogViewerModel) Close() {
+	if lv.streamer != nil {
+		lv.streamer.Close()
+		lv.streamer = nil
+	}
+}
+
+// Reconcile attaches pods that have appeared and detaches pods that have been deleted
+// Streams that failed (e.g. a container that was still starting) are retried once the pod changes
+func (lv *LogViewerModel) Reconcile() {
+	if lv.streamer == nil || !lv.aggregate {
+		return
+	}
+
+	changed := false
+	seen := make(map[string]bool)
+
+	for _, pod := range lv.resolvePods() {
+		key := logPodKey(pod)
+		seen[key] = true
+
+		attached, exists := lv.pods[key]
+		if !exists {
+			lv.attach(pod, true)
+			changed = true
+			continue
+		}
+
+		attached.pod = pod
+		lv.retryFailedTargets(attached)
+	}
+
+	var removed []string
+	for key := range lv.pods {
+		if !seen[key] {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(removed)
+	for _, key := range removed {
+		lv.detach(key)
+		changed = true
+	}
+
+	if changed {
+		lv.updateMatches()
+		lv.refreshContent()
+	}
+}
+
+// attach starts streams for the selected containers of a pod
+func (lv *LogViewerModel) attach(pod k8s.TrackedObject, announce bool) {
+	attached := &logPod{
+		pod:     pod,
+		targets: lv.targetsFor(pod),
+		color:   podColors[lv.nextColor%len(podColors)],
+	}
+	lv.nextColor++
+	lv.pods[logPodKey(pod)] = attached
+
+	for _, target := range attached.targets {
+		lv.streamer.Start(target, lv.logOptions())
+	}
+
+	if announce {
+		lv.buffer.Append(logEntry{
+			text: fmt.Sprintf("+ attached pod %s", pod.GetName()),
+			kind: logEntryNotice,
+		})
+	}
+}
+
+// detach stops all streams for a pod that no longer exists
+func (lv *LogViewerModel) detach(key string) {
+	attached, exists := lv.pods[key]
+	if !exists {
+		return
+	}
+
+	for _, target := range attached.targets {
+		lv.streamer.Stop(target)
+		delete(lv.failed, target.Key())
+	}
+	delete(lv.pods, key)
+
+	lv.buffer.Append(logEntry{
+		text: fmt.Sprintf("- detached pod %s", attached.pod.GetName()),
+		kind: logEntryNotice,
+	})
+}
+
+// retryFailedTargets restarts failed streams of a pod whose state has changed since the failure
+func (lv *LogViewerModel) retryFailedTargets(attached *logPod) {
+	raw := attached.pod.GetRaw()
+	if raw == nil {
+		return
+	}
+
+	for _, target := range attached.targets {
+		failedVersion, failed := lv.failed[target.Key()]
+		if !failed || failedVersion == raw.GetResourceVersion() {
+			continue
+		}
+		delete(lv.failed, target.Key())
+		lv.streamer.Start(target, lv.logOptions())
+	}
+}
+
+// targetsFor returns the stream targets for the selected containers of a pod
+func (lv *LogViewerModel) targetsFor(pod k8s.TrackedObject) []k8s.LogTarget {
+	var targets []k8s.LogTarget
+	for _, c := range k8s.GetPodContainers(pod) {
+		if lv.container == "" && (c.Init || c.Ephemeral) {
+			continue
+		}
+		if lv.container != "" && c.Name != lv.container {
+			continue
+		}
+		targets = append(targets, k8s.LogTarget{
+			Namespace: pod.GetNamespace(),
+			Pod:       pod.GetName(),
+			Container: c.Name,
+		})
+	}
+	return targets
+}
+
+// logOptions returns the stream options for the current viewer settings
+func (lv *LogViewerModel) logOptions() k8s.LogOptions {
+	return k8s.LogOptions{
+		Previous:  lv.previous,
+		Follow:    true,
+		TailLines: logTailLines,
+	}
+}
+
+// logPodKey returns the key used to track an attached pod
+func logPodKey(pod k8s.TrackedObject) string {
+	return pod.GetNamespace() + "/" + pod.GetName()
+}
+
+// waitForLogLines returns a command that waits for the next batch of lines from the streamer
+// Lines that are already queued are drained into the same batch to limit re-renders
+func waitForLogLines(streamer *k8s.LogStreamer) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case line := <-streamer.Lines():
+			lines := []k8s.LogLine{line}
+			for len(lines) < logBatchSize {
+				select {
+				case next := <-streamer.Lines():
+					lines = append(lines, next)
+				default:
+					return LogLinesMsg{Streamer: streamer, Lines: lines}
+				}
+			}
+			return LogLinesMsg{Streamer: streamer, Lines: lines}
+		case <-streamer.Done():
+			return nil
+		}
+	}
+}
+
+// HandleLines appends a batch of received lines and waits for the next batch
+// Only the new lines are rendered and searched; lines already shown keep their rendering
+func (lv *LogViewerModel) HandleLines(msg LogLinesMsg) tea.Cmd {
+	// Ignore batches from a streamer that has since been replaced
+	if msg.Streamer != lv.streamer {
+		return nil
+	}
+
+	added := 0
+	for _, line := range msg.Lines {
+		// Lines can still be queued for a pod that was just detached
+		attached, exists := lv.pods[line.Target.Namespace+"/"+line.Target.Pod]
+		if !exists {
+			continue
+		}
+		if line.Err != nil {
+			if raw := attached.pod.GetRaw(); raw != nil {
+				lv.failed[line.Target.Key()] = raw.GetResourceVersion()
+			}
+		}
+		lv.buffer.Append(newLogEntry(line))
+		added++
+	}
+
+	lv.showAppended(added)
+
+	return waitForLogLines(lv.streamer)
+}
+
+// newLogEntry converts a streamed line into a buffer entry
+func newLogEntry(line k8s.LogLine) logEntry {
+	switch {
+	case line.Err != nil:
+		return logEntry{target: line.Target, text: line.Err.Error(), kind: logEntryError}
+	case line.Done:
+		return logEntry{target: line.Target, text: "── end of log stream ──", kind: logEntryNotice}
+	default:
+		return logEntry{target: line.Target, text: sanitizeLogText(line.Text), kind: logEntryOutput}
+	}
+}
+
+// sanitizeLogText strips escape sequences and expands tabs so lines render predictably
+func sanitizeLogText(text string) string {
+	text = ansi.Strip(text)
+	text = strings.ReplaceAll(text, "\t", "    ")
+	return strings.TrimRight(text, "\r")
+}
+
+// SetSize updates the viewer dimensions
+func (lv *LogViewerModel) SetSize(width, height int) {
+	lv.width = width
+	lv.height = height
+
+	// Leave room for the title, search bar, border and help footer
+	lv.viewport.Width = max(width-4, 10)
+	lv.viewport.Height = max(height-7, 3)
+	lv.searchInput.Width = max(width-20, 10)
+	lv.showContent()
+}
+
+// IsSearching returns whether the search input is focused
+func (lv *LogViewerModel) IsSearching() bool {
+	return lv.searching
+}
+
+// ContainerChoices returns the selectable containers for the container picker
+// Aggregated views offer each distinct container name plus an entry for all containers
+func (lv *LogViewerModel) ContainerChoices() []string {
+	var choices []string
+	seen := make(map[string]bool)
+
+	if lv.aggregate {
+		choices = append(choices, allContainersChoice)
+	}
+
+	keys := make([]string, 0, len(lv.pods))
+	for key := range lv.pods {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, c := range k8s.GetPodContainers(lv.pods[key].pod) {
+			if !seen[c.DisplayName()] {
+				seen[c.DisplayName()] = true
+				choices = append(choices, c.DisplayName())
+			}
+		}
+	}
+	return choices
+}
+
+// SelectContainer switches the streams to the container with the given display name
+func (lv *LogViewerModel) SelectContainer(displayName string) tea.Cmd {
+	if displayName == allContainersChoice {
+		if lv.container == "" {
+			return nil
+		}
+		lv.container = ""
+		return lv.Start()
+	}
+
+	for _, attached := range lv.pods {
+		for _, c := range k8s.GetPodContainers(attached.pod) {
+			if c.DisplayName() != displayName {
+				continue
+			}
+			if c.Name == lv.container {
+				return nil
+			}
+			lv.container = c.Name
+			return lv.Start()
+		}
+	}
+	return nil
+}
+
+// Update handles key input for the log viewer
+func (lv *LogViewerModel) Update(msg tea.Msg) (*LogViewerModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		lv.viewport, cmd = lv.viewport.Update(msg)
+		lv.syncFollow()
+		return lv, cmd
+	}
+
+	if lv.searching {
+		return lv.updateSearch(keyMsg)
+	}
+
+	switch {
+	case key.Matches(keyMsg, lv.keys.Follow):
+		lv.follow = !lv.follow
+		if lv.follow {
+			lv.viewport.GotoBottom()
+		}
+		return lv, nil
+
+	case key.Matches(keyMsg, lv.keys.Previous):
+		lv.previous = !lv.previous
+		return lv, lv.Start()
+
+	case key.Matches(keyMsg, lv.keys.Search):
+		lv.searching = true
+		lv.searchInput.SetValue(lv.searchTerm)
+		lv.searchInput.CursorEnd()
+		return lv, lv.searchInput.Focus()
+
+	case key.Matches(keyMsg, lv.keys.NextMatch):
+		lv.jumpToMatch(1)
+		return lv, nil
+
+	case key.Matches(keyMsg, lv.keys.PrevMatch):
+		lv.jumpToMatch(-1)
+		return lv, nil
+
+	case key.Matches(keyMsg, lv.keys.Home):
+		lv.follow = false
+		lv.viewport.GotoTop()
+		return lv, nil
+
+	case key.Matches(keyMsg, lv.keys.End):
+		lv.follow = true
+		lv.viewport.GotoBottom()
+		return lv, nil
+	}
+
+	// Pass remaining keys to the viewport for scrolling
+	var cmd tea.Cmd
+	lv.viewport, cmd = lv.viewport.Update(keyMsg)
+	lv.syncFollow()
+	return lv, cmd
+}
+
+// updateSearch handles key input while the search bar is focused
+func (lv *LogViewerModel) updateSearch(msg tea.KeyMsg) (*LogViewerModel, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		lv.searching = false
+		lv.searchInput.Blur()
+		lv.searchTerm = lv.searchInput.Value()
+		lv.updateMatches()
+		lv.matchIndex = len(lv.matches) - 1 // Start from the most recent match
+		lv.refreshContent()
+		lv.scrollToCurrentMatch()
+		return lv, nil
+
+	case "esc":
+		lv.searching = false
+		lv.searchInput.Blur()
+		return lv, nil
+	}
+
+	var cmd tea.Cmd
+	lv.searchInput, cmd = lv.searchInput.Update(msg)
+	return lv, cmd
+}
+
+// syncFollow pauses following when the user scrolls away from the newest line
+func (lv *LogViewerModel) syncFollow() {
+	lv.follow = lv.viewport.AtBottom()
+}
+
+// updateMatches recomputes which buffer lines contain the search term
+func (lv *LogViewerModel) updateMatches() {
+	lv.matches = lv.matches[:0]
+	if lv.searchTerm == "" {
+		lv.matchIndex = 0
+		return
+	}
+
+	for i := 0; i < lv.buffer.Len(); i++ {
+		if lv.matchesSearch(lv.buffer.At(i)) {
+			lv.matches = append(lv.matches, i)
+		}
+	}
+
+	if lv.matchIndex >= len(lv.matches) {
+		lv.matchIndex = max(0, len(lv.matches)-1)
+	}
+}
+
+// matchesSearch reports whether an entry contains the search term
+func (lv *LogViewerModel) matchesSearch(entry logEntry) bool {
+	return lv.searchTerm != "" && strings.Contains(strings.ToLower(entry.text), strings.ToLower(lv.searchTerm))
+}
+
+// dropMatches forgets matches among the oldest n buffer lines after the buffer evicted them
+func (lv *LogViewerModel) dropMatches(n int) {
+	dropped := 0
+	for dropped < len(lv.matches) && lv.matches[dropped] < n {
+		dropped++
+	}
+	lv.matches = lv.matches[dropped:]
+	for i := range lv.matches {
+		lv.matches[i] -= n
+	}
+	lv.matchIndex = max(0, lv.matchIndex-dropped)
+}
+
+// jumpToMatch moves the current match by delta, wrapping around
+func (lv *LogViewerModel) jumpToMatch(delta int) {
+	if len(lv.matches) == 0 {
+		return
+	}
+
+	lv.matchIndex = (lv.matchIndex + delta + len(lv.matches)) % len(lv.matches)
+	lv.highlightCurrentMatch()
+	lv.showContent()
+	lv.scrollToCurrentMatch()
+}
+
+// scrollToCurrentMatch centers the current match in the viewport and pauses following
+func (lv *LogViewerModel) scrollToCurrentMatch() {
+	if len(lv.matches) == 0 {
+		return
+	}
+
+	lv.follow = false
+	lv.viewport.SetYOffset(lv.matches[lv.matchIndex] - lv.viewport.Height/2)
+}
+
+// refreshContent re-renders the whole buffer into the viewport
+// Needed when the search term or the attached pods change, since those change how every line renders
+func (lv *LogViewerModel) refreshContent() {
+	lv.renderedMatch = lv.currentMatchLine()
+	lv.rendered = lv.rendered[:0]
+	for i := 0; i < lv.buffer.Len(); i++ {
+		lv.rendered = append(lv.rendered, lv.renderEntry(lv.buffer.At(i), i == lv.renderedMatch))
+	}
+	lv.showContent()
+}
+
+// showAppended renders and searches the last added buffer lines, dropping the lines the buffer evicted
+func (lv *LogViewerModel) showAppended(added int) {
+	added = min(added, lv.buffer.Len())
+	kept := lv.buffer.Len() - added
+	evicted := len(lv.rendered) - kept
+	if evicted < 0 {
+		// The rendered lines fell out of step with the buffer
+		lv.updateMatches()
+		lv.refreshContent()
+		return
+	}
+
+	lv.rendered = lv.rendered[evicted:]
+	lv.dropMatches(evicted)
+	lv.renderedMatch = max(-1, lv.renderedMatch-evicted)
+	for i := kept; i < lv.buffer.Len(); i++ {
+		entry := lv.buffer.At(i)
+		if lv.matchesSearch(entry) {
+			lv.matches = append(lv.matches, i)
+		}
+		lv.rendered = append(lv.rendered, lv.renderEntry(entry, false))
+	}
+
+	lv.highlightCurrentMatch()
+	lv.showContent()
+}
+
+// currentMatchLine returns the buffer index of the current match, or -1 when nothing matches
+func (lv *LogViewerModel) currentMatchLine() int {
+	if len(lv.matches) == 0 {
+		return -1
+	}
+	return lv.matches[lv.matchIndex]
+}
+
+// highlightCurrentMatch re-renders the lines that gain or lose the current match highlight
+func (lv *LogViewerModel) highlightCurrentMatch() {
+	current := lv.currentMatchLine()
+	if current == lv.renderedMatch {
+		return
+	}
+	if lv.renderedMatch >= 0 && lv.renderedMatch < len(lv.rendered) {
+		lv.rendered[lv.renderedMatch] = lv.renderEntry(lv.buffer.At(lv.renderedMatch), false)
+	}
+	if current >= 0 {
+		lv.rendered[current] = lv.renderEntry(lv.buffer.At(current), true)
+	}
+	lv.renderedMatch = current
+}
+
+// showContent puts the rendered lines into the viewport, keeping the scroll position unless following
+func (lv *LogViewerModel) showContent() {
+	yOffset := lv.viewport.YOffset
+	lv.viewport.SetContent(strings.Join(lv.rendered, "\n"))
+
+	if lv.follow {
+		lv.viewport.GotoBottom()
+	} else {
+		lv.viewport.SetYOffset(yOffset)
+	}
+}
+
+// renderEntry renders a single buffer entry with its pod prefix and search highlighting
+func (lv *LogViewerModel) renderEntry(entry logEntry, isCurrentMatch bool) string {
+	if entry.kind == logEntryNotice {
+		return lv.renderPrefix(entry) + logNoticeStyle.Render(entry.text)
+	}
+	if entry.kind == logEntryError {
+		return lv.renderPrefix(entry) + logErrorStyle.Render("✗ "+entry.text)
+	}
+
+	matchStyle := logMatchStyle
+	if isCurrentMatch {
+		matchStyle = logCurrentMatchStyle
+	}
+	return lv.renderPrefix(entry) + highlightMatches(entry.text, lv.searchTerm, matchStyle)
+}
+
+// renderPrefix renders the colored pod (and container) prefix for aggregated views
+func (lv *LogViewerModel) renderPrefix(entry logEntry) string {
+	if !lv.aggregate || entry.target.Pod == "" {
+		return ""
+	}
+
+	prefix := entry.target.Pod
+	color := colorMuted
+
+	if attached, exists := lv.pods[entry.target.Namespace+"/"+entry.target.Pod]; exists {
+		color = attached.color
+		if len(attached.targets) > 1 {
+			prefix += "/" + entry.target.Container
+		}
+	}
+
+	return lipgloss.NewStyle().Foreground(color).Render(prefix) + " "
+}
+
+// highlightMatches renders all case-insensitive occurrences of term in text with the given style
+func highlightMatches(text, term string, style lipgloss.Style) string {
+	if term == "" {
+		return text
+	}
+
+	lowerText := strings.ToLower(text)
+	lowerTerm := strings.ToLower(term)
+
+	// Lowercasing can change byte lengths for some scripts; fall back to exact matching
+	if len(lowerText) != len(text) {
+		lowerText = text
+		lowerTerm = term
+	}
+
+	var result strings.Builder
+	pos := 0
+	for {
+		idx := strings.Index(lowerText[pos:], lowerTerm)
+		if idx < 0 {
+			break
+		}
+		start := pos + idx
+		end := start + len(lowerTerm)
+		result.WriteString(text[pos:start])
+		result.WriteString(style.Render(text[start:end]))
+		pos = end
+	}
+	result.WriteString(text[pos:])
+
+	return result.String()
+}
+
+// View renders the log viewer
+func (lv *LogViewerModel) View() string {
+	title := titleStyle.Render("Logs: " + lv.title)
+
+	var details []string
+	if lv.aggregate {
+		details = append(details, fmt.Sprintf("%d pods", len(lv.pods)))
+	}
+	if lv.container != "" {
+		details = append(details, "container: "+lv.container)
+	} else {
+		details = append(details, "all containers")
+	}
+	if lv.previous {
+		details = append(details, "previous")
+	}
+	if lv.follow {
+		details = append(details, "following")
+	} else {
+		details = append(details, "paused")
+	}
+	details = append(details, fmt.Sprintf("%d/%d lines", lv.buffer.Len(), lv.buffer.Cap()))
+	if lv.searchTerm != "" {
+		if len(lv.matches) > 0 {
+			details = append(details, fmt.Sprintf("match %d/%d", lv.matchIndex+1, len(lv.matches)))
+		} else {
+			details = append(details, "no matches")
+		}
+	}
+
+	header := title + "  " + lipgloss.NewStyle().Foreground(colorMuted).Render(strings.Join(details, " • "))
+	if lv.searching {
+		header = title + "  Search: " + lv.searchInput.View()
+	}
+
+	body := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorBorder).
+		Width(lv.width - 2).
+		Render(lv.viewport.View())
+
+	sections := []string{header, body}
+	sections = append(sections, helpStyle.Render(lv.help.ShortHelpView(lv.keys.ShortHelp())))
+
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
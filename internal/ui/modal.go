@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/help"
@@ -18,8 +19,35 @@ const (
 	ModalTypeInfo
 	ModalTypeSuccess
 	ModalTypeHelp
+	ModalTypeConfirm
 )
 
+// ConfirmAction identifies what a confirmation modal asks about
+type ConfirmAction int
+
+const (
+	ConfirmActionDelete ConfirmAction = iota
+	ConfirmActionRemoveFinalizers
+	ConfirmActionBulkDelete
+	ConfirmActionBulkRestart
+	ConfirmActionRestart
+	ConfirmActionRevert
+)
+
+// ConfirmOption is a setting shown in a confirmation modal, cycled through with its key
+type ConfirmOption struct {
+	Key      string
+	Label    string
+	Values   []string
+	Selected int
+}
+
+// ConfirmedMsg is sent when a confirmation modal is accepted
+type ConfirmedMsg struct {
+	Action  ConfirmAction
+	Options []string // The selected value of each option, in order
+}
+
 // Modal represents a unified modal dialog for all modal types
 type Modal struct {
 	title       string
@@ -31,6 +59,9 @@ type Modal struct {
 	detailLines []string        // Additional detail lines for long messages
 	helpGroups  [][]key.Binding // For help modal
 	helpModel   help.Model      // Help renderer for help modal
+
+	confirmAction  ConfirmAction   // For confirm modal
+	confirmOptions []ConfirmOption // For confirm modal
 }
 
 // NewModal creates a new modal
@@ -80,6 +111,14 @@ func (m *Modal) ShowInfo(title, message string) {
 	m.Show(title, message, ModalTypeInfo)
 }
 
+// ShowConfirm asks the user to confirm an action, with options they can change first
+// Accepting sends a ConfirmedMsg; cancelling just closes the modal
+func (m *Modal) ShowConfirm(title, message string, action ConfirmAction, options []ConfirmOption) {
+	m.Show(title, message, ModalTypeConfirm)
+	m.confirmAction = action
+	m.confirmOptions = options
+}
+
 // ShowHelp displays a help modal with key bindings
 func (m *Modal) ShowHelp(groups [][]key.Binding) {
 	m.title = "Help - Press ? to close"
@@ -114,6 +153,10 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.modalType == ModalTypeConfirm {
+			return m.updateConfirm(msg)
+		}
+
 		switch msg.String() {
 		case "enter", "esc", "q":
 			m.Hide()
@@ -130,6 +173,30 @@ func (m *Modal) Update(msg tea.Msg) (*Modal, tea.Cmd) {
 	return m, nil
 }
 
+// updateConfirm handles keys for a confirmation modal
+func (m *Modal) updateConfirm(msg tea.KeyMsg) (*Modal, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		m.Hide()
+		confirmed := ConfirmedMsg{Action: m.confirmAction}
+		for _, option := range m.confirmOptions {
+			confirmed.Options = append(confirmed.Options, option.Values[option.Selected])
+		}
+		return m, func() tea.Msg { return confirmed }
+	case "n", "esc", "q":
+		m.Hide()
+		return m, nil
+	}
+
+	for i := range m.confirmOptions {
+		option := &m.confirmOptions[i]
+		if msg.String() == option.Key {
+			option.Selected = (option.Selected + 1) % len(option.Values)
+		}
+	}
+	return m, nil
+}
+
 // View renders the modal
 func (m *Modal) View() string {
 	if !m.visible {
@@ -163,6 +230,9 @@ func (m *Modal) renderAlertModal() string {
 	case ModalTypeInfo:
 		borderColor = lipgloss.Color("#0000FF")
 		icon = "ℹ"
+	case ModalTypeConfirm:
+		borderColor = lipgloss.Color("#FFA500")
+		icon = "?"
 	}
 
 	// Title with icon (same pattern as help modal)
@@ -188,6 +258,19 @@ func (m *Modal) renderAlertModal() string {
 		Italic(true)
 	helpText := helpStyle.Render("Press Enter or Esc to close")
 
+	if m.modalType == ModalTypeConfirm {
+		optionKeyStyle := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
+		var optionLines []string
+		for _, option := range m.confirmOptions {
+			optionLines = append(optionLines, fmt.Sprintf("%s  %s: %s",
+				optionKeyStyle.Render(option.Key), option.Label, option.Values[option.Selected]))
+		}
+		if len(optionLines) > 0 {
+			contentText += "\n\n" + strings.Join(optionLines, "\n")
+		}
+		helpText = helpStyle.Render("Press y or Enter to confirm, Esc to cancel")
+	}
+
 	// Join all content vertically (same pattern as help modal)
 	modalContent := lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -19,10 +19,12 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/miles-w-3/lobot/internal/config"
 	"github.com/miles-w-3/lobot/internal/filters"
 	"github.com/miles-w-3/lobot/internal/graph"
 	"github.com/miles-w-3/lobot/internal/k8s"
 	"github.com/miles-w-3/lobot/internal/splash"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/yaml"
 )
 
@@ -37,8 +39,55 @@ const (
 	ViewModeResourceTypeSelection
 	ViewModeVisualize
 	ViewModeUtilization
+	ViewModeLogs
+	ViewModePortForwards
+	ViewModeBulkResults
+	ViewModeEditPreview
+	ViewModeCreate
+	ViewModeHistory
+	ViewModeHelmHistory
+	ViewModeHelmAction
 )
 
+// filterTarget is the filter the filter bar is editing
+type filterTarget int
+
+const (
+	filterTargetName filterTarget = iota
+	filterTargetLabels
+	filterTargetFields
+	filterTargetQuery
+	filterTargetCount
+)
+
+// label returns the filter bar label of a filter target
+func (t filterTarget) label() string {
+	switch t {
+	case filterTargetLabels:
+		return "Label selector"
+	case filterTargetFields:
+		return "Field selector"
+	case filterTargetQuery:
+		return "Query"
+	default:
+		return "Resource name filter"
+	}
+}
+
+// placeholder returns the filter bar placeholder of a filter target
+func (t filterTarget) placeholder() string {
+	switch t {
+	case filterTargetLabels:
+		return "app=web,tier!=cache,env in (prod,stg)"
+	case filterTargetFields:
+		return "status=Running,node=worker-1,owner=ReplicaSet"
+	case filterTargetQuery:
+		return "status!=Running and age>2h and label.team=payments and .spec.replicas>3"
+	default:
+		return "Search resource name..."
+	}
+}
+
 // Model represents the UI state
 type Model struct {
 	logger *slog.Logger
@@ -58,9 +107,16 @@ type Model struct {
 	height        int
 
 	// Filtering
-	namespaceFilter *filters.NamespaceFilter    // Namespace filter (set via ctrl+n selector)
-	nameFilter      *filters.ResourceNameFilter // Resource name filter (set via / search)
+	namespaceFilter *filters.NamespaceFilter     // Namespace filter (set via ctrl+n selector)
+	nameFilter      *filters.ResourceNameFilter  // Resource name filter (set via / search)
+	labelFilter     *filters.LabelSelectorFilter // Label selector (set via / search, tab to switch)
+	fieldFilter     *filters.FieldFilter         // Field selector (set via / search, tab to switch)
+	queryFilter     *filters.QueryFilter         // Query (set via / search, tab to switch)
 	filterInput     textinput.Model
+	filterTarget    filterTarget              // Which filter the filter bar is editing
+	filterDrafts    [filterTargetCount]string // Unapplied filter bar input for each target
+	filterError     string                    // Why the last filter bar input was rejected
+	queryFields     []string                  // Field completions for the query, collected on entering filter mode
 
 	// Splash screen
 	splash splash.Model
@@ -71,6 +127,7 @@ type Model struct {
 	// Manifest viewer
 	manifestViewport viewport.Model
 	manifestContent  string
+	manifestEvents   string            // Events section rendered below the manifest
 	manifestResource k8s.TrackedObject // The resource being viewed in manifest mode
 
 	// Status
@@ -86,6 +143,49 @@ type Model struct {
 
 	utilizationDashboard *UtilizationDashboardModel
 
+	logViewer      *LogViewerModel
+	logsReturnMode ViewMode // Mode to return to when the log viewer is closed
+
+	execPod k8s.TrackedObject // Pod awaiting a container selection for exec
+
+	// Text prompt (for port-forward ports)
+	prompt *PromptModel
+
+	portForwardTarget k8s.TrackedObject // Pod or Service awaiting a port mapping
+	viewContext       string            // Kube context a view being saved is scoped to, empty for all contexts
+	deleteTarget      k8s.TrackedObject // Resource awaiting delete or finalizer removal confirmation
+	portForwardPanel  *PortForwardPanelModel
+
+	// Edit preview
+	editPreview    *EditPreviewModel
+	editReturnMode ViewMode // Mode to return to when the edit preview is closed
+
+	// Creating from templates and files
+	createPanel *CreatePanelModel
+
+	// Change history
+	history      *k8s.History
+	historyPanel *HistoryPanelModel
+	revertTarget *k8s.HistoryEntry // Recorded change awaiting revert confirmation
+
+	// Helm release history, and rolling back or uninstalling a release
+	helmHistory *HelmHistoryPanelModel
+	helmAction  *HelmActionPanelModel
+
+	// Write protection
+	pendingWrite func(*Model) tea.Cmd // Change awaiting the context name being typed to confirm it
+
+	// Multi-select
+	marked      map[string]bool     // Marked resources, keyed by markKey
+	bulkTargets []k8s.TrackedObject // Marked resources a bulk action is being set up for
+	bulkAction  string              // Bulk action awaiting prompt input
+	bulkPanel   *BulkPanelModel
+
+	// Workload actions
+	workloadTarget    k8s.TrackedObject      // Workload awaiting an action's input or confirmation
+	workloadRevisions []k8s.WorkloadRevision // Revisions offered for a rollback
+	rollout           *rolloutProgress       // Rollout followed in the status bar
+
 	showingFavoriteTypes  bool
 	favoriteTypesViewport viewport.Model
 
@@ -96,6 +196,12 @@ type Model struct {
 	visualizerKeys VisualizerModeKeyMap
 	filterKeys     FilterModeKeyMap
 
+	// Table customization
+	config        *config.Config
+	customColumns map[schema.GroupVersionResource][]customColumn // Parsed custom columns per type
+	recalledView  *recalledView                                  // Columns of the view last recalled, until it is left
+	sortStates    map[schema.GroupVersionResource]sortState      // Sort column per type
+
 	// Error tracking
 	errorTracker *ErrorTracker
 }
@@ -124,10 +230,10 @@ type BuildGraphMsg struct {
 }
 
 // NewModel creates a new UI model
-func NewModel(resourceService *k8s.ResourceService, logger *slog.Logger, errorTracker *ErrorTracker) Model {
+func NewModel(resourceService *k8s.ResourceService, cfg *config.Config, logger *slog.Logger, errorTracker *ErrorTracker) Model {
 	filterInput := textinput.New()
-	filterInput.Placeholder = "Search resource name..."
-	filterInput.CharLimit = 100
+	filterInput.Placeholder = filterTargetName.placeholder()
+	filterInput.CharLimit = 256
 
 	t := table.New(
 		// table.WithColumns(columns),
@@ -156,7 +262,7 @@ func NewModel(resourceService *k8s.ResourceService, logger *slog.Logger, errorTr
 
 	favoriteTypesViewport.SetContent("Test\nTest2\nTest3\n")
 
-	return Model{
+	m := Model{
 		logger:                logger,
 		resourceService:       resourceService,
 		graphBuilder:          graphBuilder,
@@ -167,6 +273,9 @@ func NewModel(resourceService *k8s.ResourceService, logger *slog.Logger, errorTr
 		viewMode:              ViewModeSplash,
 		namespaceFilter:       filters.NewNamespaceFilter(),
 		nameFilter:            filters.NewResourceNameFilter(),
+		labelFilter:           filters.NewLabelSelectorFilter(),
+		fieldFilter:           filters.NewFieldFilter(),
+		queryFilter:           filters.NewQueryFilter(),
 		favoriteTypesViewport: favoriteTypesViewport,
 		filterInput:           filterInput,
 		splash:                splash.NewModel(logger),
@@ -179,8 +288,14 @@ func NewModel(resourceService *k8s.ResourceService, logger *slog.Logger, errorTr
 		manifestKeys:          DefaultManifestModeKeyMap(),
 		visualizerKeys:        DefaultVisualizerModeKeyMap(),
 		filterKeys:            DefaultFilterModeKeyMap(),
+		config:                cfg,
+		history:               k8s.NewHistory(cfg.HistoryDir()),
 		errorTracker:          errorTracker,
 	}
+
+	// Actions that would be refused are hidden in read-only mode
+	m.applyWriteMode()
+	return m
 }
 
 // configureHelp creates and configures the help model with brand colors
@@ -205,21 +320,43 @@ func (m *Model) UpdateResources() {
 	currentTrackedType := m.trackedTypes[m.currentType]
 	m.resources = m.resourceService.GetResources(currentTrackedType.GVR)
 
+	// Switching to another type leaves a recalled view, bringing back that type's saved columns
+	if m.recalledView != nil && m.recalledView.gvr != currentTrackedType.GVR {
+		m.leaveView()
+	}
+
 	// Apply namespace filter first
 	m.filteredResources = m.namespaceFilter.FilterResources(m.resources)
 
-	// Then apply name filter
+	// Then apply name, label, field and query filters
 	m.filteredResources = m.nameFilter.FilterResources(m.filteredResources)
+	m.filteredResources = m.labelFilter.FilterResources(m.filteredResources)
+	m.filteredResources = m.fieldFilter.FilterResources(m.filteredResources)
+	m.filteredResources = m.queryFilter.FilterResources(m.filteredResources)
 
 	// Clear rows first to avoid column mismatch during rendering
 	m.table.SetRows([]table.Row{})
 
-	m.table.SetColumns(currentTrackedType.Columns)
+	m.resizeTableColumns()
 
 	// Update table rows
 	rows := make([]table.Row, 0, len(m.filteredResources))
 	for _, resource := range m.filteredResources {
-		rows = append(rows, currentTrackedType.RowBinder(resource))
+		rows = append(rows, m.bindRow(currentTrackedType, resource))
+	}
+	m.filteredResources, rows = m.sortResources(currentTrackedType, m.filteredResources, rows)
+
+	showMarks := m.showMarkColumn()
+	for i, resource := range m.filteredResources {
+		leading := table.Row{getSeverityIndicator(resource.GetSeverity())}
+		if showMarks {
+			mark := " "
+			if m.isMarked(resource) {
+				mark = markIndicator
+			}
+			leading = append(leading, mark)
+		}
+		rows[i] = append(leading, rows[i]...)
 	}
 	m.table.SetRows(rows)
 
@@ -268,6 +405,23 @@ func (m *Model) SetReady() {
 	m.UpdateResources()
 }
 
+// reselectResource moves the cursor back to a resource after the table was rebuilt, since sorting by a
+// changing column (restarts, age) can move it to another row
+func (m *Model) reselectResource(previous k8s.TrackedObject) {
+	if previous == nil {
+		return
+	}
+	for i, resource := range m.filteredResources {
+		if resource.GetName() == previous.GetName() &&
+			resource.GetNamespace() == previous.GetNamespace() &&
+			resource.GetKind() == previous.GetKind() {
+			m.selectedIndex = i
+			m.table.SetCursor(i)
+			return
+		}
+	}
+}
+
 // GetSelectedResource returns the currently selected resource
 func (m *Model) GetSelectedResource() k8s.TrackedObject {
 	if m.selectedIndex >= 0 && m.selectedIndex < len(m.filteredResources) {
@@ -289,10 +443,11 @@ func (m *Model) EnterManifestMode() tea.Cmd {
 
 	// Format the manifest as YAML
 	m.manifestContent = formatManifest(resource.GetRaw())
+	m.manifestEvents = m.renderManifestEvents(resource)
 
 	// Create viewport
 	m.manifestViewport = viewport.New(m.width-4, m.height-6)
-	m.manifestViewport.SetContent(m.manifestContent)
+	m.manifestViewport.SetContent(m.manifestContent + m.manifestEvents)
 
 	m.viewMode = ViewModeManifest
 
@@ -338,7 +493,8 @@ func (m *Model) RefreshManifestResource() {
 
 	// Reformat the manifest with the new data
 	m.manifestContent = formatManifest(updatedResource.GetRaw())
-	m.manifestViewport.SetContent(m.manifestContent)
+	m.manifestEvents = m.renderManifestEvents(updatedResource)
+	m.manifestViewport.SetContent(m.manifestContent + m.manifestEvents)
 }
 
 // CopyManifestToClipboard copies the raw manifest YAML to clipboard
@@ -388,6 +544,135 @@ func (m *Model) ExitUtilizationMode() {
 	m.utilizationDashboard = nil
 }
 
+// logWorkloadKinds are the kinds whose pods can be tailed together from the resource list
+var logWorkloadKinds = map[string]bool{
+	"Deployment":  true,
+	"StatefulSet": true,
+	"DaemonSet":   true,
+	"ReplicaSet":  true,
+	"Job":         true,
+	"CronJob":     true,
+}
+
+// EnterLogsMode starts streaming logs for the selected pod, or for every pod of the selected workload
+func (m *Model) EnterLogsMode() tea.Cmd {
+	resource := m.GetSelectedResource()
+	if resource == nil || resource.GetRaw() == nil {
+		return nil
+	}
+
+	switch {
+	case resource.GetKind() == "Pod":
+		m.logViewer = NewLogViewerModel(m.resourceService, resource, m.width, m.height)
+	case logWorkloadKinds[resource.GetKind()]:
+		title := fmt.Sprintf("%s/%s", resource.GetKind(), resource.GetName())
+		m.logViewer = NewAggregatedLogViewerModel(m.resourceService, title, func() []k8s.TrackedObject {
+			return m.resourceService.GetOwnedPods(resource)
+		}, m.width, m.height)
+	default:
+		m.modal.ShowInfo("Logs Unavailable", "Logs can be viewed for Pods and for workloads that own pods\n(Deployments, StatefulSets, DaemonSets, ReplicaSets, Jobs and CronJobs).")
+		return nil
+	}
+
+	return m.startLogViewer(ViewModeNormal)
+}
+
+// EnterNodeLogsMode starts streaming logs for every pod belonging to the selected visualizer node
+func (m *Model) EnterNodeLogsMode() tea.Cmd {
+	if m.visualizer == nil {
+		return nil
+	}
+
+	node := m.visualizer.SelectedNode()
+	if node == nil {
+		return nil
+	}
+
+	resourceGraph := m.visualizer.Graph()
+	resource := node.Resource
+	if resource.GetKind() == "Pod" && resource.GetRaw() != nil {
+		m.logViewer = NewLogViewerModel(m.resourceService, resource, m.width, m.height)
+	} else {
+		title := fmt.Sprintf("%s/%s", resource.GetKind(), resource.GetName())
+		m.logViewer = NewAggregatedLogViewerModel(m.resourceService, title, func() []k8s.TrackedObject {
+			return m.graphNodePods(resourceGraph, node)
+		}, m.width, m.height)
+	}
+
+	return m.startLogViewer(ViewModeVisualize)
+}
+
+// graphNodePods returns the live pods belonging to a graph node
+// Pods in the node's subtree are combined with pods found through the owner index,
+// so pods created after the graph was built are included and deleted ones are dropped
+func (m *Model) graphNodePods(resourceGraph *graph.ResourceGraph, node *graph.Node) []k8s.TrackedObject {
+	livePods := make(map[string]k8s.TrackedObject)
+	for _, pod := range m.resourceService.GetResources(k8s.PodResource.GVR) {
+		livePods[logPodKey(pod)] = pod
+	}
+
+	var pods []k8s.TrackedObject
+	seen := make(map[string]bool)
+	addPod := func(pod k8s.TrackedObject) {
+		key := logPodKey(pod)
+		if live, exists := livePods[key]; exists && !seen[key] {
+			seen[key] = true
+			pods = append(pods, live)
+		}
+	}
+
+	nodes := append([]*graph.Node{node}, resourceGraph.GetDescendants(node)...)
+	for _, n := range nodes {
+		if n.Resource.GetCategory() != k8s.ObjectCategoryK8sResource {
+			continue
+		}
+		for _, pod := range m.resourceService.GetOwnedPods(n.Resource) {
+			addPod(pod)
+		}
+	}
+
+	return pods
+}
+
+// startLogViewer switches to log mode and starts the log viewer's streams
+func (m *Model) startLogViewer(returnMode ViewMode) tea.Cmd {
+	m.logsReturnMode = returnMode
+	m.viewMode = ViewModeLogs
+
+	// Disable mouse to allow native terminal text selection
+	return tea.Batch(tea.DisableMouse, m.logViewer.Start())
+}
+
+// ExitLogsMode stops log streaming and returns to the view the log viewer was opened from
+func (m *Model) ExitLogsMode() tea.Cmd {
+	if m.logViewer != nil {
+		m.logViewer.Close()
+		m.logViewer = nil
+	}
+
+	m.viewMode = m.logsReturnMode
+	if m.viewMode == ViewModeVisualize && m.visualizer == nil {
+		m.viewMode = ViewModeNormal
+	}
+
+	return tea.EnableMouseCellMotion
+}
+
+// OpenContainerSelector opens the container picker for the log viewer
+func (m *Model) OpenContainerSelector() tea.Cmd {
+	if m.logViewer == nil {
+		return nil
+	}
+
+	choices := m.logViewer.ContainerChoices()
+	if len(choices) == 0 {
+		return nil
+	}
+
+	m.selector = NewContainerSelector(choices)
+	return m.selector.Init()
+}
+
 // checkMetricsAPIAndOpen checks if metrics API is available and opens the dashboard
 func (m *Model) checkMetricsAPIAndOpen() tea.Cmd {
 	return func() tea.Msg {
@@ -423,7 +708,8 @@ func (m *Model) fetchMetricsData() tea.Cmd {
 
 // EditSelectedResource opens the selected resource in an external editor
 // This properly suspends the BubbleTea program while the editor runs
-func (m *Model) EditSelectedResource() tea.Cmd {
+// Server-populated fields such as status are hidden from the editor unless full is set
+func (m *Model) EditSelectedResource(full bool) tea.Cmd {
 	// If in manifest mode, use the stored resource; otherwise get current selection
 	var resource k8s.TrackedObject
 	if m.viewMode == ViewModeManifest {
@@ -437,7 +723,7 @@ func (m *Model) EditSelectedResource() tea.Cmd {
 	}
 
 	// Prepare the edit file BEFORE suspending the TUI
-	editResult, err := m.resourceService.PrepareEditFile(resource)
+	editResult, err := m.resourceService.PrepareEditFile(resource, full)
 	if err != nil {
 		return func() tea.Msg {
 			return EditorFinishedMsg{Err: fmt.Errorf("failed to prepare edit: %w", err)}
@@ -465,22 +751,19 @@ func (m *Model) EditSelectedResource() tea.Cmd {
 			}
 		}
 
-		// Process the edited file (validates and applies changes)
-		processErr := m.resourceService.ProcessEditedFile(context.Background(), resourceCopy, editResult)
-
-		// Check if the user just cancelled (no changes made)
-		if processErr == nil {
-			// Could be either successful update or cancellation (no changes)
-			// ProcessEditedFile returns nil for both cases
-			// The logging in ProcessEditedFile will distinguish these
-			return EditorFinishedMsg{Err: nil}
+		// Process the edited file (validates and dry-runs the changes)
+		preview, processErr := m.resourceService.ProcessEditedFile(context.Background(), resourceCopy, editResult)
+		if processErr != nil {
+			return EditorFinishedMsg{Err: processErr}
 		}
 
-		// An error occurred during processing
-		// Check if a backup file was created (will be in /tmp)
-		return EditorFinishedMsg{
-			Err: processErr,
+		// No preview means the user cancelled or made no changes
+		if preview == nil {
+			return EditorFinishedMsg{Cancelled: true}
 		}
+
+		// Changes are only applied once confirmed in the preview
+		return EditPreviewMsg{Preview: preview}
 	})
 }
 
@@ -561,21 +844,65 @@ func (m *Model) PageDown() {
 	m.selectedIndex = m.table.Cursor()
 }
 
-// EnterFilterMode enters filter mode
+// EnterFilterMode enters filter mode, starting on the name filter
 func (m *Model) EnterFilterMode() {
 	m.viewMode = ViewModeFilter
+	m.filterDrafts = [filterTargetCount]string{
+		filterTargetName:   m.nameFilter.GetPattern(),
+		filterTargetLabels: m.labelFilter.GetSelector(),
+		filterTargetFields: m.fieldFilter.GetSelector(),
+		filterTargetQuery:  m.queryFilter.GetQuery(),
+	}
+	m.filterError = ""
+	m.queryFields = filters.QueryFieldCandidates(m.resources)
+	m.setFilterTarget(filterTargetName)
 	m.filterInput.Focus()
-	m.filterInput.SetValue(m.nameFilter.GetPattern())
 }
 
 // ExitFilterMode exits filter mode
 func (m *Model) ExitFilterMode() {
 	m.viewMode = ViewModeNormal
+	m.filterError = ""
 	m.filterInput.Blur()
 }
 
+// NextFilterTarget switches the filter bar to the next filter, keeping the current input as a draft
+func (m *Model) NextFilterTarget() {
+	m.filterDrafts[m.filterTarget] = m.filterInput.Value()
+	m.filterError = ""
+	m.setFilterTarget((m.filterTarget + 1) % filterTargetCount)
+}
+
+// setFilterTarget loads a filter's draft into the filter bar
+func (m *Model) setFilterTarget(target filterTarget) {
+	m.filterTarget = target
+	m.filterInput.Placeholder = target.placeholder()
+	m.filterInput.SetValue(m.filterDrafts[target])
+	m.filterInput.CursorEnd()
+	m.filterInput.ShowSuggestions = target == filterTargetQuery
+	m.RefreshQueryAssist()
+}
+
+// RefreshQueryAssist checks the query being typed and offers completions for the field at its end
+// Syntax errors are shown as the query is typed rather than only when it is applied
+func (m *Model) RefreshQueryAssist() {
+	if m.filterTarget != filterTargetQuery {
+		m.filterInput.SetSuggestions(nil)
+		return
+	}
+
+	value := m.filterInput.Value()
+	m.filterError = ""
+	if _, err := filters.ParseQuery(value); err != nil {
+		m.filterError = err.Error()
+	}
+	m.filterInput.SetSuggestions(filters.CompleteQuery(value, m.queryFields))
+}
+
 // SwitchContext switches to a new Kubernetes context
 func (m *Model) SwitchContext(contextName string) tea.Cmd {
+	m.leaveView()
+
 	// Show splash screen
 	m.viewMode = ViewModeSplash
 	m.splash = splash.NewModel(m.logger)
@@ -607,6 +934,40 @@ func (m *Model) UpdateFilter(pattern string) {
 	}
 }
 
+// ApplyFilterDrafts applies the filter bar's input for every filter
+// Selectors are validated before anything is applied, so a typo doesn't leave the filters half updated
+func (m *Model) ApplyFilterDrafts() error {
+	m.filterDrafts[m.filterTarget] = m.filterInput.Value()
+
+	nameFilter := filters.NewResourceNameFilter()
+	if err := nameFilter.SetPattern(m.filterDrafts[filterTargetName]); err != nil {
+		m.setFilterTarget(filterTargetName)
+		return fmt.Errorf("invalid name pattern: %w", err)
+	}
+	labelFilter := filters.NewLabelSelectorFilter()
+	if err := labelFilter.SetSelector(m.filterDrafts[filterTargetLabels]); err != nil {
+		m.setFilterTarget(filterTargetLabels)
+		return fmt.Errorf("invalid label selector: %w", err)
+	}
+	fieldFilter := filters.NewFieldFilter()
+	if err := fieldFilter.SetSelector(m.filterDrafts[filterTargetFields]); err != nil {
+		m.setFilterTarget(filterTargetFields)
+		return fmt.Errorf("invalid field selector: %w", err)
+	}
+	queryFilter := filters.NewQueryFilter()
+	if err := queryFilter.SetQuery(m.filterDrafts[filterTargetQuery]); err != nil {
+		m.setFilterTarget(filterTargetQuery)
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	m.nameFilter = nameFilter
+	m.labelFilter = labelFilter
+	m.fieldFilter = fieldFilter
+	m.queryFilter = queryFilter
+	m.UpdateResources()
+	return nil
+}
+
 // Helper functions
 func max(a, b int) int {
 	if a > b {
@@ -630,6 +991,46 @@ func (m *Model) GetCurrentModeHelp() help.KeyMap {
 		return m.visualizerKeys
 	case ViewModeFilter:
 		return m.filterKeys
+	case ViewModeLogs:
+		if m.logViewer != nil {
+			return m.logViewer.keys
+		}
+		return m.normalKeys
+	case ViewModePortForwards:
+		if m.portForwardPanel != nil {
+			return m.portForwardPanel.keys
+		}
+		return m.normalKeys
+	case ViewModeBulkResults:
+		if m.bulkPanel != nil {
+			return m.bulkPanel.keys
+		}
+		return m.normalKeys
+	case ViewModeEditPreview:
+		if m.editPreview != nil {
+			return m.editPreview.keys
+		}
+		return m.normalKeys
+	case ViewModeCreate:
+		if m.createPanel != nil {
+			return m.createPanel.keys
+		}
+		return m.normalKeys
+	case ViewModeHistory:
+		if m.historyPanel != nil {
+			return m.historyPanel.keys
+		}
+		return m.normalKeys
+	case ViewModeHelmHistory:
+		if m.helmHistory != nil {
+			return m.helmHistory.keys
+		}
+		return m.normalKeys
+	case ViewModeHelmAction:
+		if m.helmAction != nil {
+			return m.helmAction.keys
+		}
+		return m.normalKeys
 	default:
 		return m.normalKeys
 	}
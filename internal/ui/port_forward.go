@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles-w-3/lobot/internal/k8s"
+)
+
+// portForwardStartTimeout bounds how long starting a port-forward may take
+const portForwardStartTimeout = 30 * time.Second
+
+// PortForwardUpdateMsg is sent when a port-forward changes state
+type PortForwardUpdateMsg struct{}
+
+// PortForwardStartedMsg is sent when starting or restarting a port-forward completes
+type PortForwardStartedMsg struct {
+	Forward   k8s.PortForward
+	Restarted bool
+	Err       error
+}
+
+// PortForwardStoppedMsg is sent when stopping or removing a port-forward completes
+type PortForwardStoppedMsg struct {
+	Err error
+}
+
+// PortForwardSelectedResource prompts for ports to forward from the selected Pod or Service
+func (m *Model) PortForwardSelectedResource() tea.Cmd {
+	resource := m.GetSelectedResource()
+	if resource == nil || resource.GetRaw() == nil {
+		return nil
+	}
+
+	if kind := resource.GetKind(); kind != "Pod" && kind != "Service" {
+		m.modal.ShowInfo("Port-Forward Unavailable", "Port-forwards can be started from Pods and Services.")
+		return nil
+	}
+
+	// Suggest the first declared port, forwarded to the same local port
+	initial := ""
+	if ports := k8s.GetForwardablePorts(resource); len(ports) > 0 {
+		initial = fmt.Sprintf("%d:%d", ports[0], ports[0])
+	}
+
+	m.portForwardTarget = resource
+	m.prompt = NewPrompt(PromptTypePortForward,
+		fmt.Sprintf("Port-forward %s/%s", resource.GetKind(), resource.GetName()),
+		"LOCAL:REMOTE, REMOTE to use the same port, or :REMOTE for a free local port",
+		initial,
+		func(value string) error {
+			_, _, err := k8s.ParsePortMapping(value)
+			return err
+		})
+	m.prompt.SetWidth(min(70, m.width-10))
+	return m.prompt.Init()
+}
+
+// ApplyPortForwardPrompt starts a port-forward to the resource the prompt was opened for
+func (m *Model) ApplyPortForwardPrompt(value string) tea.Cmd {
+	resource := m.portForwardTarget
+	m.portForwardTarget = nil
+	if resource == nil {
+		return nil
+	}
+
+	localPort, remotePort, err := k8s.ParsePortMapping(value)
+	if err != nil {
+		m.modal.ShowError("Invalid Ports", err.Error())
+		return nil
+	}
+
+	req := k8s.PortForwardRequest{
+		Namespace:  resource.GetNamespace(),
+		Kind:       resource.GetKind(),
+		Name:       resource.GetName(),
+		LocalPort:  localPort,
+		RemotePort: remotePort,
+	}
+
+	service := m.resourceService
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), portForwardStartTimeout)
+		defer cancel()
+
+		forward, err := service.StartPortForward(ctx, req)
+		return PortForwardStartedMsg{Forward: forward, Err: err}
+	}
+}
+
+// handlePortForwardStarted reports the outcome of starting a port-forward
+func (m *Model) handlePortForwardStarted(msg PortForwardStartedMsg) {
+	if m.portForwardPanel != nil {
+		m.portForwardPanel.Refresh()
+	}
+
+	if msg.Err != nil {
+		if m.errorTracker != nil {
+			m.errorTracker.LogError("port-forward", msg.Err.Error())
+		}
+		m.modal.ShowError("Port-Forward Failed", msg.Err.Error())
+		return
+	}
+
+	// The panel already shows restarted forwards; new ones are announced since the local port may have been picked for the user
+	if !msg.Restarted {
+		m.modal.ShowInfo("Port-Forward Started", fmt.Sprintf("%s\n\nPress %s to manage port-forwards.",
+			describePortForward(msg.Forward), strings.Join(m.normalKeys.PortForwards.Keys(), "/")))
+	}
+}
+
+// EnterPortForwardsMode opens the port-forward panel
+func (m *Model) EnterPortForwardsMode() tea.Cmd {
+	m.portForwardPanel = NewPortForwardPanelModel(m.resourceService, m.width, m.height)
+	m.viewMode = ViewModePortForwards
+	return m.portForwardPanel.Tick()
+}
+
+// ExitPortForwardsMode closes the port-forward panel; forwards keep running in the background
+func (m *Model) ExitPortForwardsMode() {
+	m.viewMode = ViewModeNormal
+	m.portForwardPanel = nil
+}
+
+// activePortForwards returns how many port-forwards are currently running
+func (m *Model) activePortForwards() int {
+	count := 0
+	for _, forward := range m.resourceService.ListPortForwards() {
+		if forward.State == k8s.PortForwardActive {
+			count++
+		}
+	}
+	return count
+}
+
+// describePortForward returns a one-line description of where a forward sends traffic
+func describePortForward(forward k8s.PortForward) string {
+	target := fmt.Sprintf("%s/%s", forward.Request.Namespace, forward.Pod)
+	if forward.Request.Kind == "Service" {
+		target = fmt.Sprintf("service %s/%s (pod %s)", forward.Request.Namespace, forward.Request.Name, forward.Pod)
+	}
+	return fmt.Sprintf("localhost:%d → %s port %d", forward.LocalPort, target, forward.PodPort)
+}
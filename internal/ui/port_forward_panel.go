@@ -0,0 +1,338 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles-w-3/lobot/internal/k8s"
+	"github.com/miles-w-3/lobot/internal/util"
+)
+
+// portForwardRefreshInterval is how often the panel refreshes transfer counters
+const portForwardRefreshInterval = time.Second
+
+// PortForwardTickMsg triggers a refresh of the port-forward panel
+type PortForwardTickMsg struct {
+	Panel *PortForwardPanelModel
+}
+
+// PortForwardPanelKeyMap defines key bindings for the port-forward panel
+type PortForwardPanelKeyMap struct {
+	Up      key.Binding
+	Down    key.Binding
+	Stop    key.Binding
+	Restart key.Binding
+	Remove  key.Binding
+	Back    key.Binding
+}
+
+// DefaultPortForwardPanelKeyMap returns the default key bindings for the port-forward panel
+func DefaultPortForwardPanelKeyMap() PortForwardPanelKeyMap {
+	return PortForwardPanelKeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "move up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "move down"),
+		),
+		Stop: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "stop"),
+		),
+		Restart: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "restart"),
+		),
+		Remove: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "remove"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc", "q"),
+			key.WithHelp("esc/q", "back to list"),
+		),
+	}
+}
+
+// ShortHelp returns a short list of key bindings
+func (k PortForwardPanelKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Stop, k.Restart, k.Remove, k.Back}
+}
+
+// FullHelp returns the full list of key bindings organized by category
+func (k PortForwardPanelKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down},
+		{k.Stop, k.Restart, k.Remove},
+		{k.Back},
+	}
+}
+
+var (
+	portForwardSelectedStyle = lipgloss.NewStyle().
+					Foreground(lipgloss.Color("255")).
+					Background(colorSecondary).
+					Bold(true)
+
+	portForwardStoppedStyle = lipgloss.NewStyle().
+				Foreground(colorMuted)
+)
+
+// portForwardColumn is a fixed-width column of the port-forward list
+type portForwardColumn struct {
+	title string
+	width int
+}
+
+// PortForwardPanelModel lists the port-forwards owned by the resource service
+// The forwards themselves live in the service, so closing the panel leaves them running
+type PortForwardPanelModel struct {
+	service  *k8s.ResourceService
+	forwards []k8s.PortForward
+	cursor   int
+	width    int
+	height   int
+	keys     PortForwardPanelKeyMap
+	help     help.Model
+}
+
+// NewPortForwardPanelModel creates the port-forward panel
+func NewPortForwardPanelModel(service *k8s.ResourceService, width, height int) *PortForwardPanelModel {
+	panel := &PortForwardPanelModel{
+		service: service,
+		width:   width,
+		height:  height,
+		keys:    DefaultPortForwardPanelKeyMap(),
+		help:    configureHelp(),
+	}
+	panel.Refresh()
+	return panel
+}
+
+// Refresh reloads the forwards from the service
+func (p *PortForwardPanelModel) Refresh() {
+	p.forwards = p.service.ListPortForwards()
+	if p.cursor >= len(p.forwards) {
+		p.cursor = max(0, len(p.forwards)-1)
+	}
+}
+
+// Tick schedules the next refresh of the transfer counters
+func (p *PortForwardPanelModel) Tick() tea.Cmd {
+	return tea.Tick(portForwardRefreshInterval, func(time.Time) tea.Msg {
+		return PortForwardTickMsg{Panel: p}
+	})
+}
+
+// SetSize updates the panel dimensions
+func (p *PortForwardPanelModel) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// selected returns the forward under the cursor
+func (p *PortForwardPanelModel) selected() (k8s.PortForward, bool) {
+	if p.cursor < 0 || p.cursor >= len(p.forwards) {
+		return k8s.PortForward{}, false
+	}
+	return p.forwards[p.cursor], true
+}
+
+// Update handles key presses
+// Stopping and restarting talk to the cluster, so they run as commands
+func (p *PortForwardPanelModel) Update(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, p.keys.Up):
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case key.Matches(msg, p.keys.Down):
+		if p.cursor < len(p.forwards)-1 {
+			p.cursor++
+		}
+
+	case key.Matches(msg, p.keys.Stop):
+		forward, ok := p.selected()
+		if !ok || (forward.State != k8s.PortForwardActive && forward.State != k8s.PortForwardStarting) {
+			return nil
+		}
+		service, id := p.service, forward.ID
+		return func() tea.Msg {
+			return PortForwardStoppedMsg{Err: service.StopPortForward(id)}
+		}
+
+	case key.Matches(msg, p.keys.Remove):
+		forward, ok := p.selected()
+		if !ok {
+			return nil
+		}
+		service, id := p.service, forward.ID
+		return func() tea.Msg {
+			return PortForwardStoppedMsg{Err: service.RemovePortForward(id)}
+		}
+
+	case key.Matches(msg, p.keys.Restart):
+		forward, ok := p.selected()
+		if !ok {
+			return nil
+		}
+		service, id := p.service, forward.ID
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), portForwardStartTimeout)
+			defer cancel()
+
+			restarted, err := service.RestartPortForward(ctx, id)
+			return PortForwardStartedMsg{Forward: restarted, Restarted: true, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// View renders the panel
+func (p *PortForwardPanelModel) View() string {
+	active := 0
+	for _, forward := range p.forwards {
+		if forward.State == k8s.PortForwardActive {
+			active++
+		}
+	}
+
+	title := titleStyle.Render("Port Forwards")
+	details := lipgloss.NewStyle().Foreground(colorMuted).
+		Render(fmt.Sprintf("%d active • %d total", active, len(p.forwards)))
+	header := title + "  " + details
+
+	var content string
+	if len(p.forwards) == 0 {
+		content = helpStyle.Render("No port-forwards. Select a Pod or Service and press F to start one.")
+	} else {
+		content = p.renderList()
+	}
+
+	body := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorBorder).
+		Width(p.width - 2).
+		Height(p.height - 5).
+		Render(content)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		body,
+		helpStyle.Render(p.help.ShortHelpView(p.keys.ShortHelp())),
+	)
+}
+
+// renderList renders the forwards as a table followed by details of the selected forward
+func (p *PortForwardPanelModel) renderList() string {
+	columns := []portForwardColumn{
+		{"RESOURCE", 30},
+		{"POD", 30},
+		{"LOCAL", 7},
+		{"REMOTE", 7},
+		{"STATE", 9},
+		{"CONNS", 6},
+		{"SENT", 9},
+		{"RECEIVED", 9},
+		{"AGE", 5},
+	}
+
+	// The error column takes whatever width is left
+	used := 0
+	for _, col := range columns {
+		used += col.width + 1
+	}
+	columns = append(columns, portForwardColumn{"ERROR", max(10, p.width-6-used)})
+
+	headerCells := make([]string, len(columns))
+	for i, col := range columns {
+		headerCells[i] = padCell(col.title, col.width)
+	}
+	lines := []string{tableHeaderStyle.UnsetPadding().Render(strings.Join(headerCells, " "))}
+
+	for i, forward := range p.forwards {
+		resource := fmt.Sprintf("%s/%s", strings.ToLower(forward.Request.Kind), forward.Request.Name)
+		errText := ""
+		if forward.Err != nil {
+			errText = forward.Err.Error()
+		}
+
+		values := []string{
+			resource,
+			forward.Pod,
+			formatPort(forward.LocalPort),
+			formatPort(forward.PodPort),
+			forward.State.String(),
+			fmt.Sprintf("%d", forward.Connections),
+			util.FormatBytes(forward.BytesSent),
+			util.FormatBytes(forward.BytesReceived),
+			util.FormatAge(time.Since(forward.StartedAt)),
+			errText,
+		}
+
+		cells := make([]string, len(columns))
+		for j, col := range columns {
+			cells[j] = padCell(values[j], col.width)
+		}
+
+		if i == p.cursor {
+			lines = append(lines, portForwardSelectedStyle.Render(strings.Join(cells, " ")))
+			continue
+		}
+
+		// Color the state cell (index 4) to make failures stand out
+		cells[4] = portForwardStateStyle(forward.State).Render(cells[4])
+		if errText != "" {
+			cells[len(cells)-1] = logErrorStyle.Render(cells[len(cells)-1])
+		}
+		lines = append(lines, strings.Join(cells, " "))
+	}
+
+	if forward, ok := p.selected(); ok {
+		lines = append(lines, "", describePortForward(forward))
+		if forward.Err != nil {
+			lines = append(lines, logErrorStyle.Render("Error: "+forward.Err.Error()))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// portForwardStateStyle returns the style for a port-forward state
+func portForwardStateStyle(state k8s.PortForwardState) lipgloss.Style {
+	switch state {
+	case k8s.PortForwardActive:
+		return podRunningStyle
+	case k8s.PortForwardStarting:
+		return podPendingStyle
+	case k8s.PortForwardFailed:
+		return podFailedStyle
+	default:
+		return portForwardStoppedStyle
+	}
+}
+
+// formatPort formats a port number, showing a dash until it is known
+func formatPort(port int) string {
+	if port == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d", port)
+}
+
+// padCell truncates or pads a value to exactly the given width
+func padCell(value string, width int) string {
+	if len(value) > width {
+		value = util.Truncate(value, width)
+	}
+	return value + strings.Repeat(" ", width-len(value))
+}
@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PromptType represents what a prompt's value is used for
+type PromptType int
+
+const (
+	PromptTypePortForward PromptType = iota
+	PromptTypeCustomColumn
+	PromptTypeSaveView
+	PromptTypeBulkMetadata
+	PromptTypeBulkScale
+	PromptTypeBulkExport
+	PromptTypeScale
+	PromptTypeCreateFile
+	PromptTypeConfirmWrite
+)
+
+// PromptModel is a small text input dialog rendered over the current view
+type PromptModel struct {
+	input      textinput.Model
+	promptType PromptType
+	title      string
+	hint       string
+	validate   func(string) error
+	errMsg     string
+	width      int
+	visible    bool
+}
+
+// PromptFinishedMsg is sent when a prompt is confirmed or cancelled
+type PromptFinishedMsg struct {
+	Value      string
+	PromptType PromptType
+	Cancelled  bool
+}
+
+// NewPrompt creates a prompt with an initial value
+// validate is called on enter; an error keeps the prompt open and is shown below the input
+func NewPrompt(promptType PromptType, title, hint, initial string, validate func(string) error) *PromptModel {
+	input := textinput.New()
+	input.CharLimit = 256
+	input.SetValue(initial)
+	input.CursorEnd()
+	input.Focus()
+
+	return &PromptModel{
+		input:      input,
+		promptType: promptType,
+		title:      title,
+		hint:       hint,
+		validate:   validate,
+		width:      60,
+		visible:    true,
+	}
+}
+
+// Init initializes the prompt
+func (p *PromptModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles messages
+func (p *PromptModel) Update(msg tea.Msg) (*PromptModel, tea.Cmd) {
+	if !p.visible {
+		return p, nil
+	}
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			p.visible = false
+			return p, func() tea.Msg {
+				return PromptFinishedMsg{
+					PromptType: p.promptType,
+					Cancelled:  true,
+				}
+			}
+
+		case "enter":
+			value := p.input.Value()
+			if p.validate != nil {
+				if err := p.validate(value); err != nil {
+					p.errMsg = err.Error()
+					return p, nil
+				}
+			}
+			p.visible = false
+			return p, func() tea.Msg {
+				return PromptFinishedMsg{
+					Value:      value,
+					PromptType: p.promptType,
+				}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return p, cmd
+}
+
+// View renders the prompt
+func (p *PromptModel) View() string {
+	if !p.visible {
+		return ""
+	}
+
+	title := lipgloss.NewStyle().
+		Foreground(colorAccent).
+		Bold(true).
+		Render(p.title)
+
+	sections := []string{title, "", p.input.View()}
+	if p.errMsg != "" {
+		sections = append(sections, lipgloss.NewStyle().Foreground(colorDanger).Render(p.errMsg))
+	}
+	if p.hint != "" {
+		sections = append(sections, "", helpStyle.Render(p.hint))
+	}
+	sections = append(sections, helpStyle.Render("enter to confirm • esc to cancel"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorAccent).
+		Padding(1, 2).
+		Width(p.width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+}
+
+// SetWidth sets the prompt width
+func (p *PromptModel) SetWidth(width int) {
+	p.width = width
+	p.input.Width = width - 8
+}
+
+// IsVisible returns whether the prompt is currently visible
+func (p *PromptModel) IsVisible() bool {
+	return p.visible
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles-w-3/lobot/internal/k8s"
+)
+
+// writeMode returns what may be changed in the current context
+func (m *Model) writeMode() k8s.WriteMode {
+	return m.resourceService.GetClient().WriteMode()
+}
+
+// applyWriteMode hides the key bindings of actions that change the cluster when lobot is read-only
+// The client refuses such changes regardless; this keeps them from being offered
+func (m *Model) applyWriteMode() {
+	writable := m.writeMode() != k8s.WriteDenied
+	for _, binding := range []*key.Binding{
+		&m.normalKeys.Edit,
+		&m.normalKeys.EditFull,
+		&m.normalKeys.Exec,
+		&m.normalKeys.Delete,
+		&m.normalKeys.RemoveFinalizers,
+		&m.normalKeys.WorkloadActions,
+		&m.normalKeys.Create,
+		&m.manifestKeys.Edit,
+		&m.manifestKeys.EditFull,
+	} {
+		binding.SetEnabled(writable)
+	}
+}
+
+// guardWrite runs an action that changes the cluster, as the context's write mode allows
+// In a protected context the context name must be typed to confirm first, so run may be called from a later update:
+// it gets the model to change and the context to make the change with, which lets its requests through the client's write protection
+func (m *Model) guardWrite(action string, run func(m *Model, base context.Context) tea.Cmd) tea.Cmd {
+	switch m.writeMode() {
+	case k8s.WriteDenied:
+		m.modal.ShowError("Read-Only Mode", action+" is disabled: lobot was started with --read-only.")
+		return nil
+
+	case k8s.WriteConfirm:
+		contextName := m.resourceService.GetCurrentContext()
+		m.pendingWrite = func(m *Model) tea.Cmd {
+			return run(m, k8s.ConfirmWrites(context.Background()))
+		}
+		m.prompt = NewPrompt(PromptTypeConfirmWrite,
+			"Confirm "+action,
+			fmt.Sprintf("Context %s is protected. Type its name to confirm", contextName),
+			"",
+			func(value string) error {
+				if value != contextName {
+					return fmt.Errorf("doesn't match the context name")
+				}
+				return nil
+			})
+		m.prompt.SetWidth(min(90, m.width-10))
+		return m.prompt.Init()
+
+	default:
+		return run(m, context.Background())
+	}
+}
+
+// ApplyConfirmWritePrompt runs the change the context name was typed for
+func (m *Model) ApplyConfirmWritePrompt() tea.Cmd {
+	pending := m.pendingWrite
+	m.pendingWrite = nil
+	if pending == nil {
+		return nil
+	}
+	return pending(m)
+}
+
+// writeModeBadge returns the status line badge for the current context's write mode, or "" when changes are allowed
+func (m *Model) writeModeBadge() string {
+	badgeStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#1a1a1a")).
+		Bold(true).
+		Padding(0, 1)
+
+	switch m.writeMode() {
+	case k8s.WriteDenied:
+		return badgeStyle.Background(colorWarning).Render("READ-ONLY")
+	case k8s.WriteConfirm:
+		return badgeStyle.Background(colorDanger).Render("PROTECTED")
+	default:
+		return ""
+	}
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"context"
+	"fmt"
 	"sort"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -16,6 +17,13 @@ const (
 	SelectorTypeNamespace SelectorType = iota
 	SelectorTypeContext
 	SelectorTypeResourceType
+	SelectorTypeContainer
+	SelectorTypeExecContainer
+	SelectorTypeView
+	SelectorTypeBulkAction
+	SelectorTypeWorkloadAction
+	SelectorTypeRevision
+	SelectorTypeCreateSource
 )
 
 // SelectorModel wraps the promptkit selection model
@@ -68,6 +76,115 @@ func NewContextSelector(contexts []string, current string) *SelectorModel {
 	}
 }
 
+// NewContainerSelector creates a new container selector
+func NewContainerSelector(containers []string) *SelectorModel {
+	sel := selection.New("Select Container:", containers)
+	sel.Filter = selection.FilterContainsCaseInsensitive // Enable searchable filtering
+	sel.LoopCursor = true
+
+	// Create the selection model
+	model := selection.NewModel(sel)
+
+	return &SelectorModel{
+		selection:    model,
+		selectorType: SelectorTypeContainer,
+		visible:      true,
+	}
+}
+
+// NewExecContainerSelector creates a container selector for starting an exec session
+func NewExecContainerSelector(containers []string) *SelectorModel {
+	sel := selection.New("Exec Into Container:", containers)
+	sel.Filter = selection.FilterContainsCaseInsensitive // Enable searchable filtering
+	sel.LoopCursor = true
+
+	// Create the selection model
+	model := selection.NewModel(sel)
+
+	return &SelectorModel{
+		selection:    model,
+		selectorType: SelectorTypeExecContainer,
+		visible:      true,
+	}
+}
+
+// NewViewSelector creates a new saved view selector
+func NewViewSelector(views []string) *SelectorModel {
+	sel := selection.New("Select View:", views)
+	sel.Filter = selection.FilterContainsCaseInsensitive // Enable searchable filtering
+	sel.LoopCursor = true
+
+	// Create the selection model
+	model := selection.NewModel(sel)
+
+	return &SelectorModel{
+		selection:    model,
+		selectorType: SelectorTypeView,
+		visible:      true,
+	}
+}
+
+// NewBulkActionSelector creates a selector for the action to apply to marked resources
+func NewBulkActionSelector(actions []string, count int) *SelectorModel {
+	sel := selection.New(fmt.Sprintf("Apply to %d marked:", count), actions)
+	sel.LoopCursor = true
+
+	// Create the selection model
+	model := selection.NewModel(sel)
+
+	return &SelectorModel{
+		selection:    model,
+		selectorType: SelectorTypeBulkAction,
+		visible:      true,
+	}
+}
+
+// NewWorkloadActionSelector creates a selector for the action to apply to a workload
+func NewWorkloadActionSelector(workload string, actions []string) *SelectorModel {
+	sel := selection.New(workload+":", actions)
+	sel.LoopCursor = true
+
+	// Create the selection model
+	model := selection.NewModel(sel)
+
+	return &SelectorModel{
+		selection:    model,
+		selectorType: SelectorTypeWorkloadAction,
+		visible:      true,
+	}
+}
+
+// NewRevisionSelector creates a selector for the revision to roll a workload back to
+func NewRevisionSelector(revisions []string) *SelectorModel {
+	sel := selection.New("Roll back to:", revisions)
+	sel.Filter = selection.FilterContainsCaseInsensitive // Enable searchable filtering
+	sel.LoopCursor = true
+
+	// Create the selection model
+	model := selection.NewModel(sel)
+
+	return &SelectorModel{
+		selection:    model,
+		selectorType: SelectorTypeRevision,
+		visible:      true,
+	}
+}
+
+// NewCreateSourceSelector creates a selector for where a new resource's manifest comes from
+func NewCreateSourceSelector(sources []string) *SelectorModel {
+	sel := selection.New("Create:", sources)
+	sel.LoopCursor = true
+
+	// Create the selection model
+	model := selection.NewModel(sel)
+
+	return &SelectorModel{
+		selection:    model,
+		selectorType: SelectorTypeCreateSource,
+		visible:      true,
+	}
+}
+
 // Init initializes the selector
 func (s *SelectorModel) Init() tea.Cmd {
 	return s.selection.Init()
@@ -271,6 +388,11 @@ func (m *Model) ApplyResourceTypeSelection(displayName string) tea.Cmd {
 		return nil
 	}
 
+	return m.switchToTrackedType(selectedType)
+}
+
+// switchToTrackedType shows a resource type, adding it to the rotation and starting its informer if needed
+func (m *Model) switchToTrackedType(selectedType *k8s.TrackedType) tea.Cmd {
 	// Check if this type is already in our rotation
 	typeIndex := -1
 	for i := range m.trackedTypes {
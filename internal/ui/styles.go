@@ -1,6 +1,9 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/miles-w-3/lobot/internal/k8s"
+)
 
 var (
 	// Brand colors - imported from colors.go
@@ -96,14 +99,35 @@ var (
 
 // GetStatusStyle returns the appropriate style for a resource status
 func GetStatusStyle(status string) lipgloss.Style {
-	switch status {
-	case "Running", "Active", "Available", "True":
+	return GetSeverityStyle(k8s.SeverityForStatus(status))
+}
+
+// GetSeverityStyle returns the style used for statuses of the given severity
+func GetSeverityStyle(severity k8s.Severity) lipgloss.Style {
+	switch severity {
+	case k8s.SeverityOK:
 		return podRunningStyle
-	case "Pending", "Progressing":
+	case k8s.SeverityProgressing, k8s.SeverityWarning:
 		return podPendingStyle
-	case "Failed", "Error", "False", "CrashLoopBackOff":
+	case k8s.SeverityError:
 		return podFailedStyle
 	default:
-		return lipgloss.NewStyle()
+		return lipgloss.NewStyle().Foreground(colorMuted)
+	}
+}
+
+// getSeverityIndicator returns a glyph for a severity, so status is readable without colors
+func getSeverityIndicator(severity k8s.Severity) string {
+	switch severity {
+	case k8s.SeverityOK:
+		return "●"
+	case k8s.SeverityProgressing:
+		return "◐"
+	case k8s.SeverityWarning:
+		return "▲"
+	case k8s.SeverityError:
+		return "✗"
+	default:
+		return "○"
 	}
 }
@@ -33,6 +33,7 @@ type TreeVisualizerModel struct {
 	rootResource    k8s.TrackedObject
 	keys            TreeVisualizerKeyMap
 	detailsViewport viewport.Model
+	events          EventLookup
 }
 
 // treeNode represents a flattened tree node for cursor navigation
@@ -44,7 +45,7 @@ type treeNode struct {
 }
 
 // NewTreeVisualizerModel creates a new tree visualizer with viewport-based scrolling
-func NewTreeVisualizerModel(resourceGraph *graph.ResourceGraph, width, height int) TreeVisualizerModel {
+func NewTreeVisualizerModel(resourceGraph *graph.ResourceGraph, events EventLookup, width, height int) TreeVisualizerModel {
 	// Calculate panel widths
 	detailsWidth := 35  // Reduced from 40 to give more space to tree
 	treeWidth := width - detailsWidth - 2  // Account for gap between panels
@@ -74,6 +75,7 @@ func NewTreeVisualizerModel(resourceGraph *graph.ResourceGraph, width, height in
 		expandedNodes:   make(map[*graph.Node]bool),
 		rootResource:    resourceGraph.Root.Resource,
 		keys:            DefaultTreeVisualizerKeyMap(),
+		events:          events,
 	}
 
 	// Expand all nodes by default
@@ -255,7 +257,7 @@ func (m *TreeVisualizerModel) updateDetailsPanel(node *graph.Node) {
 	if res.GetNamespace() != "" {
 		details.WriteString(fmt.Sprintf("Namespace: %s\n", res.GetNamespace()))
 	}
-	details.WriteString(fmt.Sprintf("Status: %s\n", res.GetStatus()))
+	details.WriteString(fmt.Sprintf("Status: %s\n", GetSeverityStyle(res.GetSeverity()).Render(res.GetStatus())))
 
 	// Show revision for Helm releases
 	if helmRes, ok := res.(*k8s.HelmRelease); ok && helmRes.HelmRevision > 0 {
@@ -285,6 +287,9 @@ func (m *TreeVisualizerModel) updateDetailsPanel(node *graph.Node) {
 		}
 	}
 
+	// Show recent events about the resource
+	details.WriteString(renderDetailsEvents(m.events, res, m.detailsViewport.Width))
+
 	m.detailsViewport.SetContent(details.String())
 }
 
@@ -426,6 +431,14 @@ func (m *TreeVisualizerModel) ensureSelectedVisible() {
 	}
 }
 
+// SelectedNode returns the currently selected graph node, or nil if the tree is empty
+func (m *TreeVisualizerModel) SelectedNode() *graph.Node {
+	if m.selectedIndex < 0 || m.selectedIndex >= len(m.flattenedNodes) {
+		return nil
+	}
+	return m.flattenedNodes[m.selectedIndex].graphNode
+}
+
 // toggleCurrentNode toggles expand/collapse for the currently selected node
 func (m *TreeVisualizerModel) toggleCurrentNode() {
 	if m.selectedIndex < 0 || m.selectedIndex >= len(m.flattenedNodes) {
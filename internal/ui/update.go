@@ -43,6 +43,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.table.SetHeight(tableHeight)
 		// Table width should fill the border container (border takes 2 chars, content has 4 chars padding)
 		m.table.SetWidth(m.width - 6 - 10) // TODO: -10 for favorites
+		m.resizeTableColumns()
 
 		// Update manifest viewport size if in manifest mode
 		if m.viewMode == ViewModeManifest {
@@ -50,6 +51,35 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.manifestViewport.Height = m.height - 6
 		}
 
+		if m.logViewer != nil {
+			m.logViewer.SetSize(m.width, m.height)
+		}
+
+		if m.portForwardPanel != nil {
+			m.portForwardPanel.SetSize(m.width, m.height)
+		}
+		if m.bulkPanel != nil {
+			m.bulkPanel.SetSize(m.width, m.height)
+		}
+		if m.editPreview != nil {
+			m.editPreview.SetSize(m.width, m.height)
+		}
+		if m.createPanel != nil {
+			m.createPanel.SetSize(m.width, m.height)
+		}
+		if m.historyPanel != nil {
+			m.historyPanel.SetSize(m.width, m.height)
+		}
+		if m.helmHistory != nil {
+			m.helmHistory.SetSize(m.width, m.height)
+		}
+		if m.helmAction != nil {
+			m.helmAction.SetSize(m.width, m.height)
+		}
+		if m.prompt != nil {
+			m.prompt.SetWidth(min(70, m.width-10))
+		}
+
 		// Update modal size
 		modalWidth := min(80, m.width-10)
 		modalHeight := min(20, m.height-10)
@@ -89,7 +119,95 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case ResourceUpdateMsg:
+		selected := m.GetSelectedResource()
 		m.UpdateResources()
+		m.reselectResource(selected)
+		m.refreshRollout()
+		m.refreshManifestEvents()
+		m.refreshHelmHistory()
+		if m.visualizer != nil {
+			m.visualizer.RefreshDetails()
+		}
+		if m.logViewer != nil {
+			m.logViewer.Reconcile()
+		}
+		return m, nil
+
+	case LogLinesMsg:
+		if m.logViewer != nil {
+			return m, m.logViewer.HandleLines(msg)
+		}
+		return m, nil
+
+	case PromptFinishedMsg:
+		if !msg.Cancelled {
+			switch msg.PromptType {
+			case PromptTypePortForward:
+				return m, m.ApplyPortForwardPrompt(msg.Value)
+			case PromptTypeCustomColumn:
+				m.ApplyCustomColumnPrompt(msg.Value)
+			case PromptTypeSaveView:
+				m.ApplySaveViewPrompt(msg.Value)
+			case PromptTypeBulkMetadata, PromptTypeBulkScale, PromptTypeBulkExport:
+				return m, m.ApplyBulkPrompt(msg.PromptType, msg.Value)
+			case PromptTypeScale:
+				return m, m.ApplyScalePrompt(msg.Value)
+			case PromptTypeCreateFile:
+				return m, m.ApplyCreateFilePrompt(msg.Value)
+			case PromptTypeConfirmWrite:
+				return m, m.ApplyConfirmWritePrompt()
+			}
+		}
+		m.portForwardTarget = nil
+		m.pendingWrite = nil
+		return m, nil
+
+	case ConfirmedMsg:
+		return m, m.ApplyConfirmed(msg)
+
+	case ResourceActionFinishedMsg:
+		if msg.Err != nil {
+			m.showActionError(msg)
+		} else if msg.TrackRollout {
+			m.trackRollout(msg)
+		}
+		return m, nil
+
+	case WorkloadRevisionsMsg:
+		return m, m.ApplyWorkloadRevisions(msg)
+
+	case BulkItemMsg:
+		return m, m.ApplyBulkItemUpdate(msg)
+
+	case BulkFinishedMsg:
+		m.FinishBulkOperation(msg.Operation)
+		return m, nil
+
+	case PortForwardUpdateMsg:
+		if m.portForwardPanel != nil {
+			m.portForwardPanel.Refresh()
+		}
+		return m, nil
+
+	case PortForwardTickMsg:
+		// Ticks from a panel that has since been closed are dropped
+		if m.portForwardPanel != nil && msg.Panel == m.portForwardPanel {
+			m.portForwardPanel.Refresh()
+			return m, m.portForwardPanel.Tick()
+		}
+		return m, nil
+
+	case PortForwardStartedMsg:
+		m.handlePortForwardStarted(msg)
+		return m, nil
+
+	case PortForwardStoppedMsg:
+		if msg.Err != nil {
+			m.modal.ShowError("Port-Forward Error", msg.Err.Error())
+		}
+		if m.portForwardPanel != nil {
+			m.portForwardPanel.Refresh()
+		}
 		return m, nil
 
 	case SelectorFinishedMsg:
@@ -102,6 +220,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.SwitchContext(msg.SelectedValue)
 			case SelectorTypeResourceType:
 				return m, m.ApplyResourceTypeSelection(msg.SelectedValue)
+			case SelectorTypeContainer:
+				if m.logViewer != nil {
+					return m, m.logViewer.SelectContainer(msg.SelectedValue)
+				}
+			case SelectorTypeExecContainer:
+				return m, m.ApplyExecContainerSelection(msg.SelectedValue)
+			case SelectorTypeView:
+				return m, m.ApplyViewSelection(msg.SelectedValue)
+			case SelectorTypeBulkAction:
+				return m, m.ApplyBulkActionSelection(msg.SelectedValue)
+			case SelectorTypeWorkloadAction:
+				return m, m.ApplyWorkloadActionSelection(msg.SelectedValue)
+			case SelectorTypeRevision:
+				return m, m.ApplyRevisionSelection(msg.SelectedValue)
+			case SelectorTypeCreateSource:
+				return m, m.ApplyCreateSourceSelection(msg.SelectedValue)
 			}
 		}
 		return m, nil
@@ -110,7 +244,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Build the graph for the resource
 		if msg.Resource != nil {
 			resourceGraph := m.graphBuilder.BuildGraph(msg.Resource)
-			visualizer := NewVisualizerModel(resourceGraph, m.width, m.height)
+			visualizer := NewVisualizerModel(resourceGraph, m.resourceService.GetEventsForObject, m.width, m.height)
 			m.visualizer = &visualizer
 			m.viewMode = ViewModeVisualize
 		}
@@ -141,6 +275,47 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.viewMode = ViewModeUtilization
 		return m, nil
 
+	case ExecFinishedMsg:
+		if msg.Err != nil {
+			if m.errorTracker != nil {
+				m.errorTracker.LogError("exec", msg.Err.Error())
+			}
+			m.modal.ShowError("Exec Failed", msg.Err.Error())
+		}
+		return m, nil
+
+	case EditPreviewMsg:
+		m.ShowEditPreview(msg.Preview)
+		return m, nil
+
+	case CreatePreviewMsg:
+		m.ShowCreatePreview(msg)
+		return m, nil
+
+	case CreateDocumentMsg:
+		return m, m.ApplyCreateDocument(msg)
+
+	case HistoryLoadedMsg:
+		m.ShowHistory(msg)
+		return m, nil
+
+	case HistoryRevertedMsg:
+		return m, m.ApplyHistoryReverted(msg)
+
+	case HelmDiffMsg:
+		m.ShowHelmDiff(msg)
+		return m, nil
+
+	case HelmActionPlanMsg:
+		m.ShowHelmActionPlan(msg)
+		return m, nil
+
+	case HelmStepMsg:
+		return m, m.ApplyHelmStep(msg)
+
+	case HelmWaitMsg:
+		return m, m.ApplyHelmWait(msg)
+
 	case EditorFinishedMsg:
 		if msg.Err != nil {
 			// Show error in modal instead of status message
@@ -150,10 +325,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			var message string
 
 			// Detect specific error types and provide helpful messages
-			if strings.Contains(errStr, "conflict:") {
+			if strings.Contains(errStr, "merge conflict:") {
+				title = "Merge Conflict"
+				message = errStr + "\n\n" +
+					"Press f in the preview to apply your version anyway, or esc to discard it."
+			} else if strings.Contains(errStr, "conflict:") {
 				title = "Conflict Detected"
-				message = "The resource was modified on the cluster after you opened the editor.\n\n" +
-					"The resource version has changed. Please try editing again to get the latest version."
+				message = "Other field managers own fields you changed.\n\n" +
+					"Press m in the preview to merge onto the live object, or f to take ownership of the fields."
 			} else if strings.Contains(errStr, "validation failed:") {
 				title = "Validation Failed"
 				message = "The edited manifest failed Kubernetes validation.\n\n" +
@@ -184,8 +363,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			m.modal.ShowError(title, message)
-		} else {
-			// Success case - silent (like vim :wq)
+		} else if !msg.Cancelled {
+			// Success case - silent (like vim :wq); the backup can be reverted from the history browser
+			if msg.BackupPath != "" {
+				m.logger.Info("Edit applied", "backup", msg.BackupPath)
+			}
+			if m.viewMode == ViewModeEditPreview {
+				m.ExitEditPreview()
+			}
 			// Trigger a resource refresh to show any updates
 			m.UpdateResources()
 			// If in manifest mode, refresh the manifest view with the updated resource
@@ -198,6 +383,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if key.Matches(msg, m.globalKeys.Quit) {
 			return m, tea.Quit
 		}
+
+		// Prompts take text input, so they get keys before the remaining global bindings
+		if m.prompt != nil && m.prompt.IsVisible() {
+			m.prompt, cmd = m.prompt.Update(msg)
+			return m, cmd
+		}
+
 		if key.Matches(msg, m.globalKeys.Help) {
 			// Toggle help modal
 			if m.modal.IsVisible() && m.modal.modalType == ModalTypeHelp {
@@ -233,6 +425,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleMouseEvent(msg)
 	}
 
+	// Keep the prompt's cursor blinking
+	if m.prompt != nil && m.prompt.IsVisible() {
+		m.prompt, cmd = m.prompt.Update(msg)
+		return m, cmd
+	}
+
 	// Handle filter input updates when in filter mode
 	if m.viewMode == ViewModeFilter {
 		m.filterInput, cmd = m.filterInput.Update(msg)
@@ -283,6 +481,22 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleVisualizeModeKeys(msg)
 	case ViewModeUtilization:
 		return m.handleUtilizationModeKeys(msg)
+	case ViewModeLogs:
+		return m.handleLogsModeKeys(msg)
+	case ViewModePortForwards:
+		return m.handlePortForwardsModeKeys(msg)
+	case ViewModeBulkResults:
+		return m.handleBulkResultsModeKeys(msg)
+	case ViewModeEditPreview:
+		return m.handleEditPreviewModeKeys(msg)
+	case ViewModeCreate:
+		return m.handleCreateModeKeys(msg)
+	case ViewModeHistory:
+		return m.handleHistoryModeKeys(msg)
+	case ViewModeHelmHistory:
+		return m.handleHelmHistoryModeKeys(msg)
+	case ViewModeHelmAction:
+		return m.handleHelmActionModeKeys(msg)
 	case ViewModeNormal:
 		return m.handleNormalModeKeys(msg)
 	case ViewModeSplash:
@@ -352,13 +566,95 @@ func (m Model) handleNormalModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, m.normalKeys.ContextSelector):
 		return m, m.OpenContextSelector()
 
-	// View manifest
+	// View manifest, or the revision history of a Helm release
 	case key.Matches(msg, m.normalKeys.Enter):
+		if release, ok := m.GetSelectedResource().(*k8s.HelmRelease); ok {
+			m.OpenHelmHistory(release)
+			return m, nil
+		}
 		return m, m.EnterManifestMode()
 
+	// Stream logs for the selected pod
+	case key.Matches(msg, m.normalKeys.Logs):
+		return m, m.EnterLogsMode()
+
+	// Open a shell in the selected pod
+	case key.Matches(msg, m.normalKeys.Exec):
+		return m, m.ExecIntoSelectedPod()
+
+	// Forward a local port to the selected pod or service
+	case key.Matches(msg, m.normalKeys.PortForward):
+		return m, m.PortForwardSelectedResource()
+
+	// Manage running port-forwards
+	case key.Matches(msg, m.normalKeys.PortForwards):
+		return m, m.EnterPortForwardsMode()
+
+	// Sort the table by the next column, or reverse the current sort
+	case key.Matches(msg, m.normalKeys.Sort):
+		m.CycleSortColumn()
+		return m, nil
+
+	case key.Matches(msg, m.normalKeys.ReverseSort):
+		m.ReverseSort()
+		return m, nil
+
+	// Add or remove a custom column for the current resource type
+	case key.Matches(msg, m.normalKeys.CustomColumn):
+		return m, m.PromptCustomColumn()
+
+	// Delete the selected resource, or clear its finalizers when it is stuck terminating
+	case key.Matches(msg, m.normalKeys.Delete):
+		if release, ok := m.GetSelectedResource().(*k8s.HelmRelease); ok {
+			return m, m.PlanHelmUninstall(release)
+		}
+		m.ConfirmDeleteSelected()
+		return m, nil
+
+	case key.Matches(msg, m.normalKeys.RemoveFinalizers):
+		m.ConfirmRemoveFinalizers()
+		return m, nil
+
+	// Mark resources and apply an action to all of them
+	case key.Matches(msg, m.normalKeys.Mark):
+		m.ToggleMark()
+		return m, nil
+
+	case key.Matches(msg, m.normalKeys.MarkAll):
+		m.MarkAll()
+		return m, nil
+
+	case key.Matches(msg, m.normalKeys.InvertMarks):
+		m.InvertMarks()
+		return m, nil
+
+	case key.Matches(msg, m.normalKeys.BulkAction):
+		return m, m.OpenBulkActionSelector()
+
+	// Scale, restart, pause/resume or roll back the selected workload
+	case key.Matches(msg, m.normalKeys.WorkloadActions):
+		return m, m.OpenWorkloadActions()
+	case key.Matches(msg, m.normalKeys.Create):
+		return m, m.OpenCreateSelector()
+	case key.Matches(msg, m.normalKeys.History):
+		return m, m.OpenHistory()
+
+	// Saved views
+	case key.Matches(msg, m.normalKeys.Views):
+		return m, m.OpenViewSelector()
+
+	case key.Matches(msg, m.normalKeys.SaveView):
+		return m, m.PromptSaveView(false)
+
+	case key.Matches(msg, m.normalKeys.SaveContextView):
+		return m, m.PromptSaveView(true)
+
 	// Edit resource with external editor
 	case key.Matches(msg, m.normalKeys.Edit):
-		return m, m.EditSelectedResource()
+		return m, m.EditSelectedResource(false)
+
+	case key.Matches(msg, m.normalKeys.EditFull):
+		return m, m.EditSelectedResource(true)
 
 	// Visualize resource relationships
 	case key.Matches(msg, m.normalKeys.Visualize):
@@ -391,20 +687,28 @@ func (m Model) handleFilterModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	switch {
 	case key.Matches(msg, m.filterKeys.Accept):
-		// Apply filter
-		pattern := m.filterInput.Value()
-		m.UpdateFilter(pattern)
+		// Apply filters, staying in filter mode to fix an invalid one
+		if err := m.ApplyFilterDrafts(); err != nil {
+			m.filterError = err.Error()
+			return m, nil
+		}
 		m.ExitFilterMode()
 		return m, nil
 
+	case key.Matches(msg, m.filterKeys.NextField) && len(m.filterInput.MatchedSuggestions()) == 0:
+		// Switch between the name, label, field and query filters
+		m.NextFilterTarget()
+		return m, nil
+
 	case key.Matches(msg, m.filterKeys.Cancel):
 		// Cancel filter
 		m.ExitFilterMode()
 		return m, nil
 
 	default:
-		// Update text input
+		// Update text input; tab accepts a query completion when one is offered
 		m.filterInput, cmd = m.filterInput.Update(msg)
+		m.RefreshQueryAssist()
 	}
 
 	return m, cmd
@@ -419,7 +723,10 @@ func (m Model) handleManifestModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, m.ExitManifestMode()
 
 	case key.Matches(msg, m.manifestKeys.Edit):
-		return m, m.EditSelectedResource()
+		return m, m.EditSelectedResource(false)
+
+	case key.Matches(msg, m.manifestKeys.EditFull):
+		return m, m.EditSelectedResource(true)
 
 	case key.Matches(msg, m.manifestKeys.Copy):
 		return m.CopyManifestToClipboard()
@@ -436,6 +743,9 @@ func (m Model) handleVisualizeModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, m.visualizerKeys.Back):
 		m.ExitVisualizeMode()
 		return m, nil
+
+	case key.Matches(msg, m.visualizerKeys.Logs):
+		return m, m.EnterNodeLogsMode()
 	}
 
 	// Pass all other keys to the visualizer component
@@ -466,6 +776,161 @@ func (m Model) handleUtilizationModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleLogsModeKeys handles keys in log viewing mode
+func (m Model) handleLogsModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.logViewer == nil {
+		return m, m.ExitLogsMode()
+	}
+
+	// Keys are forwarded untouched while typing a search term
+	if !m.logViewer.IsSearching() {
+		switch {
+		case key.Matches(msg, m.logViewer.keys.Back):
+			return m, m.ExitLogsMode()
+		case key.Matches(msg, m.logViewer.keys.Container):
+			return m, m.OpenContainerSelector()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.logViewer, cmd = m.logViewer.Update(msg)
+	return m, cmd
+}
+
+// handlePortForwardsModeKeys handles keys in the port-forward panel
+func (m Model) handlePortForwardsModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.portForwardPanel == nil || key.Matches(msg, m.portForwardPanel.keys.Back) {
+		m.ExitPortForwardsMode()
+		return m, nil
+	}
+
+	return m, m.portForwardPanel.Update(msg)
+}
+
+// handleBulkResultsModeKeys handles keys in the bulk results panel
+func (m Model) handleBulkResultsModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.bulkPanel == nil || key.Matches(msg, m.bulkPanel.keys.Back) {
+		m.ExitBulkResultsMode()
+		return m, nil
+	}
+
+	return m, m.bulkPanel.Update(msg)
+}
+
+// handleEditPreviewModeKeys handles keys in the edit preview
+func (m Model) handleEditPreviewModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.editPreview == nil || key.Matches(msg, m.editPreview.keys.Cancel) {
+		m.ExitEditPreview()
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.editPreview.keys.Apply):
+		return m, m.applyEdit(false)
+	case key.Matches(msg, m.editPreview.keys.Force):
+		return m, m.applyEdit(true)
+	case key.Matches(msg, m.editPreview.keys.Merge):
+		return m, m.mergeEdit()
+	}
+
+	return m, m.editPreview.Update(msg)
+}
+
+// handleCreateModeKeys handles keys in the create panel
+func (m Model) handleCreateModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.createPanel == nil || key.Matches(msg, m.createPanel.keys.Back) {
+		m.ExitCreateMode()
+		return m, nil
+	}
+
+	if key.Matches(msg, m.createPanel.keys.Apply) {
+		return m, m.ApplyCreate()
+	}
+
+	return m, m.createPanel.Update(msg)
+}
+
+// handleHistoryModeKeys handles keys in the history browser
+func (m Model) handleHistoryModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.historyPanel == nil || key.Matches(msg, m.historyPanel.keys.Back) {
+		m.ExitHistoryMode()
+		return m, nil
+	}
+
+	if key.Matches(msg, m.historyPanel.keys.Revert) {
+		m.ConfirmRevertSelected()
+		return m, nil
+	}
+
+	return m, m.historyPanel.Update(msg)
+}
+
+// handleHelmHistoryModeKeys handles keys in the Helm release history
+func (m Model) handleHelmHistoryModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.helmHistory == nil {
+		m.ExitHelmHistoryMode()
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.helmHistory.keys.Back):
+		if m.helmHistory.Viewing() {
+			m.helmHistory.ClosePage()
+		} else {
+			m.ExitHelmHistoryMode()
+		}
+		return m, nil
+	case key.Matches(msg, m.helmHistory.keys.View):
+		m.helmHistory.ViewSelected()
+		return m, nil
+	case key.Matches(msg, m.helmHistory.keys.NextTab):
+		m.helmHistory.SwitchTab(1)
+		return m, nil
+	case key.Matches(msg, m.helmHistory.keys.PrevTab):
+		m.helmHistory.SwitchTab(-1)
+		return m, nil
+	case key.Matches(msg, m.helmHistory.keys.Mark):
+		m.helmHistory.ToggleMark()
+		return m, nil
+	case key.Matches(msg, m.helmHistory.keys.Diff):
+		m.DiffHelmRevisions()
+		return m, nil
+	case key.Matches(msg, m.helmHistory.keys.DiffLive):
+		return m, m.DiffHelmRevisionLive()
+	case key.Matches(msg, m.helmHistory.keys.Rollback):
+		return m, m.PlanHelmRollback()
+	case key.Matches(msg, m.helmHistory.keys.Uninstall):
+		return m, m.PlanHelmUninstall(m.helmHistory.release)
+	}
+
+	return m, m.helmHistory.Update(msg)
+}
+
+// handleHelmActionModeKeys handles keys in the Helm rollback and uninstall panel
+func (m Model) handleHelmActionModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.helmAction == nil {
+		m.ExitHelmActionMode()
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.helmAction.keys.Back):
+		if m.helmAction.ShowingDiff() {
+			m.helmAction.ToggleDiff()
+		} else {
+			m.ExitHelmActionMode()
+		}
+		return m, nil
+	case key.Matches(msg, m.helmAction.keys.Diff):
+		m.helmAction.ToggleDiff()
+		return m, nil
+	case key.Matches(msg, m.helmAction.keys.Apply):
+		return m, m.ApplyHelmAction()
+	}
+
+	return m, m.helmAction.Update(msg)
+}
+
 // handleMouseEvent handles mouse input
 func (m Model) handleMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	switch msg.Button {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/miles-w-3/lobot/internal/k8s"
 )
 
 // View renders the UI
@@ -20,6 +21,22 @@ func (m Model) View() string {
 		baseView = m.renderVisualizeView()
 	} else if m.viewMode == ViewModeUtilization {
 		baseView = m.renderUtilizationView()
+	} else if m.viewMode == ViewModeLogs {
+		baseView = m.renderLogsView()
+	} else if m.viewMode == ViewModePortForwards {
+		baseView = m.renderPortForwardsView()
+	} else if m.viewMode == ViewModeBulkResults {
+		baseView = m.renderBulkResultsView()
+	} else if m.viewMode == ViewModeEditPreview && m.editPreview != nil {
+		baseView = m.editPreview.View()
+	} else if m.viewMode == ViewModeCreate && m.createPanel != nil {
+		baseView = m.createPanel.View()
+	} else if m.viewMode == ViewModeHistory && m.historyPanel != nil {
+		baseView = m.historyPanel.View()
+	} else if m.viewMode == ViewModeHelmHistory && m.helmHistory != nil {
+		baseView = m.helmHistory.View()
+	} else if m.viewMode == ViewModeHelmAction && m.helmAction != nil {
+		baseView = m.helmAction.View()
 	} else {
 		baseView = m.renderNormalView()
 	}
@@ -29,6 +46,11 @@ func (m Model) View() string {
 		return m.renderSelectorOverlay(baseView)
 	}
 
+	// If prompt is visible, render it centered over the view
+	if m.prompt != nil && m.prompt.IsVisible() {
+		return overlayCenter(baseView, m.prompt.View(), m.width, m.height)
+	}
+
 	// If modal is visible (including help modal), render it as an overlay
 	if m.modal.IsVisible() {
 		return m.renderModalOverlay(baseView)
@@ -154,6 +176,11 @@ func (m Model) renderStatusLine() string {
 
 	left := clusterStyle.Render(fmt.Sprintf("▶ %s", clusterName))
 
+	// Show when changes to the cluster are refused or need confirming
+	if badge := m.writeModeBadge(); badge != "" {
+		left += "  " + badge
+	}
+
 	// Add error indicator if errors have been logged
 	if m.errorTracker != nil && m.errorTracker.HasErrors() {
 		errorStyle := lipgloss.NewStyle().
@@ -162,6 +189,37 @@ func (m Model) renderStatusLine() string {
 		left += "  " + errorStyle.Render(fmt.Sprintf("⚠ %d errors (see error.log)", m.errorTracker.GetErrorCount()))
 	}
 
+	// Show running port-forwards, which keep going in the background
+	if active := m.activePortForwards(); active > 0 {
+		forwardStyle := lipgloss.NewStyle().
+			Foreground(colorAccent).
+			Bold(true)
+		left += "  " + forwardStyle.Render(fmt.Sprintf("⇄ %d port-forwards", active))
+	}
+
+	// Show the progress of a rollout started from here
+	if m.rollout != nil {
+		icon, color := "⟳", colorAccent
+		switch {
+		case m.rollout.status.Failed:
+			icon, color = "✗", colorDanger
+		case m.rollout.status.Done:
+			icon, color = "✓", colorSuccess
+		}
+		rolloutStyle := lipgloss.NewStyle().
+			Foreground(color).
+			Bold(true)
+		left += "  " + rolloutStyle.Render(icon+" "+m.rollout.summary())
+	}
+
+	// Show how many of the listed resources are marked for a bulk action
+	if marked := len(m.markedResources()); marked > 0 {
+		markStyle := lipgloss.NewStyle().
+			Foreground(colorSecondary).
+			Bold(true)
+		left += "  " + markStyle.Render(fmt.Sprintf("%s %d marked", markIndicator, marked))
+	}
+
 	// Build right side with resource type, update time, and refresh interval
 	rightParts := []string{
 		resourceBadgeStyle.Render(fmt.Sprintf("● %s", currentType.DisplayName)),
@@ -196,9 +254,13 @@ func (m Model) renderStatusLine() string {
 
 // renderFilterBar renders the filter input bar
 func (m Model) renderFilterBar() string {
-	label := "Resource name filter: "
+	label := m.filterTarget.label() + ": "
 	input := m.filterInput.View()
 	content := label + input
+	if m.filterError != "" {
+		// Kept on the same line so the table height doesn't change
+		content += "  " + lipgloss.NewStyle().Foreground(colorDanger).Render(m.filterError)
+	}
 	return filterBarStyle.Render(content)
 }
 
@@ -208,7 +270,34 @@ func (m Model) renderResourceTable() string {
 		return helpStyle.Render("No resources found")
 	}
 
-	return m.table.View()
+	return styleTableRows(m.table.View())
+}
+
+// styleTableRows colors table rows by the status indicator in their first column
+// Cells must stay plain text for the table to measure them, so color is applied to the rendered lines;
+// the header and selected row already carry their own styling and are left alone
+func styleTableRows(view string) string {
+	lines := strings.Split(view, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		for _, severity := range []k8s.Severity{k8s.SeverityOK, k8s.SeverityProgressing, k8s.SeverityWarning, k8s.SeverityError, k8s.SeverityUnknown} {
+			indicator := getSeverityIndicator(severity)
+			if !strings.HasPrefix(trimmed, indicator+" ") {
+				continue
+			}
+
+			style := GetSeverityStyle(severity)
+			if severity == k8s.SeverityWarning || severity == k8s.SeverityError {
+				// Problem rows are colored in full so they stand out when scanning the list
+				lines[i] = style.Render(line)
+			} else {
+				indent := line[:len(line)-len(trimmed)]
+				lines[i] = indent + style.Render(indicator) + strings.TrimPrefix(trimmed, indicator)
+			}
+			break
+		}
+	}
+	return strings.Join(lines, "\n")
 }
 
 // renderStatusBar renders the status bar
@@ -237,6 +326,17 @@ func (m Model) renderStatusBar() string {
 		activeFilters = append(activeFilters, fmt.Sprintf("name:%s", pattern))
 	}
 
+	// Label and field selectors
+	if selector := m.labelFilter.GetSelector(); selector != "" {
+		activeFilters = append(activeFilters, fmt.Sprintf("labels:%s", selector))
+	}
+	if selector := m.fieldFilter.GetSelector(); selector != "" {
+		activeFilters = append(activeFilters, fmt.Sprintf("fields:%s", selector))
+	}
+	if query := m.queryFilter.GetQuery(); query != "" {
+		activeFilters = append(activeFilters, fmt.Sprintf("query:%s", query))
+	}
+
 	if len(activeFilters) > 0 {
 		filterStyle := lipgloss.NewStyle().Foreground(colorAccent)
 		filterInfo := fmt.Sprintf("filters: %s", strings.Join(activeFilters, ", "))
@@ -347,6 +447,32 @@ func (m Model) renderUtilizationView() string {
 	return m.utilizationDashboard.View()
 }
 
+// renderLogsView renders the log viewer
+func (m Model) renderLogsView() string {
+	if m.logViewer == nil {
+		return "Starting log stream..."
+	}
+
+	return m.logViewer.View()
+}
+
+// renderBulkResultsView renders the bulk results panel
+func (m Model) renderBulkResultsView() string {
+	if m.bulkPanel == nil {
+		return ""
+	}
+	return m.bulkPanel.View()
+}
+
+// renderPortForwardsView renders the port-forward panel
+func (m Model) renderPortForwardsView() string {
+	if m.portForwardPanel == nil {
+		return "Loading port-forwards..."
+	}
+
+	return m.portForwardPanel.View()
+}
+
 // overlayCenter overlays content centered on a base view
 func overlayCenter(base, overlay string, width, height int) string {
 	overlayLines := strings.Split(overlay, "\n")
@@ -0,0 +1,226 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles-w-3/lobot/internal/config"
+	"github.com/miles-w-3/lobot/internal/filters"
+	"github.com/miles-w-3/lobot/internal/k8s"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// recalledView holds the columns of a recalled view
+// They replace the saved custom columns of the view's type for the session, and are never saved as them
+type recalledView struct {
+	gvr     schema.GroupVersionResource
+	columns []config.CustomColumn
+}
+
+// leaveView brings back the saved custom columns of the type a recalled view showed
+func (m *Model) leaveView() {
+	if m.recalledView == nil {
+		return
+	}
+	delete(m.customColumns, m.recalledView.gvr)
+	m.recalledView = nil
+}
+
+// columnConfig returns the custom columns shown for a resource type: a recalled view's, or the saved ones
+func (m *Model) columnConfig(gvr schema.GroupVersionResource) []config.CustomColumn {
+	if m.recalledView != nil && m.recalledView.gvr == gvr {
+		return m.recalledView.columns
+	}
+	return m.config.CustomColumns(resourceConfigKey(gvr))
+}
+
+// viewLabel is how a saved view is listed in the view selector
+func viewLabel(view config.View) string {
+	if view.Context != "" {
+		return fmt.Sprintf("%s (%s)", view.Name, view.Context)
+	}
+	return view.Name
+}
+
+// OpenViewSelector opens the selector of views saved for the current context
+func (m *Model) OpenViewSelector() tea.Cmd {
+	if m.config == nil {
+		return nil
+	}
+
+	views := m.config.ViewsFor(m.resourceService.GetCurrentContext())
+	if len(views) == 0 {
+		m.modal.ShowInfo("No Saved Views",
+			"Press w to save the current view, or W to save it for this context only.")
+		return nil
+	}
+
+	labels := make([]string, len(views))
+	for i, view := range views {
+		labels[i] = viewLabel(view)
+	}
+	m.selector = NewViewSelector(labels)
+	return m.selector.Init()
+}
+
+// ApplyViewSelection recalls the view picked in the view selector
+func (m *Model) ApplyViewSelection(label string) tea.Cmd {
+	for _, view := range m.config.ViewsFor(m.resourceService.GetCurrentContext()) {
+		if viewLabel(view) == label {
+			return m.applyView(view)
+		}
+	}
+	m.modal.ShowError("Not Found", "View not found: "+label)
+	return nil
+}
+
+// applyView switches to a view's resource type and restores its filters, columns and sort order
+func (m *Model) applyView(view config.View) tea.Cmd {
+	trackedType := m.findTrackedType(view.Resource)
+	if trackedType == nil {
+		m.modal.ShowError("Resource Type Not Found",
+			fmt.Sprintf("View %q shows %s, which this cluster doesn't serve.", view.Name, view.Resource))
+		return nil
+	}
+
+	// Views can be edited by hand, so the selectors are validated before anything changes
+	nameFilter := filters.NewResourceNameFilter()
+	if err := nameFilter.SetPattern(view.NameFilter); err != nil {
+		m.modal.ShowError("Invalid View", fmt.Sprintf("Invalid name filter in view %q: %v", view.Name, err))
+		return nil
+	}
+	labelFilter := filters.NewLabelSelectorFilter()
+	if err := labelFilter.SetSelector(view.LabelSelector); err != nil {
+		m.modal.ShowError("Invalid View", fmt.Sprintf("Invalid label selector in view %q: %v", view.Name, err))
+		return nil
+	}
+	fieldFilter := filters.NewFieldFilter()
+	if err := fieldFilter.SetSelector(view.FieldSelector); err != nil {
+		m.modal.ShowError("Invalid View", fmt.Sprintf("Invalid field selector in view %q: %v", view.Name, err))
+		return nil
+	}
+	queryFilter := filters.NewQueryFilter()
+	if err := queryFilter.SetQuery(view.Query); err != nil {
+		m.modal.ShowError("Invalid View", fmt.Sprintf("Invalid query in view %q: %v", view.Name, err))
+		return nil
+	}
+
+	m.namespaceFilter.SetPattern(view.Namespace)
+	m.nameFilter = nameFilter
+	m.labelFilter = labelFilter
+	m.fieldFilter = fieldFilter
+	m.queryFilter = queryFilter
+
+	// The view's columns are shown in place of the type's saved ones until the view is left
+	m.leaveView()
+	m.recalledView = &recalledView{gvr: trackedType.GVR, columns: slices.Clone(view.Columns)}
+	delete(m.customColumns, trackedType.GVR)
+
+	delete(m.sortStates, trackedType.GVR)
+	if view.Sort != nil {
+		for i, col := range m.tableColumns(trackedType) {
+			if strings.EqualFold(col.Title, view.Sort.Column) {
+				if m.sortStates == nil {
+					m.sortStates = make(map[schema.GroupVersionResource]sortState)
+				}
+				m.sortStates[trackedType.GVR] = sortState{column: i, descending: view.Sort.Descending}
+				break
+			}
+		}
+	}
+
+	return m.switchToTrackedType(trackedType)
+}
+
+// findTrackedType finds a resource type by its config key, looking in the rotation before discovering
+func (m *Model) findTrackedType(resourceKey string) *k8s.TrackedType {
+	for _, trackedType := range m.trackedTypes {
+		if resourceConfigKey(trackedType.GVR) == resourceKey {
+			return trackedType
+		}
+	}
+
+	discovered, err := m.resourceService.GetAllResourceTypes()
+	if err != nil {
+		m.logger.Warn("Failed to discover resource types", "error", err)
+		return nil
+	}
+	for _, trackedType := range discovered {
+		if resourceConfigKey(trackedType.GVR) == resourceKey {
+			return trackedType
+		}
+	}
+	return nil
+}
+
+// currentView captures the current resource type, filters, sort order and columns as a view
+func (m *Model) currentView(name, context string) config.View {
+	trackedType := m.trackedTypes[m.currentType]
+	key := resourceConfigKey(trackedType.GVR)
+
+	view := config.View{
+		Name:          name,
+		Context:       context,
+		Resource:      key,
+		Namespace:     m.namespaceFilter.GetPattern(),
+		NameFilter:    m.nameFilter.GetPattern(),
+		LabelSelector: m.labelFilter.GetSelector(),
+		FieldSelector: m.fieldFilter.GetSelector(),
+		Query:         m.queryFilter.GetQuery(),
+		Columns:       slices.Clone(m.columnConfig(trackedType.GVR)),
+	}
+
+	if state, ok := m.sortStates[trackedType.GVR]; ok {
+		if columns := m.tableColumns(trackedType); state.column < len(columns) {
+			view.Sort = &config.ViewSort{Column: columns[state.column].Title, Descending: state.descending}
+		}
+	}
+	return view
+}
+
+// PromptSaveView asks for the name to save the current view under
+// Scoped views are only offered in the current kube context
+func (m *Model) PromptSaveView(scoped bool) tea.Cmd {
+	if m.config == nil || len(m.trackedTypes) == 0 {
+		return nil
+	}
+
+	title := "Save view"
+	m.viewContext = ""
+	if scoped {
+		m.viewContext = m.resourceService.GetCurrentContext()
+		title = fmt.Sprintf("Save view for context %s", m.viewContext)
+	}
+
+	m.prompt = NewPrompt(PromptTypeSaveView, title,
+		"NAME to save the resource type, filters, sort order and columns, or -NAME to delete a view",
+		"",
+		func(value string) error {
+			if strings.TrimLeft(strings.TrimSpace(value), "-") == "" {
+				return fmt.Errorf("view name is empty")
+			}
+			return nil
+		})
+	m.prompt.SetWidth(min(90, m.width-10))
+	return m.prompt.Init()
+}
+
+// ApplySaveViewPrompt saves or deletes a view and saves the config
+func (m *Model) ApplySaveViewPrompt(value string) {
+	name := strings.TrimSpace(value)
+	if remove, ok := strings.CutPrefix(name, "-"); ok {
+		remove = strings.TrimSpace(remove)
+		if !m.config.DeleteView(remove, m.viewContext) {
+			m.modal.ShowError("Not Found", "View not found: "+remove)
+			return
+		}
+	} else {
+		m.config.SetView(m.currentView(name, m.viewContext))
+	}
+
+	if err := m.config.Save(); err != nil {
+		m.modal.ShowError("Failed to Save Config", err.Error())
+	}
+}
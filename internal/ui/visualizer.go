@@ -2,11 +2,9 @@ package ui
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/charmbracelet/bubbles/help"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/miles-w-3/lobot/internal/graph"
 	"github.com/miles-w-3/lobot/internal/k8s"
 )
@@ -36,13 +34,14 @@ type VisualizerModel struct {
 	width           int
 	height          int
 	rootResource    k8s.TrackedObject // The resource that triggered visualization
+	events          EventLookup       // Supplies events for the details panel
 	keys            VisualizerModeKeyMap
 }
 
 // NewVisualizerModel creates a new visualizer model
-func NewVisualizerModel(resourceGraph *graph.ResourceGraph, width, height int) VisualizerModel {
+func NewVisualizerModel(resourceGraph *graph.ResourceGraph, events EventLookup, width, height int) VisualizerModel {
 	// Create tree visualizer (default mode)
-	treeVisualizer := NewTreeVisualizerModel(resourceGraph, width, height)
+	treeVisualizer := NewTreeVisualizerModel(resourceGraph, events, width, height)
 
 	return VisualizerModel{
 		mode:            VisualizationModeTree,
@@ -52,6 +51,7 @@ func NewVisualizerModel(resourceGraph *graph.ResourceGraph, width, height int) V
 		width:           width,
 		height:          height,
 		rootResource:    resourceGraph.Root.Resource,
+		events:          events,
 		keys:            DefaultVisualizerModeKeyMap(),
 	}
 }
@@ -68,7 +68,7 @@ func (m VisualizerModel) Update(msg tea.Msg) (VisualizerModel, tea.Cmd) {
 			if m.mode == VisualizationModeTree {
 				// Lazy initialize graph visualizer
 				if m.graphVisualizer == nil {
-					m.graphVisualizer = NewGraphVisualizerModel(m.graph, m.width, m.height)
+					m.graphVisualizer = NewGraphVisualizerModel(m.graph, m.events, m.width, m.height)
 				}
 				m.mode = VisualizationModeGraph
 			} else {
@@ -83,7 +83,7 @@ func (m VisualizerModel) Update(msg tea.Msg) (VisualizerModel, tea.Cmd) {
 		m.height = msg.Height
 		if m.graphVisualizer != nil {
 			// Recreate graph visualizer with new size
-			m.graphVisualizer = NewGraphVisualizerModel(m.graph, m.width, m.height)
+			m.graphVisualizer = NewGraphVisualizerModel(m.graph, m.events, m.width, m.height)
 		}
 		// Tree visualizer handles resize in its own Update
 	}
@@ -117,6 +117,27 @@ func (m *VisualizerModel) GetKeyMap() help.KeyMap {
 	return m.treeVisualizer.keys
 }
 
+// SelectedNode returns the graph node currently selected in the active visualizer
+func (m *VisualizerModel) SelectedNode() *graph.Node {
+	if m.mode == VisualizationModeGraph && m.graphVisualizer != nil {
+		return m.graphVisualizer.SelectedNode()
+	}
+	return m.treeVisualizer.SelectedNode()
+}
+
+// RefreshDetails re-renders the details panels, e.g. after new events arrive
+func (m *VisualizerModel) RefreshDetails() {
+	m.treeVisualizer.updateDetailsPanel(m.treeVisualizer.SelectedNode())
+	if m.graphVisualizer != nil {
+		m.graphVisualizer.updateDetailsPanel(m.graphVisualizer.detailsNode)
+	}
+}
+
+// Graph returns the resource graph being visualized
+func (m *VisualizerModel) Graph() *graph.ResourceGraph {
+	return m.graph
+}
+
 // Helper functions shared by visualizers
 
 // findRootNodes finds all nodes in the graph that have no parents
@@ -163,53 +184,13 @@ func addNamespaceLabel(node *graph.Node, rootResource k8s.TrackedObject, baseNam
 
 // formatResourceDesc formats the resource description (status indicator)
 func formatResourceDesc(node *graph.Node) string {
-	status := node.Resource.GetStatus()
-	indicator := getStatusIndicator(status)
-
-	style := lipgloss.NewStyle()
-
-	// Color by status
-	if strings.Contains(strings.ToLower(status), "running") ||
-		strings.Contains(strings.ToLower(status), "ready") ||
-		strings.Contains(strings.ToLower(status), "active") {
-		style = style.Foreground(ColorSuccess)
-	} else if strings.Contains(strings.ToLower(status), "pending") ||
-		strings.Contains(strings.ToLower(status), "creating") {
-		style = style.Foreground(ColorWarning)
-	} else if strings.Contains(strings.ToLower(status), "failed") ||
-		strings.Contains(strings.ToLower(status), "error") {
-		style = style.Foreground(ColorDanger)
-	} else {
-		style = style.Foreground(ColorMuted)
-	}
-
-	return style.Render(fmt.Sprintf("[%s] %s", status, indicator))
+	return GetSeverityStyle(node.Resource.GetSeverity()).Render(formatResourceDescPlain(node))
 }
 
 // formatResourceDescPlain formats the resource description without styling (for selected rows)
 func formatResourceDescPlain(node *graph.Node) string {
-	status := node.Resource.GetStatus()
-	indicator := getStatusIndicator(status)
-	return fmt.Sprintf("[%s] %s", status, indicator)
-}
-
-// getStatusIndicator returns a visual indicator for the status
-func getStatusIndicator(status string) string {
-	status = strings.ToLower(status)
-
-	if strings.Contains(status, "running") ||
-		strings.Contains(status, "ready") ||
-		strings.Contains(status, "active") {
-		return "●" // Green dot
-	} else if strings.Contains(status, "pending") ||
-		strings.Contains(status, "creating") {
-		return "◐" // Half-filled circle
-	} else if strings.Contains(status, "failed") ||
-		strings.Contains(status, "error") {
-		return "✗" // X mark
-	}
-
-	return "○" // Empty circle for unknown
+	indicator := getSeverityIndicator(node.Resource.GetSeverity())
+	return fmt.Sprintf("[%s] %s", node.Resource.GetStatus(), indicator)
 }
 
 // getColorForKind returns a color code for a given resource kind
@@ -0,0 +1,288 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/miles-w-3/lobot/internal/k8s"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Workload actions, as listed in the workload action selector
+const (
+	workloadActionScale    = "Scale"
+	workloadActionRestart  = "Restart"
+	workloadActionPause    = "Pause rollout"
+	workloadActionResume   = "Resume rollout"
+	workloadActionRollback = "Roll back"
+)
+
+const (
+	// workloadActionTimeout bounds a workload action request
+	workloadActionTimeout = 30 * time.Second
+	// rolloutLinger is how long a finished rollout stays in the status bar
+	rolloutLinger = 10 * time.Second
+)
+
+// WorkloadRevisionsMsg is sent when a workload's revisions have been listed for a rollback
+type WorkloadRevisionsMsg struct {
+	Resource  k8s.TrackedObject
+	Revisions []k8s.WorkloadRevision
+	Err       error
+}
+
+// rolloutProgress follows a workload's rollout after an action, using informer updates
+type rolloutProgress struct {
+	resource k8s.TrackedObject
+	gvr      schema.GroupVersionResource
+	action   string
+	// resourceVersion is the version before the action; the cache still holding it means the change hasn't arrived
+	resourceVersion string
+	status          k8s.RolloutStatus
+	finishedAt      time.Time
+}
+
+// workloadActionsFor returns the workload actions that apply to a resource
+func workloadActionsFor(resource k8s.TrackedObject) []string {
+	var actions []string
+	if k8s.IsScalable(resource) {
+		actions = append(actions, workloadActionScale)
+	}
+	if k8s.IsRestartable(resource) {
+		actions = append(actions, workloadActionRestart)
+	}
+	if k8s.IsPausable(resource) {
+		if paused, _, _ := unstructured.NestedBool(resource.GetRaw().Object, "spec", "paused"); paused {
+			actions = append(actions, workloadActionResume)
+		} else {
+			actions = append(actions, workloadActionPause)
+		}
+	}
+	if k8s.IsRestartable(resource) {
+		actions = append(actions, workloadActionRollback)
+	}
+	return actions
+}
+
+// OpenWorkloadActions opens the list of actions for the selected workload
+func (m *Model) OpenWorkloadActions() tea.Cmd {
+	resource := m.GetSelectedResource()
+	if resource == nil || resource.GetRaw() == nil {
+		return nil
+	}
+
+	actions := workloadActionsFor(resource)
+	if len(actions) == 0 {
+		m.modal.ShowInfo("No Workload Actions",
+			"Scale, restart, pause/resume and roll back apply to Deployments, StatefulSets, ReplicaSets and DaemonSets.")
+		return nil
+	}
+
+	m.workloadTarget = resource
+	m.selector = NewWorkloadActionSelector(describeResource(resource), actions)
+	return m.selector.Init()
+}
+
+// ApplyWorkloadActionSelection runs the chosen workload action, asking for what it needs first
+func (m *Model) ApplyWorkloadActionSelection(action string) tea.Cmd {
+	target := m.workloadTarget
+	if target == nil {
+		return nil
+	}
+	client := m.resourceService.GetClient()
+
+	switch action {
+	case workloadActionScale:
+		replicas, found, _ := unstructured.NestedInt64(target.GetRaw().Object, "spec", "replicas")
+		if !found {
+			replicas = 1
+		}
+		m.prompt = NewPrompt(PromptTypeScale,
+			"Scale "+describeResource(target),
+			"Number of replicas",
+			fmt.Sprintf("%d", replicas),
+			func(value string) error {
+				_, err := parseReplicas(value)
+				return err
+			})
+		m.prompt.SetWidth(min(90, m.width-10))
+		return m.prompt.Init()
+
+	case workloadActionRestart:
+		m.modal.ShowConfirm("Restart "+target.GetKind(),
+			fmt.Sprintf("Roll out new pods for %s?\nPods are replaced following its update strategy.", describeResource(target)),
+			ConfirmActionRestart, nil)
+		return nil
+
+	case workloadActionPause, workloadActionResume:
+		paused := action == workloadActionPause
+		return m.runWorkloadAction(target, action, !paused, func(ctx context.Context) error {
+			return client.SetWorkloadPaused(ctx, target, paused)
+		})
+
+	case workloadActionRollback:
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), workloadActionTimeout)
+			defer cancel()
+			revisions, err := client.ListWorkloadRevisions(ctx, target)
+			return WorkloadRevisionsMsg{Resource: target, Revisions: revisions, Err: err}
+		}
+	}
+	return nil
+}
+
+// ApplyScalePrompt scales the workload the scale prompt was opened for
+func (m *Model) ApplyScalePrompt(value string) tea.Cmd {
+	target := m.workloadTarget
+	if target == nil {
+		return nil
+	}
+
+	replicas, err := parseReplicas(value)
+	if err != nil {
+		m.modal.ShowError("Invalid Input", err.Error())
+		return nil
+	}
+
+	client := m.resourceService.GetClient()
+	recorder := m.recorder()
+	return m.runWorkloadAction(target, workloadActionScale, true, func(ctx context.Context) error {
+		_, err := recorder.run(ctx, workloadActionScale, target, func(ctx context.Context) error {
+			return client.ScaleWorkload(ctx, target, replicas)
+		})
+		return err
+	})
+}
+
+// applyWorkloadConfirmed runs a workload action accepted in a confirmation modal
+func (m *Model) applyWorkloadConfirmed(msg ConfirmedMsg) tea.Cmd {
+	target := m.workloadTarget
+	if target == nil || msg.Action != ConfirmActionRestart {
+		return nil
+	}
+
+	client := m.resourceService.GetClient()
+	return m.runWorkloadAction(target, workloadActionRestart, true, func(ctx context.Context) error {
+		return client.RestartWorkload(ctx, target)
+	})
+}
+
+// revisionLabel describes a revision in the rollback selector
+// It identifies the chosen revision too, so it must not change between listing and choosing
+func revisionLabel(revision k8s.WorkloadRevision) string {
+	label := fmt.Sprintf("Revision %d • %s • %s", revision.Revision, revision.Images, revision.Created.Format("2006-01-02 15:04"))
+	if revision.Current {
+		label += " (current)"
+	}
+	return label
+}
+
+// ApplyWorkloadRevisions offers the listed revisions to roll back to
+func (m *Model) ApplyWorkloadRevisions(msg WorkloadRevisionsMsg) tea.Cmd {
+	if msg.Err != nil {
+		m.showActionError(ResourceActionFinishedMsg{Action: "Roll Back", Resource: msg.Resource, Err: msg.Err})
+		return nil
+	}
+	if len(msg.Revisions) < 2 {
+		m.modal.ShowInfo("No Earlier Revisions", fmt.Sprintf("%s has no earlier revision to roll back to.", describeResource(msg.Resource)))
+		return nil
+	}
+
+	m.workloadTarget = msg.Resource
+	m.workloadRevisions = msg.Revisions
+	labels := make([]string, len(msg.Revisions))
+	for i, revision := range msg.Revisions {
+		labels[i] = revisionLabel(revision)
+	}
+	m.selector = NewRevisionSelector(labels)
+	return m.selector.Init()
+}
+
+// ApplyRevisionSelection rolls the workload back to the chosen revision
+func (m *Model) ApplyRevisionSelection(label string) tea.Cmd {
+	target := m.workloadTarget
+	if target == nil {
+		return nil
+	}
+
+	for _, revision := range m.workloadRevisions {
+		if revisionLabel(revision) != label {
+			continue
+		}
+		if revision.Current {
+			m.modal.ShowInfo("Already Current", fmt.Sprintf("%s is already at revision %d.", describeResource(target), revision.Revision))
+			return nil
+		}
+
+		client := m.resourceService.GetClient()
+		return m.runWorkloadAction(target, workloadActionRollback, true, func(ctx context.Context) error {
+			return client.RollbackWorkload(ctx, target, revision)
+		})
+	}
+	return nil
+}
+
+// runWorkloadAction runs a workload action, following the rollout it starts when trackRollout is set
+func (m *Model) runWorkloadAction(resource k8s.TrackedObject, action string, trackRollout bool, run func(context.Context) error) tea.Cmd {
+	return m.guardWrite(action, func(_ *Model, base context.Context) tea.Cmd {
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(base, workloadActionTimeout)
+			defer cancel()
+			return ResourceActionFinishedMsg{Action: action, Resource: resource, Err: run(ctx), TrackRollout: trackRollout}
+		}
+	})
+}
+
+// trackRollout starts following the rollout of a workload an action succeeded on
+func (m *Model) trackRollout(msg ResourceActionFinishedMsg) {
+	res, ok := msg.Resource.(*k8s.K8sResource)
+	if !ok || msg.Resource.GetRaw() == nil {
+		return
+	}
+
+	m.rollout = &rolloutProgress{
+		resource:        msg.Resource,
+		gvr:             res.GVR,
+		action:          msg.Action,
+		resourceVersion: msg.Resource.GetRaw().GetResourceVersion(),
+		status:          k8s.RolloutStatus{Message: "waiting for the change to arrive"},
+	}
+	m.refreshRollout()
+}
+
+// refreshRollout updates the followed rollout from the informer cache, dropping it a while after it finishes
+func (m *Model) refreshRollout() {
+	rollout := m.rollout
+	if rollout == nil {
+		return
+	}
+	if !rollout.finishedAt.IsZero() {
+		if time.Since(rollout.finishedAt) > rolloutLinger {
+			m.rollout = nil
+		}
+		return
+	}
+
+	uid := rollout.resource.GetRaw().GetUID()
+	for _, resource := range m.resourceService.GetResources(rollout.gvr) {
+		raw := resource.GetRaw()
+		if raw == nil || raw.GetUID() != uid || raw.GetResourceVersion() == rollout.resourceVersion {
+			continue
+		}
+
+		rollout.status = k8s.GetRolloutStatus(raw)
+		if rollout.status.Done || rollout.status.Failed {
+			rollout.finishedAt = time.Now()
+		}
+		return
+	}
+}
+
+// summary describes the followed rollout for the status bar
+func (r *rolloutProgress) summary() string {
+	return fmt.Sprintf("%s %s/%s: %s", r.action, strings.ToLower(r.resource.GetKind()), r.resource.GetName(), r.status.Message)
+}
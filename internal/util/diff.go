@@ -0,0 +1,182 @@
+package util
+
+import "strings"
+
+// DiffOp is how a line of a diff relates the old text to the new
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffInsert
+	DiffDelete
+)
+
+// DiffLine is one line of a line diff
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// DiffText compares two texts line by line
+func DiffText(oldText, newText string) []DiffLine {
+	return DiffLines(splitLines(oldText), splitLines(newText))
+}
+
+// splitLines splits text into lines, ignoring a trailing newline
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// DiffLines returns the edits that turn a into b, a shortest edit script found with Myers' O(ND) algorithm
+// Memory grows with the number of lines rather than their product, so large, very different manifests stay cheap
+func DiffLines(a, b []string) []DiffLine {
+	return diffLines(make([]DiffLine, 0, len(a)+len(b)), a, b)
+}
+
+// diffLines appends the edits that turn a into b to diff
+// Common leading and trailing lines are matched up front; the rest is split where the shortest edit script
+// crosses its middle, and each half is diffed in turn
+func diffLines(diff []DiffLine, a, b []string) []DiffLine {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	for _, line := range a[:prefix] {
+		diff = append(diff, DiffLine{DiffEqual, line})
+	}
+
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	switch x, y, ok := diffMiddle(midA, midB); {
+	case len(midA) == 0 || len(midB) == 0 || !ok:
+		for _, line := range midA {
+			diff = append(diff, DiffLine{DiffDelete, line})
+		}
+		for _, line := range midB {
+			diff = append(diff, DiffLine{DiffInsert, line})
+		}
+	default:
+		diff = diffLines(diff, midA[:x], midB[:y])
+		diff = diffLines(diff, midA[x:], midB[y:])
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		diff = append(diff, DiffLine{DiffEqual, line})
+	}
+	return diff
+}
+
+// diffMiddle finds where a shortest edit script of a into b crosses its middle, searching forward from the start
+// and backward from the end until the two searches meet
+// It reports false when a and b have nothing in common, or no split would make progress
+func diffMiddle(a, b []string) (int, int, bool) {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return 0, 0, false
+	}
+
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	// forward[offset+k] is the furthest x reached on diagonal k = x-y from the start;
+	// backward[offset+k] is the same from the end, counting lines back from it
+	forward := make([]int, 2*maxD+2)
+	backward := make([]int, 2*maxD+2)
+	for i := range forward {
+		forward[i], backward[i] = -1, -1
+	}
+	forward[offset+1], backward[offset+1] = 0, 0
+
+	delta := n - m
+	// With an odd delta the searches meet on a forward step, otherwise on a backward one
+	odd := delta%2 != 0
+	// Diagonals that ran off the edge of the grid are skipped on later steps
+	forwardStart, forwardEnd, backwardStart, backwardEnd := 0, 0, 0, 0
+
+	for d := 0; d < maxD; d++ {
+		for k := -d + forwardStart; k <= d-forwardEnd; k += 2 {
+			i := offset + k
+			var x int
+			if k == -d || (k != d && forward[i-1] < forward[i+1]) {
+				x = forward[i+1]
+			} else {
+				x = forward[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			forward[i] = x
+
+			switch {
+			case x > n:
+				forwardEnd += 2
+			case y > m:
+				forwardStart += 2
+			case odd:
+				j := offset + delta - k
+				if j >= 0 && j < len(backward) && backward[j] != -1 && x >= n-backward[j] {
+					return splitPoint(x, y, n, m)
+				}
+			}
+		}
+
+		for k := -d + backwardStart; k <= d-backwardEnd; k += 2 {
+			i := offset + k
+			var x int
+			if k == -d || (k != d && backward[i-1] < backward[i+1]) {
+				x = backward[i+1]
+			} else {
+				x = backward[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[n-x-1] == b[m-y-1] {
+				x++
+				y++
+			}
+			backward[i] = x
+
+			switch {
+			case x > n:
+				backwardEnd += 2
+			case y > m:
+				backwardStart += 2
+			case !odd:
+				j := offset + delta - k
+				if j >= 0 && j < len(forward) && forward[j] != -1 {
+					forwardX := forward[j]
+					forwardY := forwardX - (j - offset)
+					if forwardX >= n-x {
+						return splitPoint(forwardX, forwardY, n, m)
+					}
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// splitPoint returns a point to split a diff at, or false when splitting there would leave one half the whole diff
+func splitPoint(x, y, n, m int) (int, int, bool) {
+	if (x == 0 && y == 0) || (x == n && y == m) {
+		return 0, 0, false
+	}
+	return x, y, true
+}
+
+// DiffChanged reports whether a diff contains any insertions or deletions
+func DiffChanged(diff []DiffLine) bool {
+	for _, line := range diff {
+		if line.Op != DiffEqual {
+			return true
+		}
+	}
+	return false
+}
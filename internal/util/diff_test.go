@@ -0,0 +1,127 @@
+package util
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// applyDiff rebuilds the old and new texts from a diff
+func applyDiff(diff []DiffLine) (oldLines, newLines []string) {
+	for _, line := range diff {
+		if line.Op != DiffInsert {
+			oldLines = append(oldLines, line.Text)
+		}
+		if line.Op != DiffDelete {
+			newLines = append(newLines, line.Text)
+		}
+	}
+	return oldLines, newLines
+}
+
+// lcsLength is the length of a longest common subsequence, by the quadratic table
+func lcsLength(a, b []string) int {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	return lcs[0][0]
+}
+
+// checkDiff checks that a diff turns a into b with as few edits as possible
+func checkDiff(t *testing.T, a, b []string, diff []DiffLine) {
+	t.Helper()
+	oldLines, newLines := applyDiff(diff)
+	if !slices.Equal(oldLines, a) || !slices.Equal(newLines, b) {
+		t.Fatalf("diff of %q and %q rebuilds %q and %q", a, b, oldLines, newLines)
+	}
+	equal := 0
+	for _, line := range diff {
+		if line.Op == DiffEqual {
+			equal++
+		}
+	}
+	if want := lcsLength(a, b); equal != want {
+		t.Fatalf("diff of %q and %q keeps %d lines, want %d", a, b, equal, want)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want []DiffLine
+	}{
+		{name: "both empty"},
+		{name: "insert into empty", b: []string{"x"}, want: []DiffLine{{DiffInsert, "x"}}},
+		{name: "delete all", a: []string{"x"}, want: []DiffLine{{DiffDelete, "x"}}},
+		{
+			name: "change in the middle",
+			a:    []string{"a", "b", "c"},
+			b:    []string{"a", "x", "c"},
+			want: []DiffLine{{DiffEqual, "a"}, {DiffDelete, "b"}, {DiffInsert, "x"}, {DiffEqual, "c"}},
+		},
+		{
+			name: "nothing in common",
+			a:    []string{"a", "b"},
+			b:    []string{"c", "d"},
+			want: []DiffLine{{DiffDelete, "a"}, {DiffDelete, "b"}, {DiffInsert, "c"}, {DiffInsert, "d"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := DiffLines(tt.a, tt.b)
+			if len(diff) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(diff, tt.want) {
+				t.Errorf("DiffLines() = %v, want %v", diff, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffLinesMinimal(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	randomLines := func() []string {
+		lines := make([]string, rng.Intn(12))
+		for i := range lines {
+			lines[i] = fmt.Sprint(rng.Intn(4))
+		}
+		return lines
+	}
+	for i := 0; i < 2000; i++ {
+		a, b := randomLines(), randomLines()
+		checkDiff(t, a, b, DiffLines(a, b))
+	}
+}
+
+func TestDiffLinesLarge(t *testing.T) {
+	a := make([]string, 5000)
+	b := make([]string, 5000)
+	for i := range a {
+		a[i] = fmt.Sprintf("old: %d", i)
+		b[i] = fmt.Sprintf("new: %d", i)
+	}
+	// A few lines in common keep the search from giving up straight away
+	for i := 0; i < len(a); i += 500 {
+		b[i] = a[i]
+	}
+
+	diff := DiffLines(a, b)
+	oldLines, newLines := applyDiff(diff)
+	if !slices.Equal(oldLines, a) || !slices.Equal(newLines, b) {
+		t.Fatal("diff of large texts doesn't rebuild them")
+	}
+}